@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lex00/cfn-lint-go/pkg/fix"
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// runFixes applies the fixes attached to the matches of each result. With
+// dryRun the changes are written to out as unified diffs. Otherwise the
+// fixed templates are written back and linted again, and their new results
+// replace the old ones. A summary goes to stderr.
+func runFixes(ctx context.Context, linter *lint.Linter, results []lint.FileResult, dryRun bool, out io.Writer) ([]lint.FileResult, error) {
+	fixed, skipped := 0, 0
+	var changed []int
+	for i, result := range results {
+		if result.Source == nil {
+			continue
+		}
+		applied := fix.Apply(result.Source, result.Matches)
+		if bytes.Equal(applied.Source, result.Source) {
+			continue
+		}
+		// Never write a template the fixes broke
+		if _, err := template.Parse(applied.Source); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: not fixing %s: the fixed template does not parse: %v\n", result.Filename, err)
+			continue
+		}
+
+		if dryRun {
+			fmt.Fprint(out, fix.Diff(result.Filename, result.Source, applied.Source))
+		} else if err := writeFixed(result.Filename, applied.Source); err != nil {
+			return nil, err
+		}
+		fixed += applied.Fixed
+		skipped += applied.Skipped
+		changed = append(changed, i)
+	}
+
+	verb := "Fixed"
+	if dryRun {
+		verb = "Would fix"
+	}
+	fmt.Fprintf(os.Stderr, "%s %d findings in %d files", verb, fixed, len(changed))
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, " (%d overlapping fixes skipped; run again to apply them)", skipped)
+	}
+	fmt.Fprintln(os.Stderr)
+
+	if dryRun || len(changed) == 0 {
+		return results, nil
+	}
+
+	paths := make([]string, len(changed))
+	for i, index := range changed {
+		paths[i] = results[index].Filename
+	}
+	for i, result := range linter.LintFilesContext(ctx, paths) {
+		results[changed[i]] = result
+	}
+	return results, nil
+}
+
+// writeFixed replaces a template with its fixed content, keeping the file
+// mode.
+func writeFixed(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("writing fixes: %w", err)
+	}
+	if err := os.WriteFile(path, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("writing fixes: %w", err)
+	}
+	return nil
+}
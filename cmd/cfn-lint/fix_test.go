@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+)
+
+func TestRunFixes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.yaml")
+	source := `Resources:
+  Fn:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: python3.7 # pinned
+      Handler: index.handler
+      Role: !Sub "arn:aws:iam::123456789012:role/fn"
+`
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := lint.New(lint.Options{IncludeRules: []string{"W1020", "W2531"}})
+	ctx := context.Background()
+	results := linter.LintFilesContext(ctx, []string{path})
+
+	var out bytes.Buffer
+	if _, err := runFixes(ctx, linter, results, true, &out); err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	diff := out.String()
+	for _, line := range []string{"-      Runtime: python3.7 # pinned", "+      Runtime: python3.12 # pinned", `+      Role: "arn:aws:iam::123456789012:role/fn"`} {
+		if !strings.Contains(diff, line+"\n") {
+			t.Errorf("Expected diff to contain %q, got:\n%s", line, diff)
+		}
+	}
+	if data, _ := os.ReadFile(path); string(data) != source {
+		t.Fatal("Dry run changed the template")
+	}
+
+	fixed, err := runFixes(ctx, linter, results, false, &out)
+	if err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "Runtime: python3.12 # pinned") {
+		t.Errorf("Expected the runtime to be fixed, got:\n%s", data)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected file mode to be kept, got %v", info.Mode().Perm())
+	}
+	for _, m := range fixed[0].Matches {
+		if m.Fix != nil {
+			t.Errorf("Expected no fixable matches after fixing, got %s: %s", m.Rule.ID, m.Message)
+		}
+	}
+}
@@ -2,19 +2,30 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/lex00/cfn-lint-go/pkg/baseline"
+	"github.com/lex00/cfn-lint-go/pkg/cache"
 	"github.com/lex00/cfn-lint-go/pkg/config"
 	"github.com/lex00/cfn-lint-go/pkg/docgen"
 	"github.com/lex00/cfn-lint-go/pkg/graph"
 	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/lsp"
 	"github.com/lex00/cfn-lint-go/pkg/output"
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/sam"
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -39,24 +50,60 @@ var version = "dev"
 
 func main() {
 	if err := rootCmd().Execute(); err != nil {
+		var exit exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// stdinTemplate is the template argument that reads from standard input.
+const stdinTemplate = "-"
+
+// exitError ends a lint run with a non-zero exit code after its output has
+// been written.
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit code %d", e.code)
+}
+
+// lintFlags holds the command-line flags of the lint command.
+type lintFlags struct {
+	format              string
+	outputFile          string
+	noColor             bool
+	filename            string
+	configFile          string
+	ignoreTemplates     []string
+	regions             []string
+	parameterFiles      []string
+	ignoreRules         []string
+	includeRules        []string
+	mandatoryChecks     []string
+	severity            map[string]string
+	includeExperimental bool
+	noSAMTransform      bool
+	showTransformed     bool
+	includeSuppressed   bool
+	jobs                int
+	ruleTimeout         time.Duration
+	nonZeroExitCode     string
+	baselineFile        string
+	writeBaseline       string
+	noCache             bool
+	clearCache          bool
+	watch               bool
+	fix                 bool
+	fixDryRun           bool
+}
+
 func rootCmd() *cobra.Command {
-	var (
-		format              string
-		outputFile          string
-		noColor             bool
-		configFile          string
-		regions             []string
-		ignoreRules         []string
-		includeRules        []string
-		includeExperimental bool
-		noSAMTransform      bool
-		showTransformed     bool
-	)
+	var flags lintFlags
 
 	cmd := &cobra.Command{
 		Use:   "cfn-lint [templates...]",
@@ -69,55 +116,190 @@ Examples:
     cfn-lint template.yaml --format sarif
     cfn-lint template.yaml --format junit --output results.xml
     cfn-lint template.yaml --format pretty
+    cfn-lint templates/ --watch                   # Re-lint templates as they change
+    cfn-lint templates/ --fix-dry-run             # Show available fixes as a diff
+    cfn-lint templates/ --fix                     # Apply fixes, then report what is left
+    generate-template | cfn-lint - --filename generated.yaml   # Lint standard input
     cfn-lint *.yaml --ignore-rules E1001,W3002
+    cfn-lint templates/ --ignore-templates 'templates/legacy/**'
+    cfn-lint 'stacks/**/*.yaml'                   # Quote globs to expand ** without shell support
+    cfn-lint template.yaml --non-zero-exit-code error   # Only errors fail the run
+    cfn-lint template.yaml --severity W3037=error --mandatory-checks W3037
+    cfn-lint templates/*.yaml --jobs 8
+    cfn-lint templates/*.yaml --no-cache         # Lint everything again, ignoring cached results
+    cfn-lint --clear-cache                        # Remove cached results
+    cfn-lint template.yaml --include-suppressed   # Report inline-suppressed matches
+    cfn-lint templates/*.yaml --write-baseline .cfnlint-baseline.json
+    cfn-lint templates/*.yaml --baseline .cfnlint-baseline.json  # Only report new findings
     cfn-lint template.yaml --config .cfnlintrc.yaml
+    cfn-lint template.yaml --regions us-east-1,cn-north-1,us-gov-west-1
+    cfn-lint template.yaml --regions ALL_REGIONS
+    cfn-lint template.yaml --parameter-files params/prod.json   # Check parameter values
     cfn-lint sam-template.yaml                    # Auto-detect and transform SAM
     cfn-lint sam-template.yaml --no-sam-transform # Lint SAM as-is (skip transform)
-    cfn-lint sam-template.yaml --show-transformed # Output transformed CloudFormation`,
+    cfn-lint sam-template.yaml --show-transformed # Output transformed CloudFormation
+    cfn-lint resolve template.yaml --parameters params/prod.json   # Output the template as deployed`,
 		Version: getVersion(),
 		Args:    cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLint(args, format, outputFile, configFile, noColor, regions, ignoreRules, includeRules, includeExperimental, noSAMTransform, showTransformed)
+			err := runLint(args, flags)
+			if errors.As(err, new(exitError)) {
+				// Findings are not usage errors; main sets the exit code
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
 		},
 	}
 
-	cmd.Flags().StringVarP(&format, "format", "f", "", "Output format: text, json, sarif, junit, pretty")
-	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write output to file instead of stdout")
-	cmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output (for pretty format)")
-	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Path to config file (.cfnlintrc)")
-	cmd.Flags().StringSliceVarP(&regions, "regions", "r", nil, "AWS regions to validate against")
-	cmd.Flags().StringSliceVarP(&ignoreRules, "ignore-rules", "i", nil, "Rule IDs to ignore (comma-separated)")
-	cmd.Flags().StringSliceVar(&includeRules, "include-checks", nil, "Rule IDs to include (even if ignored)")
-	cmd.Flags().BoolVar(&includeExperimental, "include-experimental", false, "Include experimental rules")
-	cmd.Flags().BoolVar(&noSAMTransform, "no-sam-transform", false, "Skip SAM to CloudFormation transformation (lint SAM templates as-is)")
-	cmd.Flags().BoolVar(&showTransformed, "show-transformed", false, "Output transformed CloudFormation template (for SAM debugging)")
+	cmd.Flags().StringVarP(&flags.format, "format", "f", "", "Output format: text, json, sarif, junit, pretty")
+	cmd.Flags().StringVarP(&flags.outputFile, "output", "o", "", "Write output to file instead of stdout")
+	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "Disable colored output (for pretty format)")
+	cmd.Flags().StringVar(&flags.filename, "filename", "", "File name to report for a template read from stdin (-)")
+	cmd.Flags().StringSliceVar(&flags.ignoreTemplates, "ignore-templates", nil, "Templates, directories or glob patterns to skip")
+	addRuleFlags(cmd, &flags)
+	cmd.Flags().BoolVar(&flags.showTransformed, "show-transformed", false, "Output transformed CloudFormation template (for SAM debugging)")
+	cmd.Flags().BoolVar(&flags.includeSuppressed, "include-suppressed", false, "Report matches suppressed by inline cfn-lint-disable comments")
+	cmd.Flags().IntVarP(&flags.jobs, "jobs", "j", 0, "Number of templates to lint in parallel (default: number of CPUs)")
+	cmd.Flags().StringVar(&flags.nonZeroExitCode, "non-zero-exit-code", "", "Lowest level that fails the run: informational (default), warning, error, none")
+	cmd.Flags().StringVar(&flags.baselineFile, "baseline", "", "Only report findings that are not in this baseline file")
+	cmd.Flags().StringVar(&flags.writeBaseline, "write-baseline", "", "Record the current findings in a baseline file and exit")
+	cmd.Flags().BoolVar(&flags.watch, "watch", false, "Keep running and re-lint templates and config as they change (pretty output)")
+	cmd.Flags().BoolVar(&flags.fix, "fix", false, "Apply fixes for fixable findings to the templates, then report the remaining findings")
+	cmd.Flags().BoolVar(&flags.fixDryRun, "fix-dry-run", false, "Print the fixes for fixable findings as a unified diff without changing templates")
+	cmd.Flags().BoolVar(&flags.noCache, "no-cache", false, "Do not read or write cached lint results (caching is on by default)")
+	cmd.Flags().BoolVar(&flags.clearCache, "clear-cache", false, "Remove cached lint results before linting")
 
 	cmd.AddCommand(graphCmd())
+	cmd.AddCommand(lspCmd())
+	cmd.AddCommand(resolveCmd())
 	cmd.AddCommand(listRulesCmd())
 	cmd.AddCommand(updateDocumentationCmd())
 
 	return cmd
 }
 
-func runLint(templates []string, format, outputFile, configFile string, noColor bool, regions []string, ignoreRules, includeRules []string, includeExperimental bool, noSAMTransform, showTransformed bool) error {
+// addRuleFlags adds the flags that decide which rules run on a template and
+// how, shared by the commands that lint: the config file, regions,
+// parameter files, rule selection, severity overrides, the SAM transform
+// and the rule timeout.
+func addRuleFlags(cmd *cobra.Command, flags *lintFlags) {
+	cmd.Flags().StringVarP(&flags.configFile, "config", "c", "", "Path to config file (.cfnlintrc)")
+	cmd.Flags().StringSliceVarP(&flags.regions, "regions", "r", nil, "AWS regions to validate against (ALL_REGIONS for every region)")
+	cmd.Flags().StringSliceVar(&flags.parameterFiles, "parameter-files", nil, "Stack parameter files to validate against the templates (CLI, template configuration or key/value JSON or YAML)")
+	cmd.Flags().StringSliceVarP(&flags.ignoreRules, "ignore-rules", "i", nil, "Rule IDs to ignore (comma-separated)")
+	cmd.Flags().StringSliceVar(&flags.includeRules, "include-checks", nil, "Rule IDs to include (even if ignored, experimental or opt-in)")
+	cmd.Flags().StringSliceVar(&flags.mandatoryChecks, "mandatory-checks", nil, "Rule IDs that always run and cannot be ignored or suppressed")
+	cmd.Flags().StringToStringVar(&flags.severity, "severity", nil, "Override rule levels, e.g. W3037=error,I3011=warning")
+	cmd.Flags().BoolVar(&flags.includeExperimental, "include-experimental", false, "Include experimental rules")
+	cmd.Flags().BoolVar(&flags.noSAMTransform, "no-sam-transform", false, "Skip SAM to CloudFormation transformation (lint SAM templates as-is)")
+	cmd.Flags().DurationVar(&flags.ruleTimeout, "rule-timeout", 0, "Maximum time a single rule may run on a template, e.g. 30s (default: no limit)")
+}
+
+// openCache opens the default result cache. Linting works without a cache,
+// so errors only produce a warning.
+func openCache(disabled bool) lint.ResultCache {
+	if disabled {
+		return nil
+	}
+	dir, err := cache.DefaultDir()
+	if err == nil {
+		var c *cache.Cache
+		if c, err = cache.Open(dir); err == nil {
+			return c
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Warning: result cache disabled: %v\n", err)
+	return nil
+}
+
+// clearCache removes every entry from the default result cache.
+func clearCache() error {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return err
+	}
+	c, err := cache.Open(dir)
+	if err != nil {
+		return err
+	}
+	return c.Clear()
+}
+
+// lintSetup is the effective configuration of a lint run.
+type lintSetup struct {
+	cfg        *config.Config // config file merged with the CLI flags
+	configPath string         // config file that was loaded, if any
+	templates  []string       // expanded template paths
+	options    lint.Options
+}
+
+// loadSetup loads the config file, merges the CLI flags into it and
+// resolves the templates to lint.
+func loadSetup(templates []string, flags lintFlags) (*lintSetup, error) {
+	setup, err := loadOptions(templates, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine templates to lint
+	if len(setup.cfg.Templates) == 0 {
+		return nil, fmt.Errorf("no templates specified")
+	}
+	templatesToLint, err := config.ExpandTemplates(setup.cfg.Templates, setup.cfg.IgnoreTemplates)
+	if err != nil {
+		return nil, err
+	}
+	if len(templatesToLint) == 0 {
+		return nil, fmt.Errorf("no templates found matching %s", strings.Join(setup.cfg.Templates, ", "))
+	}
+	setup.templates = templatesToLint
+	return setup, nil
+}
+
+// loadOptions is loadSetup without resolving the templates, for commands
+// such as lsp that are given the documents to lint.
+func loadOptions(templates []string, flags lintFlags) (*lintSetup, error) {
+	finalCfg, configPath, err := loadConfig(templates, flags)
+	if err != nil {
+		return nil, err
+	}
+	options, err := lintOptions(finalCfg, flags)
+	if err != nil {
+		return nil, err
+	}
+	return &lintSetup{
+		cfg:        finalCfg,
+		configPath: configPath,
+		options:    options,
+	}, nil
+}
+
+// loadConfig loads the config file given with --config, or the one found
+// by config.Find, and merges the CLI flags into it. It also returns the path
+// of the loaded file, or "" if there was none.
+func loadConfig(templates []string, flags lintFlags) (*config.Config, string, error) {
 	// Load config file if specified or found
 	var cfg *config.Config
-	if configFile != "" {
+	var configPath string
+	if flags.configFile != "" {
 		// Explicit config file
-		loadedCfg, err := config.Load(configFile)
+		loadedCfg, err := config.Load(flags.configFile)
 		if err != nil {
-			return fmt.Errorf("loading config file: %w", err)
+			return nil, "", fmt.Errorf("loading config file: %w", err)
 		}
 		cfg = loadedCfg
+		configPath = flags.configFile
 	} else {
 		// Try to find config file
 		foundPath, err := config.Find()
 		if err == nil {
 			loadedCfg, err := config.Load(foundPath)
 			if err != nil {
-				return fmt.Errorf("loading config file %s: %w", foundPath, err)
+				return nil, "", fmt.Errorf("loading config file %s: %w", foundPath, err)
 			}
 			cfg = loadedCfg
+			configPath = foundPath
 		} else {
 			// No config file found, use defaults
 			cfg = &config.Config{}
@@ -127,19 +309,30 @@ func runLint(templates []string, format, outputFile, configFile string, noColor
 	// Merge CLI flags with config (CLI takes precedence)
 	cliCfg := &config.Config{
 		Templates:           templates,
-		Regions:             regions,
-		IgnoreChecks:        ignoreRules,
-		IncludeChecks:       includeRules,
-		IncludeExperimental: includeExperimental,
-		Format:              format,
-		OutputFile:          outputFile,
+		IgnoreTemplates:     flags.ignoreTemplates,
+		Regions:             flags.regions,
+		ParameterFiles:      flags.parameterFiles,
+		IgnoreChecks:        flags.ignoreRules,
+		IncludeChecks:       flags.includeRules,
+		IncludeExperimental: flags.includeExperimental,
+		MandatoryChecks:     flags.mandatoryChecks,
+		SeverityOverrides:   flags.severity,
+		NonZeroExitCode:     flags.nonZeroExitCode,
+		Format:              flags.format,
+		OutputFile:          flags.outputFile,
+	}
+	return config.Merge(cfg, cliCfg), configPath, nil
+}
+
+// lintOptions validates the merged configuration and turns it into linter
+// options.
+func lintOptions(finalCfg *config.Config, flags lintFlags) (lint.Options, error) {
+	if err := regions.Validate(finalCfg.Regions); err != nil {
+		return lint.Options{}, fmt.Errorf("invalid regions: %w", err)
 	}
-	finalCfg := config.Merge(cfg, cliCfg)
 
-	// Determine templates to lint
-	templatesToLint := finalCfg.Templates
-	if len(templatesToLint) == 0 {
-		return fmt.Errorf("no templates specified")
+	if err := lint.ValidateFailLevel(finalCfg.NonZeroExitCode); err != nil {
+		return lint.Options{}, fmt.Errorf("invalid non-zero exit code: %w", err)
 	}
 
 	// Determine effective ignore rules (ignoreChecks - includeChecks)
@@ -156,8 +349,8 @@ func runLint(templates []string, format, outputFile, configFile string, noColor
 
 	// Determine if SAM transform should be disabled
 	// CLI flag takes precedence, then config, then default (false = transform enabled)
-	disableSAMTransform := noSAMTransform
-	if !noSAMTransform && finalCfg.SAM != nil {
+	disableSAMTransform := flags.noSAMTransform
+	if !flags.noSAMTransform && finalCfg.SAM != nil {
 		// If config specifies auto_transform: false, disable transform
 		disableSAMTransform = !finalCfg.SAM.AutoTransform
 	}
@@ -174,17 +367,88 @@ func runLint(templates []string, format, outputFile, configFile string, noColor
 		}
 	}
 
+	return lint.Options{
+		Regions:             finalCfg.Regions,
+		ParameterFiles:      finalCfg.ParameterFiles,
+		IgnoreRules:         effectiveIgnoreRules,
+		IncludeRules:        finalCfg.IncludeChecks,
+		IncludeExperimental: finalCfg.IncludeExperimental,
+		MandatoryChecks:     finalCfg.MandatoryChecks,
+		SeverityOverrides:   finalCfg.SeverityOverrides,
+		ConfigureRules:      finalCfg.ConfigureRules,
+		DisableSAMTransform: disableSAMTransform,
+		SAMTransformOptions: samOpts,
+		IncludeSuppressed:   flags.includeSuppressed,
+		Jobs:                flags.jobs,
+		RuleTimeout:         flags.ruleTimeout,
+	}, nil
+}
+
+func runLint(templates []string, flags lintFlags) error {
+	if flags.baselineFile != "" && flags.writeBaseline != "" {
+		return fmt.Errorf("--baseline and --write-baseline cannot be used together")
+	}
+	if flags.fix && flags.fixDryRun {
+		return fmt.Errorf("--fix and --fix-dry-run cannot be used together")
+	}
+	if flags.clearCache {
+		if err := clearCache(); err != nil {
+			return err
+		}
+		if len(templates) == 0 {
+			return nil
+		}
+	}
+
+	if flags.watch {
+		return runWatch(templates, flags)
+	}
+
+	setup, err := loadSetup(templates, flags)
+	if err != nil {
+		return err
+	}
+	finalCfg, templatesToLint := setup.cfg, setup.templates
+
+	// A "-" template is read from standard input
+	var stdin []byte
+	readStdin := false
+	for _, path := range templatesToLint {
+		if path == stdinTemplate {
+			if stdin, err = io.ReadAll(os.Stdin); err != nil {
+				return fmt.Errorf("reading standard input: %w", err)
+			}
+			readStdin = true
+		}
+	}
+	if readStdin && flags.fix {
+		return fmt.Errorf("--fix cannot write to a template read from stdin (-); use --fix-dry-run")
+	}
+	stdinName := stdinTemplate
+	if flags.filename != "" {
+		if !readStdin {
+			return fmt.Errorf("--filename can only be used when reading a template from stdin (-)")
+		}
+		stdinName = flags.filename
+	}
+
 	// Handle --show-transformed flag: output transformed template and exit
-	if showTransformed {
+	if flags.showTransformed {
 		for _, path := range templatesToLint {
-			tmpl, err := template.ParseFile(path)
+			var tmpl *template.Template
+			if path == stdinTemplate {
+				tmpl, err = template.Parse(stdin)
+				path = stdinName
+			} else {
+				tmpl, err = template.ParseFile(path)
+			}
 			if err != nil {
 				return fmt.Errorf("parsing %s: %w", path, err)
 			}
 
 			// Transform SAM template if applicable
-			if sam.IsSAMTemplate(tmpl) && !disableSAMTransform {
-				result, err := sam.Transform(tmpl, samOpts)
+			if sam.IsSAMTemplate(tmpl) && !setup.options.DisableSAMTransform {
+				result, err := sam.Transform(tmpl, setup.options.SAMTransformOptions)
 				if err != nil {
 					return fmt.Errorf("transforming SAM template %s: %w", path, err)
 				}
@@ -200,21 +464,68 @@ func runLint(templates []string, format, outputFile, configFile string, noColor
 		return nil
 	}
 
-	linter := lint.New(lint.Options{
-		Regions:             finalCfg.Regions,
-		IgnoreRules:         effectiveIgnoreRules,
-		IncludeExperimental: finalCfg.IncludeExperimental,
-		DisableSAMTransform: disableSAMTransform,
-		SAMTransformOptions: samOpts,
-	})
+	setup.options.Cache = openCache(flags.noCache)
+	linter := lint.New(setup.options)
 
-	var allMatches []lint.Match
+	// Stop linting cleanly on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var files []string
+	stdinIndex := -1
 	for _, path := range templatesToLint {
-		matches, err := linter.LintFile(path)
+		if path == stdinTemplate {
+			stdinIndex = len(files)
+			continue
+		}
+		files = append(files, path)
+	}
+	results := linter.LintFilesContext(ctx, files)
+	if readStdin {
+		results = slices.Insert(results, stdinIndex, linter.LintBytesResult(ctx, stdin, stdinName))
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			return fmt.Errorf("linting %s: %w", result.Filename, result.Err)
+		}
+	}
+
+	if flags.fixDryRun {
+		_, err := runFixes(ctx, linter, results, true, os.Stdout)
+		return err
+	}
+	if flags.fix {
+		if results, err = runFixes(ctx, linter, results, false, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	var allMatches []lint.Match
+	for _, result := range results {
+		if result.Err != nil {
+			return fmt.Errorf("linting %s: %w", result.Filename, result.Err)
+		}
+		allMatches = append(allMatches, result.Matches...)
+	}
+
+	if flags.writeBaseline != "" {
+		b := baseline.New(allMatches)
+		if err := b.Save(flags.writeBaseline); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d findings to baseline %s\n", len(b.Findings), flags.writeBaseline)
+		return nil
+	}
+
+	if flags.baselineFile != "" {
+		b, err := baseline.Load(flags.baselineFile)
 		if err != nil {
-			return fmt.Errorf("linting %s: %w", path, err)
+			return err
 		}
-		allMatches = append(allMatches, matches...)
+		result := b.Filter(allMatches)
+		allMatches = result.Matches
+		fmt.Fprintf(os.Stderr, "Baseline: %d existing findings hidden, %d fixed since baseline\n", result.Existing, result.Fixed)
 	}
 
 	// Determine output format
@@ -237,7 +548,19 @@ func runLint(templates []string, format, outputFile, configFile string, noColor
 		writer = f
 	}
 
-	return outputMatches(writer, allMatches, outFormat, noColor)
+	sources := output.SourcesFromResults(results)
+	if err := outputMatches(writer, allMatches, sources, outFormat, flags.noColor); err != nil {
+		return err
+	}
+
+	code, err := lint.ExitCode(allMatches, finalCfg.NonZeroExitCode)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return exitError{code: code}
+	}
+	return nil
 }
 
 // outputTransformedTemplate outputs a template as YAML to stdout.
@@ -373,13 +696,24 @@ func outputTransformedTemplate(tmpl *template.Template, sourcePath string) error
 	return nil
 }
 
-func outputMatches(w *os.File, matches []lint.Match, format string, noColor bool) error {
+func outputMatches(w io.Writer, matches []lint.Match, sources output.Sources, format string, noColor bool) error {
 	switch format {
 	case "text":
 		for _, m := range matches {
-			fmt.Fprintf(w, "%s:%d:%d: %s %s [%s]\n",
+			fmt.Fprintf(w, "%s:%d:%d: %s %s [%s]",
 				m.Location.Filename, m.Location.Start.LineNumber, m.Location.Start.ColumnNumber,
 				m.Level, m.Message, m.Rule.ID)
+			if len(m.Regions) > 0 {
+				fmt.Fprintf(w, " (%s)", strings.Join(m.Regions, ", "))
+			}
+			if m.Suppression != nil {
+				fmt.Fprint(w, " (suppressed")
+				if m.Suppression.Justification != "" {
+					fmt.Fprintf(w, ": %s", m.Suppression.Justification)
+				}
+				fmt.Fprint(w, ")")
+			}
+			fmt.Fprintln(w)
 		}
 	case "json":
 		// Ensure we output [] for empty slice, not null
@@ -400,16 +734,13 @@ func outputMatches(w *os.File, matches []lint.Match, format string, noColor bool
 			return fmt.Errorf("encoding JUnit: %w", err)
 		}
 	case "pretty":
-		if err := output.WritePretty(w, matches, noColor); err != nil {
+		if err := output.WritePrettyWithSources(w, matches, sources, noColor); err != nil {
 			return fmt.Errorf("writing pretty output: %w", err)
 		}
 	default:
 		return fmt.Errorf("unknown format: %s (valid: text, json, sarif, junit, pretty)", format)
 	}
 
-	if len(matches) > 0 {
-		os.Exit(2)
-	}
 	return nil
 }
 
@@ -442,6 +773,40 @@ The output can be rendered with Graphviz:
 	return cmd
 }
 
+func lspCmd() *cobra.Command {
+	var flags lintFlags
+
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a Language Server Protocol server over stdio",
+		Long: `Run a Language Server Protocol server on standard input and output.
+
+Editors start it as a language server for YAML and JSON CloudFormation
+templates. It publishes diagnostics for open documents as they change, and
+offers hover documentation and completion of resource types, properties and
+GetAtt attributes from the CloudFormation schema. Configuration is read from
+--config or the .cfnlintrc found from the working directory, and the rule
+flags (regions, parameter files, rule selection, mandatory checks, severity
+overrides and the rule timeout) work as they do when linting.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			setup, err := loadOptions(nil, flags)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			server := lsp.NewServer(lint.New(setup.options), getVersion())
+			return server.Serve(ctx, os.Stdin, os.Stdout)
+		},
+	}
+
+	addRuleFlags(cmd, &flags)
+
+	return cmd
+}
+
 func listRulesCmd() *cobra.Command {
 	var format string
 
@@ -453,21 +818,28 @@ func listRulesCmd() *cobra.Command {
 
 			if format == "json" {
 				type ruleInfo struct {
-					ID          string   `json:"id"`
-					ShortDesc   string   `json:"short_desc"`
-					Description string   `json:"description"`
-					Tags        []string `json:"tags"`
-					Source      string   `json:"source,omitempty"`
+					ID          string         `json:"id"`
+					ShortDesc   string         `json:"short_desc"`
+					Description string         `json:"description"`
+					Tags        []string       `json:"tags"`
+					Source      string         `json:"source,omitempty"`
+					Status      string         `json:"status"`
+					Options     []rules.Option `json:"options,omitempty"`
 				}
 				var ruleList []ruleInfo
 				for _, r := range allRules {
-					ruleList = append(ruleList, ruleInfo{
+					info := ruleInfo{
 						ID:          r.ID(),
 						ShortDesc:   r.ShortDesc(),
 						Description: r.Description(),
 						Tags:        r.Tags(),
 						Source:      r.Source(),
-					})
+						Status:      string(rules.StatusOf(r)),
+					}
+					if configurable, ok := r.(rules.ConfigurableRule); ok {
+						info.Options = configurable.Options()
+					}
+					ruleList = append(ruleList, info)
 				}
 				enc := json.NewEncoder(os.Stdout)
 				enc.SetIndent("", "  ")
@@ -481,14 +853,14 @@ func listRulesCmd() *cobra.Command {
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			_, _ = fmt.Fprintln(w, "RULE\tDESCRIPTION\tTAGS")
-			_, _ = fmt.Fprintln(w, "----\t-----------\t----")
+			_, _ = fmt.Fprintln(w, "RULE\tDESCRIPTION\tSTATUS\tTAGS")
+			_, _ = fmt.Fprintln(w, "----\t-----------\t------\t----")
 			for _, r := range allRules {
 				tags := ""
 				if len(r.Tags()) > 0 {
 					tags = fmt.Sprintf("%v", r.Tags())
 				}
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID(), r.ShortDesc(), tags)
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.ID(), r.ShortDesc(), rules.StatusOf(r), tags)
 			}
 			return w.Flush()
 		},
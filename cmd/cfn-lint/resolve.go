@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
+	"github.com/lex00/cfn-lint-go/pkg/eval"
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/output"
+	"github.com/lex00/cfn-lint-go/pkg/regions"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// resolveFlags holds the command-line flags of the resolve command.
+type resolveFlags struct {
+	parameterFile string
+	parameters    map[string]string
+	region        string
+	accountID     string
+	stackName     string
+	partition     string
+	format        string
+	lint          bool
+	configFile    string
+	noColor       bool
+}
+
+func resolveCmd() *cobra.Command {
+	var flags resolveFlags
+
+	cmd := &cobra.Command{
+		Use:   "resolve [template]",
+		Short: "Print a template as it would be deployed with a set of parameters",
+		Long: `Print a template with its intrinsic functions evaluated for one set of
+parameter values, region and account.
+
+Resources and outputs whose condition is false are removed, Fn::If is
+replaced by the branch it takes, Ref AWS::NoValue values are dropped and
+Ref, Fn::Sub, Fn::Join, Fn::FindInMap and the other functions that can be
+evaluated before deployment are replaced by their values. Functions that
+depend on deployed resources, such as Fn::GetAtt, are kept as written.
+Parameters without a value use their Default.
+
+With --lint the resolved template is linted instead of printed. Findings
+point at the lines of the source template they were resolved from.
+
+Examples:
+    cfn-lint resolve template.yaml --parameters params/prod.json --region eu-west-1
+    cfn-lint resolve template.yaml --parameter Env=prod --format json
+    cfn-lint resolve template.yaml --parameters deployments/prod.yaml   # Git sync deployment file
+    cfn-lint resolve template.yaml --parameters params/prod.json --lint`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// The arguments are valid; later errors are not usage errors
+			cmd.SilenceUsage = true
+			err := runResolve(os.Stdout, args[0], flags)
+			if errors.As(err, new(exitError)) {
+				cmd.SilenceErrors = true
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.parameterFile, "parameters", "", "Parameter file (CLI, template configuration, key/value or Git sync deployment file)")
+	cmd.Flags().StringToStringVar(&flags.parameters, "parameter", nil, "Parameter values, overriding the parameter file, e.g. Env=prod,Size=3")
+	cmd.Flags().StringVar(&flags.region, "region", "us-east-1", "Region to resolve AWS::Region, Fn::GetAZs and regional mappings for")
+	cmd.Flags().StringVar(&flags.accountID, "account-id", "", "Value of AWS::AccountId (default: left unresolved)")
+	cmd.Flags().StringVar(&flags.stackName, "stack-name", "", "Value of AWS::StackName (default: left unresolved)")
+	cmd.Flags().StringVar(&flags.partition, "partition", "", "Value of AWS::Partition (default: the region's partition)")
+	cmd.Flags().StringVarP(&flags.format, "format", "f", "", "Output format: yaml (default) or json; with --lint text (default), json, sarif, junit, pretty")
+	cmd.Flags().BoolVar(&flags.lint, "lint", false, "Lint the resolved template instead of printing it")
+	cmd.Flags().StringVarP(&flags.configFile, "config", "c", "", "Path to config file (.cfnlintrc), used with --lint")
+	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "Disable colored output (for pretty format)")
+
+	return cmd
+}
+
+// runResolve resolves a template and writes it, or its lint findings, to w.
+func runResolve(w io.Writer, path string, flags resolveFlags) error {
+	if err := regions.Validate([]string{flags.region}); err != nil {
+		return fmt.Errorf("invalid region: %w", err)
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading template: %w", err)
+	}
+	tmpl, err := template.Parse(source)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	tmpl.Filename = path
+
+	values, err := resolveParameters(flags)
+	if err != nil {
+		return err
+	}
+	for _, name := range tmpl.GetParameterNames() {
+		if _, ok := values[name]; !ok && tmpl.Parameters[name].Default == nil {
+			fmt.Fprintf(os.Stderr, "Warning: parameter '%s' has no value; references to it are left unresolved\n", name)
+		}
+	}
+
+	evaluator := eval.New(tmpl, eval.Options{
+		Parameters:  values,
+		UseDefaults: true,
+		Region:      flags.region,
+		AccountID:   flags.accountID,
+		StackName:   flags.stackName,
+		Partition:   flags.partition,
+	})
+	resolved, err := evaluator.ResolveTemplate()
+	if err != nil {
+		var evalErr *eval.Error
+		if errors.As(err, &evalErr) {
+			if loc := tmpl.ResolvePath(evalErr.Path); loc != nil && loc.Node() != nil {
+				return fmt.Errorf("%s:%d:%d: %s", path, loc.Node().Line, loc.Node().Column, err)
+			}
+		}
+		return fmt.Errorf("resolving %s: %w", path, err)
+	}
+
+	if flags.lint {
+		return lintResolved(w, resolved, path, source, flags)
+	}
+	return writeResolved(w, resolved, path, flags.format)
+}
+
+// resolveParameters reads the parameter values from --parameters and
+// --parameter. Values that keep the stack's previous value are left out.
+func resolveParameters(flags resolveFlags) (map[string]string, error) {
+	values := make(map[string]string)
+	if flags.parameterFile != "" {
+		data, err := os.ReadFile(flags.parameterFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading parameter file: %w", err)
+		}
+		var params *deployment.Parameters
+		var fileErrors []deployment.Error
+		if deployment.IsFile(data) {
+			file := deployment.ParseFile(flags.parameterFile, data)
+			params, fileErrors = file.Parameters, file.Errors
+		} else {
+			params = deployment.ParseParameters(flags.parameterFile, data)
+			fileErrors = params.Errors
+		}
+		if len(fileErrors) > 0 {
+			e := fileErrors[0]
+			return nil, fmt.Errorf("%s:%d:%d: %s", flags.parameterFile, e.Line, e.Column, e.Message)
+		}
+		for _, v := range params.Values {
+			if v.UsePreviousValue {
+				fmt.Fprintf(os.Stderr, "Warning: parameter '%s' uses its previous value, which is not known\n", v.Key)
+				continue
+			}
+			values[v.Key] = v.Value
+		}
+	}
+	for key, value := range flags.parameters {
+		values[key] = value
+	}
+	return values, nil
+}
+
+// writeResolved writes a resolved template as YAML or JSON.
+func writeResolved(w io.Writer, resolved *template.Template, path, format string) error {
+	switch format {
+	case "", "yaml":
+		fmt.Fprintf(w, "# Resolved template from: %s\n", path)
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(resolved.Root); err != nil {
+			return fmt.Errorf("serializing template: %w", err)
+		}
+		return enc.Close()
+	case "json":
+		data, err := json.MarshalIndent(template.DecodeNode(resolved.Root), "", "  ")
+		if err != nil {
+			return fmt.Errorf("serializing template: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	return fmt.Errorf("unknown format: %s (valid: yaml, json)", format)
+}
+
+// lintResolved lints a resolved template for the resolve region and writes
+// the findings, whose locations refer to the source template.
+func lintResolved(w io.Writer, resolved *template.Template, path string, source []byte, flags resolveFlags) error {
+	cfg, _, err := loadConfig(nil, lintFlags{configFile: flags.configFile})
+	if err != nil {
+		return err
+	}
+	options, err := lintOptions(cfg, lintFlags{})
+	if err != nil {
+		return err
+	}
+	// The template is resolved for one region, and its parameters already
+	// have their values
+	options.Regions = []string{flags.region}
+	options.ParameterFiles = nil
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	matches, err := lint.New(options).LintContext(ctx, resolved, path)
+	if err != nil {
+		return fmt.Errorf("linting %s: %w", path, err)
+	}
+
+	format := flags.format
+	if format == "" {
+		format = "text"
+	}
+	if err := outputMatches(w, matches, output.Sources{path: source}, format, flags.noColor); err != nil {
+		return err
+	}
+
+	code, err := lint.ExitCode(matches, cfg.NonZeroExitCode)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return exitError{code: code}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunResolve(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	tmplPath := write("template.yaml", `Parameters:
+  Env:
+    Type: String
+Mappings:
+  Images:
+    eu-west-1:
+      prod: ami-0123456789abcdef0
+      dev: ami-bad
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+Resources:
+  Instance:
+    Type: AWS::EC2::Instance
+    Properties:
+      ImageId: !FindInMap [Images, !Ref "AWS::Region", !Ref Env]
+      Monitoring: !If [IsProd, true, !Ref "AWS::NoValue"]
+  Alarm:
+    Type: AWS::SNS::Topic
+    Condition: IsProd
+`)
+	prod := write("prod.json", `[{"ParameterKey": "Env", "ParameterValue": "prod"}]`)
+	deploy := write("deploy.yaml", "template-file-path: template.yaml\nparameters:\n  Env: dev\n")
+	config := write(".cfnlintrc.yaml", "regions: [us-east-1]\n")
+
+	var out bytes.Buffer
+	if err := runResolve(&out, tmplPath, resolveFlags{parameterFile: prod, region: "eu-west-1"}); err != nil {
+		t.Fatalf("runResolve() error = %v", err)
+	}
+	for _, want := range []string{"ImageId: ami-0123456789abcdef0\n", "Monitoring: true\n", "  Alarm:\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Conditions") || strings.Contains(out.String(), "Mappings") {
+		t.Errorf("Expected resolved definitions to be removed, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runResolve(&out, tmplPath, resolveFlags{parameterFile: deploy, region: "eu-west-1", format: "json"}); err != nil {
+		t.Fatalf("runResolve() error = %v", err)
+	}
+	if !strings.Contains(out.String(), `"ImageId": "ami-bad"`) || strings.Contains(out.String(), "Alarm") {
+		t.Errorf("Expected the dev template as JSON, got:\n%s", out.String())
+	}
+
+	// Findings in the resolved template point at the source
+	out.Reset()
+	err := runResolve(&out, tmplPath, resolveFlags{parameters: map[string]string{"Env": "dev"}, region: "eu-west-1", lint: true, configFile: config})
+	if !errors.As(err, new(exitError)) {
+		t.Fatalf("Expected findings to fail the run, got %v", err)
+	}
+	if want := tmplPath + ":15:7: Error"; !strings.Contains(out.String(), want) {
+		t.Errorf("Expected a finding at %q, got:\n%s", want, out.String())
+	}
+
+	err = runResolve(&out, tmplPath, resolveFlags{parameters: map[string]string{"Env": "prod"}, region: "us-east-1"})
+	if err == nil || !strings.Contains(err.Error(), tmplPath+":15:16: ") {
+		t.Errorf("Expected the FindInMap error at its source position, got %v", err)
+	}
+}
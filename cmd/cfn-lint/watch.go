@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/lex00/cfn-lint-go/pkg/baseline"
+	"github.com/lex00/cfn-lint-go/pkg/config"
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/output"
+)
+
+// watchInterval is how often watch mode checks templates and config for
+// changes.
+const watchInterval = 500 * time.Millisecond
+
+// rescanInterval is how often watch mode expands the templates again when
+// none of the watched files changed, to pick up templates added to
+// subdirectories of a watched directory and newly created config files.
+const rescanInterval = 10 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// fileState identifies a version of a file by size and modification time.
+// Missing files have the zero state.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{size: info.Size(), modTime: info.ModTime()}
+}
+
+// watcher keeps lint results for a set of templates up to date.
+type watcher struct {
+	args  []string
+	flags lintFlags
+	cache lint.ResultCache
+
+	setup    *lintSetup
+	err      error       // why the setup could not be loaded
+	inputs   []fileState // config and parameter files
+	bstate   fileState   // baseline file when it was loaded
+	scanned  time.Time   // when the templates were last expanded
+	watched  []string    // files and directories that trigger a rescan
+	wstates  []fileState // states of watched at the last scan
+	linter   *lint.Linter
+	baseline *baseline.Baseline
+	states   map[string]fileState
+	deps     map[string][]fileState // dependencies of each result
+	results  map[string]lint.FileResult
+	linted   int // templates linted by the last poll
+}
+
+// poll lints the templates that are new or changed, or whose dependencies,
+// such as the template of a deployment file, changed. The configuration is
+// reloaded and the templates expanded again only when a template, the
+// config, a parameter or baseline file or a watched directory changed, or
+// every rescanInterval; otherwise poll only stats files. A changed config
+// or parameter file re-lints every template. poll reports whether the
+// output needs to be redrawn.
+func (w *watcher) poll(ctx context.Context) bool {
+	w.linted = 0
+
+	// Record the states before linting so edits made meanwhile are picked
+	// up by the next poll
+	states := make(map[string]fileState)
+	if w.setup != nil {
+		for _, path := range w.setup.templates {
+			states[path] = statFile(path)
+		}
+	}
+
+	changed := false
+	if w.needsScan(states) {
+		var ok bool
+		if changed, ok = w.scan(); !ok {
+			return changed
+		}
+		for _, path := range w.setup.templates {
+			if _, ok := states[path]; !ok {
+				states[path] = statFile(path)
+			}
+		}
+	} else if w.setup == nil {
+		return false // the setup failed and nothing it depends on changed
+	}
+
+	var stale []string
+	for _, path := range w.setup.templates {
+		state := states[path]
+		result, ok := w.results[path]
+		if !ok || w.states[path] != state || !slices.Equal(dependencyStates(result), w.deps[path]) {
+			w.states[path] = state
+			stale = append(stale, path)
+		}
+	}
+	for _, result := range w.linter.LintFilesContext(ctx, stale) {
+		w.results[result.Filename] = result
+		w.deps[result.Filename] = dependencyStates(result)
+	}
+	w.linted = len(stale)
+
+	return changed || len(stale) > 0
+}
+
+// needsScan reports whether the templates must be expanded again: on the
+// first poll, after rescanInterval, or when a watched file or one of the
+// current templates, with the given states, changed. A changed template
+// may have stopped being a template or, as a deployment file, name another
+// one.
+func (w *watcher) needsScan(states map[string]fileState) bool {
+	if w.scanned.IsZero() || time.Since(w.scanned) >= rescanInterval {
+		return true
+	}
+	if !slices.Equal(statFiles(w.watched), w.wstates) {
+		return true
+	}
+	for path, state := range states {
+		if w.states[path] != state {
+			return true
+		}
+	}
+	return false
+}
+
+// scan reloads the configuration and expands the templates again, starting
+// over when the config or a parameter file changed, reloading a changed
+// baseline file and dropping the results of templates that are gone. It reports whether the output needs to be
+// redrawn, and whether the setup loaded.
+func (w *watcher) scan() (changed, ok bool) {
+	w.scanned = time.Now()
+	setup, err := loadSetup(w.args, w.flags)
+	w.watch(setup)
+	if err != nil {
+		return w.fail(err), false
+	}
+
+	var inputs []fileState
+	if setup.configPath != "" {
+		inputs = append(inputs, statFile(setup.configPath))
+	}
+	for _, path := range setup.options.ParameterFiles {
+		inputs = append(inputs, statFile(path))
+	}
+	if w.setup == nil || !slices.Equal(inputs, w.inputs) {
+		w.reset(setup)
+		w.inputs = inputs
+	}
+	if changed, err = w.loadBaseline(); err != nil {
+		return w.fail(err), false
+	}
+	w.setup, w.err = setup, nil
+
+	for path := range w.results {
+		if !slices.Contains(setup.templates, path) {
+			delete(w.results, path)
+			delete(w.states, path)
+			delete(w.deps, path)
+			changed = true
+		}
+	}
+	return changed, true
+}
+
+// fail records why the setup could not be loaded. It reports whether the
+// output needs to be redrawn, which it does not for the same error again.
+func (w *watcher) fail(err error) bool {
+	changed := w.err == nil || w.err.Error() != err.Error()
+	w.setup, w.err = nil, err
+	return changed
+}
+
+// watch records the files whose changes trigger a rescan: the config,
+// parameter and baseline files, the roots of the template patterns and the
+// directories of the current templates. Without a setup, the template
+// arguments and the --config file are watched.
+func (w *watcher) watch(setup *lintSetup) {
+	var paths []string
+	if setup != nil {
+		if setup.configPath != "" {
+			paths = append(paths, setup.configPath)
+		}
+		paths = append(paths, setup.options.ParameterFiles...)
+		for _, pattern := range setup.cfg.Templates {
+			paths = append(paths, config.PatternRoot(pattern))
+		}
+		for _, path := range setup.templates {
+			paths = append(paths, filepath.Dir(path))
+		}
+	} else {
+		if w.flags.configFile != "" {
+			paths = append(paths, w.flags.configFile)
+		}
+		for _, pattern := range w.args {
+			paths = append(paths, config.PatternRoot(pattern))
+		}
+	}
+	if w.flags.baselineFile != "" {
+		paths = append(paths, w.flags.baselineFile)
+	}
+
+	seen := make(map[string]bool)
+	w.watched = w.watched[:0]
+	for _, path := range paths {
+		if !seen[path] {
+			seen[path] = true
+			w.watched = append(w.watched, path)
+		}
+	}
+	w.wstates = statFiles(w.watched)
+}
+
+// reset starts over with a new configuration: a new linter and no results.
+func (w *watcher) reset(setup *lintSetup) {
+	setup.options.Cache = w.cache
+	w.linter = lint.New(setup.options)
+	w.states = make(map[string]fileState)
+	w.deps = make(map[string][]fileState)
+	w.results = make(map[string]lint.FileResult)
+}
+
+// loadBaseline loads the baseline file when it is new or changed. Results
+// are filtered when drawn, so a changed baseline needs no re-linting. It
+// reports whether the baseline was reloaded.
+func (w *watcher) loadBaseline() (bool, error) {
+	if w.flags.baselineFile == "" {
+		return false, nil
+	}
+	state := statFile(w.flags.baselineFile)
+	if w.baseline != nil && state == w.bstate {
+		return false, nil
+	}
+	b, err := baseline.Load(w.flags.baselineFile)
+	if err != nil {
+		return false, err
+	}
+	w.baseline, w.bstate = b, state
+	return true, nil
+}
+
+// statFiles returns the states of files.
+func statFiles(paths []string) []fileState {
+	states := make([]fileState, 0, len(paths))
+	for _, path := range paths {
+		states = append(states, statFile(path))
+	}
+	return states
+}
+
+// dependencyStates returns the states of the files a result depends on.
+func dependencyStates(result lint.FileResult) []fileState {
+	var states []fileState
+	for _, path := range result.Dependencies {
+		states = append(states, statFile(path))
+	}
+	return states
+}
+
+// render draws the current results in pretty format, followed by a status
+// line. When clear is set the terminal is cleared first.
+func (w *watcher) render(out io.Writer, clear bool) {
+	if clear {
+		fmt.Fprint(out, clearScreen)
+	} else {
+		fmt.Fprintln(out)
+	}
+
+	if w.err != nil {
+		fmt.Fprintf(out, "Error: %v\n", w.err)
+		fmt.Fprintf(out, "\n[%s] Waiting for changes (Ctrl-C to stop)\n", time.Now().Format(time.TimeOnly))
+		return
+	}
+
+	results := make([]lint.FileResult, 0, len(w.setup.templates))
+	var matches []lint.Match
+	for _, path := range w.setup.templates {
+		result := w.results[path]
+		if result.Err != nil {
+			fmt.Fprintf(out, "Error linting %s: %v\n", path, result.Err)
+		}
+		results = append(results, result)
+		matches = append(matches, result.Matches...)
+	}
+	hidden := 0
+	if w.baseline != nil {
+		filtered := w.baseline.Filter(matches)
+		matches, hidden = filtered.Matches, filtered.Existing
+	}
+
+	if err := output.WritePrettyWithSources(out, matches, output.SourcesFromResults(results), w.flags.noColor); err != nil {
+		fmt.Fprintf(out, "Error: %v\n", err)
+	}
+
+	fmt.Fprintf(out, "\n[%s] Linted %d of %d templates", time.Now().Format(time.TimeOnly), w.linted, len(w.setup.templates))
+	if hidden > 0 {
+		fmt.Fprintf(out, ", %d baseline findings hidden", hidden)
+	}
+	fmt.Fprintln(out, ". Watching for changes (Ctrl-C to stop)")
+}
+
+// runWatch lints templates, then keeps polling them and the config file
+// and redraws the results whenever something changes, until interrupted.
+func runWatch(templates []string, flags lintFlags) error {
+	switch {
+	case slices.Contains(templates, stdinTemplate):
+		return fmt.Errorf("--watch cannot read templates from stdin (-)")
+	case flags.writeBaseline != "":
+		return fmt.Errorf("--watch cannot be used with --write-baseline")
+	case flags.showTransformed:
+		return fmt.Errorf("--watch cannot be used with --show-transformed")
+	case flags.fix || flags.fixDryRun:
+		return fmt.Errorf("--watch cannot be used with --fix or --fix-dry-run")
+	case flags.outputFile != "":
+		return fmt.Errorf("--watch writes to the terminal and cannot be used with --output")
+	case flags.format != "" && flags.format != "pretty":
+		return fmt.Errorf("--watch only supports the pretty format")
+	}
+
+	// Only redraw in place on a terminal
+	clear := false
+	if info, err := os.Stdout.Stat(); err == nil {
+		clear = info.Mode()&os.ModeCharDevice != 0
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	w := &watcher{args: templates, flags: flags, cache: openCache(flags.noCache)}
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		if w.poll(ctx) && ctx.Err() == nil {
+			w.render(os.Stdout, clear)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lex00/cfn-lint-go/pkg/baseline"
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	dir := t.TempDir()
+	step := 0
+	// touch makes sure the modification time moves even on coarse clocks
+	touch := func(path string) {
+		t.Helper()
+		step++
+		later := time.Now().Add(time.Duration(step) * time.Second)
+		if err := os.Chtimes(path, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		touch(path)
+		touch(dir)
+	}
+	template := "Resources:\n  Topic:\n    Type: AWS::SNS::Topic\n"
+	write("a.yaml", template)
+	write(".cfnlintrc.yaml", "regions: [us-east-1]\nparameter_files: [params.json]\n")
+	write("params.json", "{}\n")
+
+	w := &watcher{
+		args:  []string{filepath.Join(dir, "*.yaml")},
+		flags: lintFlags{configFile: filepath.Join(dir, ".cfnlintrc.yaml"), noColor: true},
+	}
+	ctx := context.Background()
+
+	if !w.poll(ctx) || w.linted != 1 {
+		t.Fatalf("Expected first poll to lint 1 template, linted %d", w.linted)
+	}
+	scanned := w.scanned
+	if w.poll(ctx) || w.linted != 0 {
+		t.Errorf("Expected no changes, linted %d", w.linted)
+	}
+	if w.scanned != scanned {
+		t.Error("Expected an unchanged poll not to expand the templates again")
+	}
+
+	write("b.yaml", template)
+	if !w.poll(ctx) || w.linted != 1 {
+		t.Errorf("Expected only the new template to be linted, linted %d", w.linted)
+	}
+
+	write("a.yaml", template+"Outputs: {}\n")
+	if !w.poll(ctx) || w.linted != 1 {
+		t.Errorf("Expected only the changed template to be linted, linted %d", w.linted)
+	}
+
+	write(".cfnlintrc.yaml", "regions: [us-west-2]\nparameter_files: [params.json]\n")
+	if !w.poll(ctx) || w.linted != 2 {
+		t.Errorf("Expected a config change to re-lint every template, linted %d", w.linted)
+	}
+
+	write("params.json", "{\"Env\": \"prod\"}\n")
+	if !w.poll(ctx) || w.linted != 2 {
+		t.Errorf("Expected a parameter file change to re-lint every template, linted %d", w.linted)
+	}
+
+	write("deploy.yaml", "template-file-path: a.yaml\n")
+	if !w.poll(ctx) || w.linted != 1 {
+		t.Errorf("Expected only the new deployment file to be linted, linted %d", w.linted)
+	}
+	write("a.yaml", template)
+	if !w.poll(ctx) || w.linted != 2 {
+		t.Errorf("Expected a template change to re-lint its deployment file, linted %d", w.linted)
+	}
+	if err := os.Remove(filepath.Join(dir, "deploy.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "b.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	touch(dir)
+	if !w.poll(ctx) || len(w.results) != 1 {
+		t.Errorf("Expected removed template to be dropped, have %d results", len(w.results))
+	}
+
+	var out bytes.Buffer
+	w.render(&out, false)
+	if !strings.Contains(out.String(), "of 1 templates. Watching for changes") {
+		t.Errorf("Expected status line, got:\n%s", out.String())
+	}
+}
+
+func TestWatcherPoll_SetupError(t *testing.T) {
+	dir := t.TempDir()
+	w := &watcher{args: []string{filepath.Join(dir, "*.yaml")}, flags: lintFlags{noColor: true}}
+
+	if !w.poll(context.Background()) || w.err == nil {
+		t.Fatal("Expected an error when no templates match")
+	}
+	if w.poll(context.Background()) {
+		t.Error("Expected the same error not to redraw")
+	}
+
+	var out bytes.Buffer
+	w.render(&out, false)
+	if !strings.Contains(out.String(), "no templates found") {
+		t.Errorf("Expected error in output, got:\n%s", out.String())
+	}
+
+	path := filepath.Join(dir, "a.yaml")
+	if err := os.WriteFile(path, []byte("Resources:\n  Topic:\n    Type: AWS::SNS::Topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !w.poll(context.Background()) || w.err != nil || w.linted != 1 {
+		t.Errorf("Expected new template to be linted, err %v", w.err)
+	}
+}
+
+func TestWatcherPoll_Baseline(t *testing.T) {
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "a.yaml")
+	if err := os.WriteFile(tmplPath, []byte("Parameters:\n  Unused:\n    Type: String\nResources:\n  Topic:\n    Type: AWS::SNS::Topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	baselinePath := filepath.Join(dir, "baseline.json")
+	if err := baseline.New(nil).Save(baselinePath); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &watcher{args: []string{tmplPath}, flags: lintFlags{baselineFile: baselinePath, noColor: true}}
+	ctx := context.Background()
+	if !w.poll(ctx) || w.linted != 1 {
+		t.Fatalf("Expected first poll to lint 1 template, linted %d", w.linted)
+	}
+	var out bytes.Buffer
+	w.render(&out, false)
+	if strings.Contains(out.String(), "baseline findings hidden") {
+		t.Fatalf("Expected an empty baseline to hide nothing, got:\n%s", out.String())
+	}
+
+	// Record the current findings, as --write-baseline would
+	var matches []lint.Match
+	for _, result := range w.results {
+		matches = append(matches, result.Matches...)
+	}
+	if len(matches) == 0 {
+		t.Fatal("Expected the unused parameter to be reported")
+	}
+	if err := baseline.New(matches).Save(baselinePath); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(baselinePath, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if !w.poll(ctx) || w.linted != 0 {
+		t.Errorf("Expected a baseline change to redraw without re-linting, linted %d", w.linted)
+	}
+	out.Reset()
+	w.render(&out, false)
+	if !strings.Contains(out.String(), "baseline findings hidden") {
+		t.Errorf("Expected the rewritten baseline to hide the findings, got:\n%s", out.String())
+	}
+}
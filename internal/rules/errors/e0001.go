@@ -11,7 +11,9 @@ func init() {
 }
 
 // E0001 checks for template transformation errors.
-// This rule is triggered when AWS::Include or other transforms fail.
+// This rule is triggered when AWS::Include or other transforms fail, such as
+// an Fn::ForEach loop of the AWS::LanguageExtensions transform that cannot
+// be expanded.
 type E0001 struct{}
 
 func (r *E0001) ID() string { return "E0001" }
@@ -21,7 +23,7 @@ func (r *E0001) ShortDesc() string {
 }
 
 func (r *E0001) Description() string {
-	return "Checks for errors during template transformation (e.g., AWS::Include, AWS::Serverless)."
+	return "Checks for errors during template transformation (e.g., AWS::Include, AWS::Serverless, AWS::LanguageExtensions)."
 }
 
 func (r *E0001) Source() string {
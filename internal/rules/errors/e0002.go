@@ -33,7 +33,8 @@ func (r *E0002) Tags() []string {
 }
 
 func (r *E0002) Match(tmpl *template.Template) []rules.Match {
-	// Rule processing errors are caught at runtime by the linter.
-	// This rule exists for documentation purposes.
+	// Rule processing errors are reported by the linter when a rule
+	// panics or exceeds the rule timeout. This rule exists for
+	// documentation purposes.
 	return nil
 }
@@ -2,6 +2,7 @@
 package errors
 
 import (
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -10,9 +11,8 @@ func init() {
 	rules.Register(&E0100{})
 }
 
-// E0100 checks deployment file syntax validation.
-// Note: This rule is registered but currently returns no matches as deployment file
-// validation requires separate file parsing infrastructure not yet implemented.
+// E0100 checks that a Git sync deployment file has a valid structure and
+// names a template that can be linted.
 type E0100 struct{}
 
 func (r *E0100) ID() string { return "E0100" }
@@ -23,21 +23,40 @@ func (r *E0100) ShortDesc() string {
 
 func (r *E0100) Description() string {
 	return "Validate if a deployment file has the correct syntax for one of the supported formats. " +
-		"Deployment files are used to configure template deployment parameters and are separate from CloudFormation templates."
+		"Deployment files are used to configure template deployment parameters and are separate from CloudFormation templates. " +
+		"Git sync deployment files must have a template-file-path naming a readable template, and may have parameters and tags mappings of strings."
 }
 
 func (r *E0100) Source() string {
-	return "https://github.com/aws-cloudformation/cfn-lint/blob/main/docs/rules.md"
+	return "https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/git-sync-concepts-terms.html"
 }
 
 func (r *E0100) Tags() []string {
 	return []string{"base", "deployment"}
 }
 
+// Match returns no matches: E0100 only checks deployment files, through
+// MatchDeployment.
 func (r *E0100) Match(tmpl *template.Template) []rules.Match {
-	// Deployment file validation requires separate file parsing infrastructure.
-	// This rule is registered for compatibility but does not perform validation
-	// on CloudFormation templates directly. It would need to be invoked with
-	// deployment-specific file parsers.
 	return nil
 }
+
+// MatchDeployment reports the structure errors in a deployment file,
+// including a template-file-path whose template cannot be loaded.
+func (r *E0100) MatchDeployment(tmpl *template.Template, file *deployment.File) []rules.Match {
+	var matches []rules.Match
+	for _, e := range file.Errors {
+		line, column := e.Line, e.Column
+		if line == 0 {
+			line, column = 1, 1
+		}
+		matches = append(matches, rules.Match{
+			Message:  e.Message,
+			Line:     line,
+			Column:   column,
+			Path:     e.Path,
+			Filename: file.Filename,
+		})
+	}
+	return matches
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -52,3 +53,28 @@ Resources:
 		t.Errorf("Expected 0 matches for template (E0100 validates deployment files, not templates), got %d", len(matches))
 	}
 }
+
+func TestE0100_MatchDeployment(t *testing.T) {
+	rule := &E0100{}
+	tmpl := &template.Template{}
+
+	valid := deployment.ParseFile("deploy.yaml", []byte("template-file-path: app.yaml\nparameters:\n  Env: prod\ntags:\n  Team: web\n"))
+	if matches := rule.MatchDeployment(tmpl, valid); len(matches) != 0 {
+		t.Errorf("Expected 0 matches for a valid deployment file, got %+v", matches)
+	}
+
+	invalid := deployment.ParseFile("deploy.yaml", []byte("template-file-path: app.yaml\ntags:\n  aws:team: web\n"))
+	matches := rule.MatchDeployment(tmpl, invalid)
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %+v", matches)
+	}
+	if m := matches[0]; m.Filename != "deploy.yaml" || m.Line != 3 || m.Column != 3 {
+		t.Errorf("Expected match at deploy.yaml:3:3, got %s:%d:%d", m.Filename, m.Line, m.Column)
+	}
+
+	unreadable := &deployment.File{Filename: "deploy.yaml", Errors: []deployment.Error{{Message: "reading deployment file: missing"}}}
+	matches = rule.MatchDeployment(tmpl, unreadable)
+	if len(matches) != 1 || matches[0].Line != 1 || matches[0].Column != 1 {
+		t.Errorf("Expected a match at 1:1 for an unreadable file, got %+v", matches)
+	}
+}
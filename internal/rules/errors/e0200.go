@@ -2,6 +2,7 @@
 package errors
 
 import (
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -10,9 +11,8 @@ func init() {
 	rules.Register(&E0200{})
 }
 
-// E0200 checks parameter file syntax validation.
-// Note: This rule is registered but currently returns no matches as parameter file
-// validation requires separate file parsing infrastructure not yet implemented.
+// E0200 checks that parameter files given with --parameter-files or the
+// parameter_files config option can be read in a supported format.
 type E0200 struct{}
 
 func (r *E0200) ID() string { return "E0200" }
@@ -34,10 +34,27 @@ func (r *E0200) Tags() []string {
 	return []string{"base", "parameters"}
 }
 
+// Match returns no matches: E0200 only checks parameter files, through
+// MatchParameters.
 func (r *E0200) Match(tmpl *template.Template) []rules.Match {
-	// Parameter file validation requires separate file parsing infrastructure.
-	// This rule is registered for compatibility but does not perform validation
-	// on CloudFormation templates directly. It would need to be invoked with
-	// parameter file-specific parsers.
 	return nil
 }
+
+// MatchParameters reports the syntax errors in a parameter file.
+func (r *E0200) MatchParameters(tmpl *template.Template, params *deployment.Parameters) []rules.Match {
+	var matches []rules.Match
+	for _, e := range params.Errors {
+		line, column := e.Line, e.Column
+		if line == 0 {
+			line, column = 1, 1
+		}
+		matches = append(matches, rules.Match{
+			Message:  e.Message,
+			Line:     line,
+			Column:   column,
+			Path:     e.Path,
+			Filename: params.Filename,
+		})
+	}
+	return matches
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -55,3 +56,31 @@ Resources:
 		t.Errorf("Expected 0 matches for template (E0200 validates parameter files, not templates), got %d", len(matches))
 	}
 }
+
+func TestE0200_MatchParameters(t *testing.T) {
+	tmpl, err := template.Parse([]byte("Resources:\n  MyBucket:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	rule := &E0200{}
+
+	valid := deployment.ParseParameters("params.json", []byte(`[{"ParameterKey": "Env", "ParameterValue": "prod"}]`))
+	if matches := rule.MatchParameters(tmpl, valid); len(matches) != 0 {
+		t.Errorf("Expected 0 matches for a valid parameter file, got %+v", matches)
+	}
+
+	invalid := deployment.ParseParameters("params.json", []byte("[{\"ParameterKey\": \"Env\", \"ParameterValue\": \"prod\"},\n {\"ParameterKey\": \"Size\"}]"))
+	matches := rule.MatchParameters(tmpl, invalid)
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %+v", matches)
+	}
+	if m := matches[0]; m.Filename != "params.json" || m.Line != 2 || m.Column != 2 {
+		t.Errorf("Expected match at params.json:2:2, got %s:%d:%d", m.Filename, m.Line, m.Column)
+	}
+
+	unreadable := &deployment.Parameters{Filename: "missing.json", Errors: []deployment.Error{{Message: "reading parameter file: not found"}}}
+	matches = rule.MatchParameters(tmpl, unreadable)
+	if len(matches) != 1 || matches[0].Line != 1 || matches[0].Column != 1 {
+		t.Errorf("Expected a read error at 1:1, got %+v", matches)
+	}
+}
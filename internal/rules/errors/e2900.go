@@ -2,6 +2,14 @@
 package errors
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -10,9 +18,8 @@ func init() {
 	rules.Register(&E2900{})
 }
 
-// E2900 validates deployment file parameters.
-// Note: This rule is registered but currently returns no matches as deployment file
-// validation requires separate file parsing infrastructure not yet implemented.
+// E2900 checks the values in parameter files against the template's
+// Parameters.
 type E2900 struct{}
 
 func (r *E2900) ID() string { return "E2900" }
@@ -22,35 +29,159 @@ func (r *E2900) ShortDesc() string {
 }
 
 func (r *E2900) Description() string {
-	return "Validate that parameters defined in deployment files (e.g., AWS SAM deploy configuration) " +
+	return "Validate that parameters defined in deployment files (e.g., parameter files) " +
 		"match the parameters defined in the CloudFormation template. This ensures that all required " +
-		"parameters are provided and no extra parameters are specified."
+		"parameters are provided, no extra parameters are specified, and values satisfy the parameter's " +
+		"AllowedValues, AllowedPattern, MinLength/MaxLength and MinValue/MaxValue constraints."
 }
 
 func (r *E2900) Source() string {
-	return "https://docs.aws.amazon.com/serverless-application-model/latest/developerguide/serverless-deploying.html"
+	return "https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/parameters-section-structure.html"
 }
 
 func (r *E2900) Tags() []string {
 	return []string{"parameters", "deployment"}
 }
 
+// Match returns no matches: E2900 only checks parameter files, through
+// MatchParameters.
 func (r *E2900) Match(tmpl *template.Template) []rules.Match {
-	// Deployment file parameter validation requires separate file parsing infrastructure.
-	// This rule is registered for compatibility but does not perform validation
-	// on CloudFormation templates directly. It would need to be invoked with
-	// deployment configuration file parsers (e.g., samconfig.toml, parameter overrides).
-	//
-	// Future implementation would:
-	// 1. Parse deployment configuration files (samconfig.toml, parameter JSON files)
-	// 2. Extract parameter overrides from deployment configuration
-	// 3. Validate against template.Parameters map
-	// 4. Check for missing required parameters (those without defaults)
-	// 5. Check for extra parameters not defined in template
-	//
-	// Example validation logic:
-	// - Ensure all required template parameters are provided
-	// - Ensure no undefined parameters are specified
-	// - Validate parameter value types match template constraints
 	return nil
 }
+
+// MatchParameters checks the values of a parameter file. Files in an
+// unrecognized format are left to E0200.
+func (r *E2900) MatchParameters(tmpl *template.Template, params *deployment.Parameters) []rules.Match {
+	if params.Format == "" {
+		return nil
+	}
+
+	var matches []rules.Match
+	given := make(map[string]bool)
+	for _, v := range params.Values {
+		given[v.Key] = true
+		param, ok := tmpl.Parameters[v.Key]
+		if !ok {
+			matches = append(matches, rules.Match{
+				Message:  fmt.Sprintf("Parameter '%s' is not defined in the template", v.Key),
+				Line:     v.KeyNode.Line,
+				Column:   v.KeyNode.Column,
+				Path:     v.KeyPath,
+				Filename: params.Filename,
+			})
+			continue
+		}
+		if v.UsePreviousValue {
+			continue
+		}
+		for _, message := range checkParameterValue(v.Key, param, v.Value) {
+			matches = append(matches, rules.Match{
+				Message:  message,
+				Line:     v.ValueNode.Line,
+				Column:   v.ValueNode.Column,
+				Path:     v.ValuePath,
+				Filename: params.Filename,
+			})
+		}
+	}
+
+	// Parameters without a Default must be given
+	var missing []string
+	for name, param := range tmpl.Parameters {
+		if param.Default == nil && !given[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		matches = append(matches, rules.Match{
+			Message:  fmt.Sprintf("Parameter '%s' has no Default and no value in the parameter file", name),
+			Line:     params.Node.Line,
+			Column:   params.Node.Column,
+			Path:     params.Path,
+			Filename: params.Filename,
+		})
+	}
+
+	return matches
+}
+
+// checkParameterValue checks a value against the constraints of a template
+// parameter. List parameters are checked item by item. Values of NoEcho
+// parameters are not repeated in messages.
+func checkParameterValue(name string, param *template.Parameter, value string) []string {
+	items := []string{value}
+	isList := param.Type == "CommaDelimitedList" || strings.HasPrefix(param.Type, "List<")
+	if isList {
+		items = strings.Split(value, ",")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+	}
+	isNumber := param.Type == "Number" || param.Type == "List<Number>"
+
+	var pattern *regexp.Regexp
+	if param.AllowedPattern != "" && !isNumber {
+		// CloudFormation matches the pattern against the whole value;
+		// invalid patterns are reported by E2001
+		pattern, _ = regexp.Compile("^(?:" + param.AllowedPattern + ")$")
+	}
+
+	var messages []string
+	for _, item := range items {
+		shown := item
+		if param.NoEcho {
+			shown = "****"
+		}
+
+		if len(param.AllowedValues) > 0 && !isAllowedValue(item, param.AllowedValues) {
+			messages = append(messages, fmt.Sprintf("Parameter '%s' value '%s' is not in AllowedValues", name, shown))
+		}
+		if pattern != nil && !pattern.MatchString(item) {
+			messages = append(messages, fmt.Sprintf("Parameter '%s' value '%s' does not match AllowedPattern '%s'", name, shown, param.AllowedPattern))
+		}
+
+		if isNumber {
+			num, err := strconv.ParseFloat(item, 64)
+			if err != nil {
+				messages = append(messages, fmt.Sprintf("Parameter '%s' value '%s' is not a number", name, shown))
+				continue
+			}
+			if param.MinValue != nil && num < *param.MinValue {
+				messages = append(messages, fmt.Sprintf("Parameter '%s' value %s is less than MinValue %v", name, shown, *param.MinValue))
+			}
+			if param.MaxValue != nil && num > *param.MaxValue {
+				messages = append(messages, fmt.Sprintf("Parameter '%s' value %s is greater than MaxValue %v", name, shown, *param.MaxValue))
+			}
+		}
+	}
+
+	if param.Type == "String" {
+		length := utf8.RuneCountInString(value)
+		if param.MinLength != nil && length < *param.MinLength {
+			messages = append(messages, fmt.Sprintf("Parameter '%s' value length %d is less than MinLength %d", name, length, *param.MinLength))
+		}
+		if param.MaxLength != nil && length > *param.MaxLength {
+			messages = append(messages, fmt.Sprintf("Parameter '%s' value length %d is greater than MaxLength %d", name, length, *param.MaxLength))
+		}
+	}
+
+	return messages
+}
+
+// isAllowedValue reports whether value is one of the allowed values, which
+// the template may write as strings, numbers or booleans. Numbers compare
+// by value, so 1.0 is allowed by 1.
+func isAllowedValue(value string, allowed []any) bool {
+	num, numErr := strconv.ParseFloat(value, 64)
+	for _, a := range allowed {
+		s := fmt.Sprint(a)
+		if s == value {
+			return true
+		}
+		if other, err := strconv.ParseFloat(s, 64); err == nil && numErr == nil && other == num {
+			return true
+		}
+	}
+	return false
+}
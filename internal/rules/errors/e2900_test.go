@@ -1,12 +1,15 @@
 package errors
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
-func TestE2900_PlaceholderRule(t *testing.T) {
+func TestE2900_NoMatchesOnTemplate(t *testing.T) {
 	yaml := `
 AWSTemplateFormatVersion: '2010-09-09'
 Parameters:
@@ -25,10 +28,114 @@ Resources:
 	rule := &E2900{}
 	matches := rule.Match(tmpl)
 
-	// E2900 is a placeholder rule that requires deployment file parsing infrastructure
-	// It should return no matches when validating templates directly
+	// E2900 checks parameter files through MatchParameters; it returns no
+	// matches when validating templates directly
 	if len(matches) != 0 {
-		t.Errorf("Expected 0 matches for placeholder rule E2900, got %d", len(matches))
+		t.Errorf("Expected 0 matches for template (E2900 validates parameter files), got %d", len(matches))
+	}
+}
+
+func TestE2900_MatchParameters(t *testing.T) {
+	tmpl, err := template.Parse([]byte(`
+Parameters:
+  Env:
+    Type: String
+    AllowedValues: [dev, prod]
+  Name:
+    Type: String
+    AllowedPattern: "[a-z]+"
+    MinLength: 3
+    MaxLength: 8
+  Size:
+    Type: Number
+    MinValue: 1
+    MaxValue: 10
+    Default: 2
+  Ports:
+    Type: List<Number>
+    AllowedValues: [80, 443]
+    Default: "80"
+  Zones:
+    Type: CommaDelimitedList
+    AllowedPattern: "[a-z]+-[a-z]+-[0-9][a-z]"
+    Default: us-east-1a
+  Secret:
+    Type: String
+    NoEcho: true
+    AllowedPattern: "[0-9]+"
+    Default: "1"
+  Vpc:
+    Type: String
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "valid",
+			data: `{"Env": "prod", "Name": "web", "Size": 10, "Ports": "80, 443", "Zones": "us-east-1a,eu-west-1b", "Vpc": "vpc-1"}`,
+		},
+		{
+			name: "unknown and missing parameters",
+			data: "[{\"ParameterKey\": \"Env\", \"ParameterValue\": \"dev\"},\n {\"ParameterKey\": \"Nmae\", \"ParameterValue\": \"web\"}]",
+			want: []string{
+				"2:19 [[1] ParameterKey] Parameter 'Nmae' is not defined in the template",
+				"1:1 [] Parameter 'Name' has no Default and no value in the parameter file",
+				"1:1 [] Parameter 'Vpc' has no Default and no value in the parameter file",
+			},
+		},
+		{
+			name: "previous values count as given",
+			data: `[{"ParameterKey": "Env", "UsePreviousValue": true}, {"ParameterKey": "Name", "UsePreviousValue": true}, {"ParameterKey": "Vpc", "UsePreviousValue": true}]`,
+		},
+		{
+			name: "constraints",
+			data: "Parameters:\n  Env: test\n  Name: Web-Server-1\n  Size: 0\n  Ports: 80,8080\n  Zones: us-east-1a,Nowhere\n  Secret: abc\n  Vpc: vpc-1\n",
+			want: []string{
+				"2:8 [Parameters Env] Parameter 'Env' value 'test' is not in AllowedValues",
+				"3:9 [Parameters Name] Parameter 'Name' value 'Web-Server-1' does not match AllowedPattern '[a-z]+'",
+				"3:9 [Parameters Name] Parameter 'Name' value length 12 is greater than MaxLength 8",
+				"4:9 [Parameters Size] Parameter 'Size' value 0 is less than MinValue 1",
+				"5:10 [Parameters Ports] Parameter 'Ports' value '8080' is not in AllowedValues",
+				"6:10 [Parameters Zones] Parameter 'Zones' value 'Nowhere' does not match AllowedPattern '[a-z]+-[a-z]+-[0-9][a-z]'",
+				"7:11 [Parameters Secret] Parameter 'Secret' value '****' does not match AllowedPattern '[0-9]+'",
+			},
+		},
+		{
+			name: "not a number",
+			data: `{"Env": "dev", "Name": "web", "Size": "big", "Vpc": "vpc-1"}`,
+			want: []string{"1:39 [Size] Parameter 'Size' value 'big' is not a number"},
+		},
+		{
+			name: "unrecognized format is left to E0200",
+			data: `"prod"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := deployment.ParseParameters("params.json", []byte(tt.data))
+			matches := (&E2900{}).MatchParameters(tmpl, params)
+
+			var got []string
+			for _, m := range matches {
+				if m.Filename != "params.json" {
+					t.Errorf("Expected match in params.json, got %q", m.Filename)
+				}
+				got = append(got, fmt.Sprintf("%d:%d %v %s", m.Line, m.Column, m.Path, m.Message))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected matches:\n%v\ngot:\n%v", tt.want, got)
+			}
+		})
 	}
 }
 
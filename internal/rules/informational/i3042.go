@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -34,41 +35,49 @@ func (r *I3042) Tags() []string {
 }
 
 func (r *I3042) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, regions.Default)
+}
+
+func (r *I3042) MatchRegion(tmpl *template.Template, region string) []rules.Match {
 	var matches []rules.Match
 
 	// Check all resources
 	for resName, res := range tmpl.Resources {
-		checkForHardcodedARNs(res.Properties, []string{"Resources", resName, "Properties"}, &matches)
+		checkForHardcodedARNs(res.Properties, []string{"Resources", resName, "Properties"}, region, &matches)
 	}
 
 	// Check outputs
 	for outName, out := range tmpl.Outputs {
 		if out.Value != nil {
-			checkForHardcodedARNs(map[string]any{"Value": out.Value}, []string{"Outputs", outName, "Value"}, &matches)
+			checkForHardcodedARNs(map[string]any{"Value": out.Value}, []string{"Outputs", outName, "Value"}, region, &matches)
 		}
 	}
 
 	return matches
 }
 
-func checkForHardcodedARNs(v any, path []string, matches *[]rules.Match) {
+func checkForHardcodedARNs(v any, path []string, region string, matches *[]rules.Match) {
 	switch val := v.(type) {
 	case string:
 		// Check if it's an ARN with hardcoded values
 		if isHardcodedARN(val) {
 			suggestion := suggestPseudoParams(val)
+			message := fmt.Sprintf("ARN contains hardcoded values. Consider using pseudo parameters for better portability. Suggestion: %s", suggestion)
+			if arnRegion := hardcodedARNRegion(val); arnRegion != "" && arnRegion != region {
+				message = fmt.Sprintf("ARN hardcodes region '%s', not the stack's region. Consider using pseudo parameters for better portability. Suggestion: %s", arnRegion, suggestion)
+			}
 			*matches = append(*matches, rules.Match{
-				Message: fmt.Sprintf("ARN contains hardcoded values. Consider using pseudo parameters for better portability. Suggestion: %s", suggestion),
+				Message: message,
 				Path:    path,
 			})
 		}
 	case map[string]any:
 		for key, child := range val {
-			checkForHardcodedARNs(child, append(path, key), matches)
+			checkForHardcodedARNs(child, append(path, key), region, matches)
 		}
 	case []any:
 		for i, child := range val {
-			checkForHardcodedARNs(child, append(path, fmt.Sprintf("[%d]", i)), matches)
+			checkForHardcodedARNs(child, append(path, fmt.Sprintf("[%d]", i)), region, matches)
 		}
 	}
 }
@@ -85,19 +94,17 @@ func isHardcodedARN(s string) bool {
 	}
 
 	// Check for hardcoded region (e.g., us-east-1, eu-west-1)
-	hardcodedRegions := []string{
-		":us-east-1:", ":us-east-2:", ":us-west-1:", ":us-west-2:",
-		":eu-west-1:", ":eu-west-2:", ":eu-west-3:", ":eu-central-1:",
-		":ap-northeast-1:", ":ap-northeast-2:", ":ap-southeast-1:", ":ap-southeast-2:",
-		":ap-south-1:", ":ca-central-1:", ":sa-east-1:",
-	}
-	for _, region := range hardcodedRegions {
-		if strings.Contains(s, region) {
-			return true
-		}
-	}
+	return hardcodedARNRegion(s) != ""
+}
 
-	return false
+// hardcodedARNRegion returns the region in the region field of an ARN, or
+// "" if that field is not a known region.
+func hardcodedARNRegion(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 4 || !regions.IsValid(parts[3]) {
+		return ""
+	}
+	return parts[3]
 }
 
 func containsAccountIDPattern(s string) bool {
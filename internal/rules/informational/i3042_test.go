@@ -1,6 +1,7 @@
 package informational
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -112,3 +113,29 @@ func TestI3042_Metadata(t *testing.T) {
 		t.Error("Tags should not be empty")
 	}
 }
+
+func TestI3042_RegionOutsideTarget(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  Subscription:
+    Type: AWS::SNS::Subscription
+    Properties:
+      TopicArn: arn:aws:sns:eu-south-2:123456789012:alerts
+      Protocol: email
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &I3042{}
+	matches := rule.MatchRegion(tmpl, "us-west-2")
+	if len(matches) != 1 || !strings.Contains(matches[0].Message, "not the stack's region") {
+		t.Errorf("Expected the ARN's region to be reported, got %v", matches)
+	}
+	matches = rule.MatchRegion(tmpl, "eu-south-2")
+	if len(matches) != 1 || strings.Contains(matches[0].Message, "not the stack's region") {
+		t.Errorf("Expected a portability suggestion in eu-south-2, got %v", matches)
+	}
+}
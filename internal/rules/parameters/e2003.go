@@ -3,7 +3,6 @@ package parameters
 
 import (
 	"fmt"
-	"regexp"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -35,15 +34,32 @@ func (r *E2003) Tags() []string {
 }
 
 // Parameter names must be alphanumeric (A-Za-z0-9)
-var validParamNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+const defaultParamNamePattern = `^[A-Za-z][A-Za-z0-9]*$`
+
+// Options returns the configurable options for E2003.
+func (r *E2003) Options() []rules.Option {
+	return []rules.Option{
+		{Name: "pattern", Type: rules.OptionPattern, Default: defaultParamNamePattern, Description: "Regular expression parameter names must match"},
+	}
+}
 
 func (r *E2003) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchWithContext(rules.DefaultContext(r), tmpl)
+}
+
+// MatchWithContext checks parameter names against the configured pattern.
+func (r *E2003) MatchWithContext(ctx *rules.Context, tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
 
+	pattern := ctx.Pattern("pattern")
 	for paramName, param := range tmpl.Parameters {
-		if !validParamNamePattern.MatchString(paramName) {
+		if !pattern.MatchString(paramName) {
+			message := fmt.Sprintf("Parameter name '%s' must be alphanumeric and start with a letter", paramName)
+			if pattern.String() != defaultParamNamePattern {
+				message = fmt.Sprintf("Parameter name '%s' does not match pattern '%s'", paramName, pattern)
+			}
 			matches = append(matches, rules.Match{
-				Message: fmt.Sprintf("Parameter name '%s' must be alphanumeric and start with a letter", paramName),
+				Message: message,
 				Line:    param.Node.Line,
 				Column:  param.Node.Column,
 				Path:    []string{"Parameters", paramName},
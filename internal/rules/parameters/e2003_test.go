@@ -3,6 +3,7 @@ package parameters
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -74,3 +75,35 @@ Resources:
 		t.Errorf("Expected 1 match for invalid parameter name starting with number, got %d", len(matches))
 	}
 }
+
+func TestE2003_ConfiguredPattern(t *testing.T) {
+	tmpl := `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  pEnvironment:
+    Type: String
+  Environment:
+    Type: String
+Resources:
+  MyResource:
+    Type: AWS::S3::Bucket
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &E2003{}
+	ctx, err := rules.NewContext(rule, map[string]any{"pattern": "^p[A-Z][A-Za-z0-9]*$"})
+	if err != nil {
+		t.Fatalf("NewContext failed: %v", err)
+	}
+	matches := rule.MatchWithContext(ctx, parsed)
+
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match for configured pattern, got %d: %v", len(matches), matches)
+	}
+	if matches[0].Path[1] != "Environment" {
+		t.Errorf("Expected match for Environment, got %v", matches[0].Path)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/schema"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -54,7 +55,7 @@ var deprecatedLambdaRuntimes = map[string]string{
 	"ruby2.7": "deprecated since December 2023",
 
 	// Java
-	"java8": "deprecated since December 2023 (use java8.al2)",
+	"java8": "deprecated since December 2023",
 
 	// .NET
 	"dotnetcore1.0": "deprecated since July 2019",
@@ -64,7 +65,7 @@ var deprecatedLambdaRuntimes = map[string]string{
 	"dotnet5.0":     "deprecated since May 2022",
 
 	// Go
-	"go1.x": "deprecated since December 2023 (use provided.al2)",
+	"go1.x": "deprecated since December 2023",
 }
 
 func (r *E2531) Match(tmpl *template.Template) []rules.Match {
@@ -89,8 +90,12 @@ func (r *E2531) Match(tmpl *template.Template) []rules.Match {
 
 		// Check if runtime is deprecated
 		if deprecationInfo, deprecated := isRuntimeDeprecated(runtimeStr); deprecated {
+			message := fmt.Sprintf("Lambda function '%s' uses deprecated runtime '%s' (%s). Please migrate to a supported runtime.", resName, runtimeStr, deprecationInfo)
+			if successor, ok := schema.LambdaRuntimeSuccessor(runtimeStr); ok {
+				message = fmt.Sprintf("Lambda function '%s' uses deprecated runtime '%s' (%s). Please migrate to a supported runtime such as '%s'.", resName, runtimeStr, deprecationInfo, successor)
+			}
 			matches = append(matches, rules.Match{
-				Message: fmt.Sprintf("Lambda function '%s' uses deprecated runtime '%s' (%s). Please migrate to a supported runtime.", resName, runtimeStr, deprecationInfo),
+				Message: message,
 				Line:    res.Node.Line,
 				Column:  res.Node.Column,
 				Path:    []string{"Resources", resName, "Properties", "Runtime"},
@@ -112,3 +117,20 @@ func isRuntimeDeprecated(runtime string) (string, bool) {
 
 	return "", false
 }
+
+// Fix replaces a deprecated runtime with its successor.
+func (r *E2531) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	resolved := tmpl.ResolvePath(m.Path)
+	if resolved == nil || !resolved.Exact(len(m.Path)) || resolved.Value.ShortTag() != "!!str" {
+		return nil
+	}
+	runtime := resolved.Value.Value
+	successor, ok := schema.LambdaRuntimeSuccessor(runtime)
+	if !ok {
+		return nil
+	}
+	return &rules.Fix{
+		Description: fmt.Sprintf("Replace runtime %s with %s", runtime, successor),
+		Edits:       []rules.TextEdit{rules.ReplaceScalar(tmpl, resolved.Value, successor)},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -250,7 +251,11 @@ Resources:
 	matches := rule.Match(tmpl)
 
 	if len(matches) == 0 {
-		t.Error("Expected match for deprecated go1.x runtime")
+		t.Fatal("Expected match for deprecated go1.x runtime")
+	}
+	// The message names the runtime Fix writes
+	if !strings.Contains(matches[0].Message, "'provided.al2023'") {
+		t.Errorf("Expected the message to suggest provided.al2023, got '%s'", matches[0].Message)
 	}
 }
 
@@ -358,3 +363,21 @@ func TestE2531_Metadata(t *testing.T) {
 		t.Error("Tags should not be empty")
 	}
 }
+
+func TestE2531_Fix(t *testing.T) {
+	tmpl := `Resources:
+  Fn:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: 'go1.x' # old
+`
+	want := `Resources:
+  Fn:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: 'provided.al2023' # old
+`
+	if got := testutil.ApplyFixes(t, &E2531{}, tmpl); got != want {
+		t.Errorf("Unexpected fixed template:\n%s", got)
+	}
+}
@@ -4,6 +4,7 @@ package resources
 import (
 	"fmt"
 
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/schema"
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -37,6 +38,7 @@ func (r *E3003) Tags() []string {
 
 func (r *E3003) Match(tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
+	solver := condlogic.New(tmpl)
 
 	for resName, res := range tmpl.Resources {
 		required, err := schema.GetRequiredProperties(res.Type)
@@ -49,16 +51,20 @@ func (r *E3003) Match(tmpl *template.Template) []rules.Match {
 			continue
 		}
 
-		for _, prop := range required {
-			if _, exists := res.Properties[prop]; !exists {
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf("Resource '%s' (%s) is missing required property '%s'", resName, res.Type, prop),
-					Line:    res.Node.Line,
-					Column:  res.Node.Column,
-					Path:    []string{"Resources", resName, "Properties"},
-				})
+		matches = append(matches, matchScenarios(solver, res, func(props map[string]any) []rules.Match {
+			var found []rules.Match
+			for _, prop := range required {
+				if _, exists := props[prop]; !exists {
+					found = append(found, rules.Match{
+						Message: fmt.Sprintf("Resource '%s' (%s) is missing required property '%s'", resName, res.Type, prop),
+						Line:    res.Node.Line,
+						Column:  res.Node.Column,
+						Path:    []string{"Resources", resName, "Properties"},
+					})
+				}
 			}
-		}
+			return found
+		})...)
 	}
 
 	return matches
@@ -173,6 +173,55 @@ Resources:
 	}
 }
 
+func TestE3003_RemovedInConditionBranch(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Parameters:
+  Env:
+    Type: String
+    AllowedValues: [dev, prod]
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsDev: !Equals [!Ref Env, dev]
+Resources:
+  MyFunction:
+    Type: AWS::Lambda::Function
+    Properties:
+      Role: !If [IsProd, arn:aws:iam::123456789012:role/MyRole, !Ref "AWS::NoValue"]
+      Code:
+        S3Bucket: my-bucket
+        S3Key: code.zip
+      Runtime: python3.9
+      Handler: index.handler
+  ProdFunction:
+    Type: AWS::Lambda::Function
+    Condition: IsProd
+    Properties:
+      Role: !If [IsDev, !Ref "AWS::NoValue", arn:aws:iam::123456789012:role/MyRole]
+      Code:
+        S3Bucket: my-bucket
+        S3Key: code.zip
+      Runtime: python3.9
+      Handler: index.handler
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3003{}
+	matches := rule.Match(tmpl)
+
+	// ProdFunction only exists when IsDev is false, so its Role is always set
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d: %v", len(matches), matches)
+	}
+	want := "Resource 'MyFunction' (AWS::Lambda::Function) is missing required property 'Role' when IsProd=false"
+	if matches[0].Message != want {
+		t.Errorf("Message = %q, want %q", matches[0].Message, want)
+	}
+}
+
 func TestE3003_Metadata(t *testing.T) {
 	rule := &E3003{}
 
@@ -22,7 +22,7 @@ func (r *E3010) ShortDesc() string {
 }
 
 func (r *E3010) Description() string {
-	return "Checks that the template does not exceed the maximum of 500 resources."
+	return "Checks that the template does not exceed the maximum of 500 resources, or the configured limit."
 }
 
 func (r *E3010) Source() string {
@@ -36,13 +36,26 @@ func (r *E3010) Tags() []string {
 // MaxResources is the CloudFormation limit for resources per template.
 const MaxResources = 500
 
+// Options returns the configurable options for E3010.
+func (r *E3010) Options() []rules.Option {
+	return []rules.Option{
+		{Name: "limit", Type: rules.OptionInteger, Default: MaxResources, Description: "Maximum number of resources allowed in a template"},
+	}
+}
+
 func (r *E3010) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchWithContext(rules.DefaultContext(r), tmpl)
+}
+
+// MatchWithContext checks the resource count against the configured limit.
+func (r *E3010) MatchWithContext(ctx *rules.Context, tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
 
+	limit := ctx.Int("limit")
 	count := len(tmpl.Resources)
-	if count > MaxResources {
+	if count > limit {
 		matches = append(matches, rules.Match{
-			Message: fmt.Sprintf("Template has %d resources, exceeding the limit of %d", count, MaxResources),
+			Message: fmt.Sprintf("Template has %d resources, exceeding the limit of %d", count, limit),
 			Line:    1,
 			Column:  1,
 			Path:    []string{"Resources"},
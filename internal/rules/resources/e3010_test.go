@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -98,3 +99,32 @@ func TestE3010_Metadata(t *testing.T) {
 		t.Error("Tags should not be empty")
 	}
 }
+
+func TestE3010_ConfiguredLimit(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  Bucket1:
+    Type: AWS::S3::Bucket
+  Bucket2:
+    Type: AWS::S3::Bucket
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3010{}
+	ctx, err := rules.NewContext(rule, map[string]any{"limit": 1})
+	if err != nil {
+		t.Fatalf("NewContext failed: %v", err)
+	}
+	matches := rule.MatchWithContext(ctx, tmpl)
+
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match for configured limit, got %d", len(matches))
+	}
+	if !strings.Contains(matches[0].Message, "limit of 1") {
+		t.Errorf("Expected configured limit in message, got %q", matches[0].Message)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/schema"
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -38,6 +39,7 @@ func (r *E3012) Tags() []string {
 
 func (r *E3012) Match(tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
+	solver := condlogic.New(tmpl)
 
 	for resName, res := range tmpl.Resources {
 		rt, err := schema.GetResourceType(res.Type)
@@ -46,27 +48,31 @@ func (r *E3012) Match(tmpl *template.Template) []rules.Match {
 			continue
 		}
 
-		for propName, propValue := range res.Properties {
-			prop := rt.GetProperty(propName)
-			if prop == nil {
-				// Unknown property - handled by E3002
-				continue
+		matches = append(matches, matchScenarios(solver, res, func(props map[string]any) []rules.Match {
+			var found []rules.Match
+			for propName, propValue := range props {
+				prop := rt.GetProperty(propName)
+				if prop == nil {
+					// Unknown property - handled by E3002
+					continue
+				}
+
+				// Skip validation if value is an intrinsic function
+				if isIntrinsicFunction(propValue) {
+					continue
+				}
+
+				if err := validatePropertyType(propValue, prop.PrimitiveType, prop.Type); err != nil {
+					found = append(found, rules.Match{
+						Message: fmt.Sprintf("Property '%s' in resource '%s' (%s): %s", propName, resName, res.Type, err.Error()),
+						Line:    res.Node.Line,
+						Column:  res.Node.Column,
+						Path:    []string{"Resources", resName, "Properties", propName},
+					})
+				}
 			}
-
-			// Skip validation if value is an intrinsic function
-			if isIntrinsicFunction(propValue) {
-				continue
-			}
-
-			if err := validatePropertyType(propValue, prop.PrimitiveType, prop.Type); err != nil {
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf("Property '%s' in resource '%s' (%s): %s", propName, resName, res.Type, err.Error()),
-					Line:    res.Node.Line,
-					Column:  res.Node.Column,
-					Path:    []string{"Resources", resName, "Properties", propName},
-				})
-			}
-		}
+			return found
+		})...)
 	}
 
 	return matches
@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -168,6 +169,33 @@ Resources:
 	}
 }
 
+func TestE3012_WrongTypeInConditionBranch(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Conditions:
+  HasName: !Not [!Equals [!Ref "AWS::StackName", ""]]
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !If [HasName, my-bucket, 12345]
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3012{}
+	matches := rule.Match(tmpl)
+
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d: %v", len(matches), matches)
+	}
+	if !strings.HasSuffix(matches[0].Message, " when HasName=false") {
+		t.Errorf("Expected the match to name the scenario, got %q", matches[0].Message)
+	}
+}
+
 func TestE3012_IntegerAsDouble(t *testing.T) {
 	yaml := `
 AWSTemplateFormatVersion: '2010-09-09'
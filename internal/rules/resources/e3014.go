@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/schema"
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -38,6 +39,7 @@ func (r *E3014) Tags() []string {
 
 func (r *E3014) Match(tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
+	solver := condlogic.New(tmpl)
 
 	for resName, res := range tmpl.Resources {
 		constraints := schema.GetResourceConstraints(res.Type)
@@ -45,30 +47,34 @@ func (r *E3014) Match(tmpl *template.Template) []rules.Match {
 			continue
 		}
 
-		// Check each mutually exclusive set
-		for _, exclusiveSet := range constraints.MutuallyExclusive {
-			// Find which properties from the set are present
-			present := []string{}
-			for _, prop := range exclusiveSet {
-				if hasProperty(res.Properties, prop) {
-					present = append(present, prop)
+		matches = append(matches, matchScenarios(solver, res, func(props map[string]any) []rules.Match {
+			var found []rules.Match
+			// Check each mutually exclusive set
+			for _, exclusiveSet := range constraints.MutuallyExclusive {
+				// Find which properties from the set are present
+				present := []string{}
+				for _, prop := range exclusiveSet {
+					if hasProperty(props, prop) {
+						present = append(present, prop)
+					}
 				}
-			}
 
-			// If more than one is present, report error
-			if len(present) > 1 {
-				sort.Strings(present)
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf(
-						"Resource '%s' (%s) has mutually exclusive properties: %s",
-						resName, res.Type, strings.Join(present, ", "),
-					),
-					Line:   res.Node.Line,
-					Column: res.Node.Column,
-					Path:   []string{"Resources", resName, "Properties"},
-				})
+				// If more than one is present, report error
+				if len(present) > 1 {
+					sort.Strings(present)
+					found = append(found, rules.Match{
+						Message: fmt.Sprintf(
+							"Resource '%s' (%s) has mutually exclusive properties: %s",
+							resName, res.Type, strings.Join(present, ", "),
+						),
+						Line:   res.Node.Line,
+						Column: res.Node.Column,
+						Path:   []string{"Resources", resName, "Properties"},
+					})
+				}
 			}
-		}
+			return found
+		})...)
 	}
 
 	return matches
@@ -117,6 +117,46 @@ Resources:
 	}
 }
 
+func TestE3014_ConflictInConditionBranches(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Parameters:
+  Env:
+    Type: String
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  NotProd: !Not [!Condition IsProd]
+Resources:
+  ExclusiveBranches:
+    Type: AWS::EC2::Instance
+    Properties:
+      ImageId: ami-12345678
+      SecurityGroups: !If [IsProd, [default], !Ref "AWS::NoValue"]
+      SecurityGroupIds: !If [NotProd, [sg-12345678], !Ref "AWS::NoValue"]
+  OverlappingBranches:
+    Type: AWS::EC2::Instance
+    Properties:
+      ImageId: ami-12345678
+      SecurityGroups: !If [IsProd, [default], !Ref "AWS::NoValue"]
+      SecurityGroupIds: [sg-12345678]
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3014{}
+	matches := rule.Match(tmpl)
+
+	// The two properties of ExclusiveBranches are never set together
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d: %v", len(matches), matches)
+	}
+	if !strings.Contains(matches[0].Message, "'OverlappingBranches'") || !strings.HasSuffix(matches[0].Message, " when IsProd=true") {
+		t.Errorf("Expected a conflict in OverlappingBranches when IsProd=true, got %q", matches[0].Message)
+	}
+}
+
 func TestE3014_Metadata(t *testing.T) {
 	rule := &E3014{}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/lex00/cloudformation-schema-go/enums"
 
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -39,6 +40,7 @@ func (r *E3030) Tags() []string {
 
 func (r *E3030) Match(tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
+	solver := condlogic.New(tmpl)
 
 	for resName, res := range tmpl.Resources {
 		// Extract service name from resource type (e.g., "lambda" from "AWS::Lambda::Function")
@@ -47,33 +49,37 @@ func (r *E3030) Match(tmpl *template.Template) []rules.Match {
 			continue
 		}
 
-		for propName, propValue := range res.Properties {
-			// Skip non-string values and intrinsic functions
-			strValue, ok := propValue.(string)
-			if !ok {
-				continue
+		matches = append(matches, matchScenarios(solver, res, func(props map[string]any) []rules.Match {
+			var found []rules.Match
+			for propName, propValue := range props {
+				// Skip non-string values and intrinsic functions
+				strValue, ok := propValue.(string)
+				if !ok {
+					continue
+				}
+
+				// Check if this property has an enum mapping
+				enumName := enums.GetEnumForProperty(service, propName)
+				if enumName == "" {
+					continue
+				}
+
+				// Validate the value against allowed enum values
+				if !enums.IsValidValue(service, enumName, strValue) {
+					allowedValues := enums.GetAllowedValues(service, enumName)
+					found = append(found, rules.Match{
+						Message: fmt.Sprintf(
+							"Property '%s' in resource '%s' (%s) has invalid value '%s'. Allowed values: %v",
+							propName, resName, res.Type, strValue, allowedValues,
+						),
+						Line:   res.Node.Line,
+						Column: res.Node.Column,
+						Path:   []string{"Resources", resName, "Properties", propName},
+					})
+				}
 			}
-
-			// Check if this property has an enum mapping
-			enumName := enums.GetEnumForProperty(service, propName)
-			if enumName == "" {
-				continue
-			}
-
-			// Validate the value against allowed enum values
-			if !enums.IsValidValue(service, enumName, strValue) {
-				allowedValues := enums.GetAllowedValues(service, enumName)
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf(
-						"Property '%s' in resource '%s' (%s) has invalid value '%s'. Allowed values: %v",
-						propName, resName, res.Type, strValue, allowedValues,
-					),
-					Line:   res.Node.Line,
-					Column: res.Node.Column,
-					Path:   []string{"Resources", resName, "Properties", propName},
-				})
-			}
-		}
+			return found
+		})...)
 	}
 
 	return matches
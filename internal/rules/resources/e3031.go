@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/schema"
@@ -36,8 +37,12 @@ func (r *E3031) Tags() []string {
 	return []string{"resources", "properties", "pattern"}
 }
 
-// patternCache caches compiled regex patterns.
-var patternCache = make(map[string]*regexp.Regexp)
+// patternCache caches compiled regex patterns. It is shared by concurrent
+// lint runs, so access is guarded by patternCacheMu.
+var (
+	patternCache   = make(map[string]*regexp.Regexp)
+	patternCacheMu sync.Mutex
+)
 
 func (r *E3031) Match(tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
@@ -60,15 +65,10 @@ func (r *E3031) Match(tmpl *template.Template) []rules.Match {
 			}
 
 			// Get or compile the pattern
-			pattern, ok := patternCache[constraints.Pattern]
-			if !ok {
-				var err error
-				pattern, err = regexp.Compile(constraints.Pattern)
-				if err != nil {
-					// Invalid pattern - skip validation
-					continue
-				}
-				patternCache[constraints.Pattern] = pattern
+			pattern, err := cachedPattern(constraints.Pattern)
+			if err != nil {
+				// Invalid pattern - skip validation
+				continue
 			}
 
 			if !pattern.MatchString(strValue) {
@@ -100,3 +100,19 @@ func truncateString(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// cachedPattern returns the compiled pattern, compiling it on first use.
+func cachedPattern(expr string) (*regexp.Regexp, error) {
+	patternCacheMu.Lock()
+	defer patternCacheMu.Unlock()
+
+	if pattern, ok := patternCache[expr]; ok {
+		return pattern, nil
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patternCache[expr] = pattern
+	return pattern, nil
+}
@@ -3,6 +3,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -69,3 +70,27 @@ func (r *E3035) Match(tmpl *template.Template) []rules.Match {
 
 	return matches
 }
+
+// Fix corrects the casing of a DeletionPolicy such as "retain".
+func (r *E3035) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	return policyCaseFix(tmpl, m, validDeletionPolicies)
+}
+
+// policyCaseFix returns a fix replacing a policy value that differs from a
+// valid one only in case.
+func policyCaseFix(tmpl *template.Template, m rules.Match, valid map[string]bool) *rules.Fix {
+	resolved := tmpl.ResolvePath(m.Path)
+	if resolved == nil || !resolved.Exact(len(m.Path)) || resolved.Value.ShortTag() != "!!str" {
+		return nil
+	}
+	value := resolved.Value.Value
+	for policy := range valid {
+		if strings.EqualFold(policy, value) {
+			return &rules.Fix{
+				Description: fmt.Sprintf("Replace %s '%s' with '%s'", m.Path[len(m.Path)-1], value, policy),
+				Edits:       []rules.TextEdit{rules.ReplaceScalar(tmpl, resolved.Value, policy)},
+			}
+		}
+	}
+	return nil
+}
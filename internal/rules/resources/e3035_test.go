@@ -3,6 +3,7 @@ package resources
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -91,3 +92,25 @@ Resources:
 		t.Errorf("Expected 0 matches when no DeletionPolicy, got %d: %v", len(matches), matches)
 	}
 }
+
+func TestE3035_Fix(t *testing.T) {
+	tmpl := `Resources:
+  Cased:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: retainExceptOnCreate
+  Unknown:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: Keep
+`
+	want := `Resources:
+  Cased:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: RetainExceptOnCreate
+  Unknown:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: Keep
+`
+	if got := testutil.ApplyFixes(t, &E3035{}, tmpl); got != want {
+		t.Errorf("Unexpected fixed template:\n%s", got)
+	}
+}
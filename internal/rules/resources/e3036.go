@@ -68,3 +68,8 @@ func (r *E3036) Match(tmpl *template.Template) []rules.Match {
 
 	return matches
 }
+
+// Fix corrects the casing of an UpdateReplacePolicy such as "retain".
+func (r *E3036) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	return policyCaseFix(tmpl, m, validUpdateReplacePolicies)
+}
@@ -3,6 +3,7 @@ package resources
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -91,3 +92,11 @@ Resources:
 		t.Errorf("Expected 0 matches when no UpdateReplacePolicy, got %d: %v", len(matches), matches)
 	}
 }
+
+func TestE3036_Fix(t *testing.T) {
+	tmpl := `{"Resources": {"Db": {"Type": "AWS::RDS::DBInstance", "UpdateReplacePolicy": "SNAPSHOT"}}}`
+	want := `{"Resources": {"Db": {"Type": "AWS::RDS::DBInstance", "UpdateReplacePolicy": "Snapshot"}}}`
+	if got := testutil.ApplyFixes(t, &E3036{}, tmpl); got != want {
+		t.Errorf("Unexpected fixed template:\n%s", got)
+	}
+}
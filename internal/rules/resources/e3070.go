@@ -0,0 +1,64 @@
+// Package resources contains resource validation rules (E3xxx).
+package resources
+
+import (
+	"fmt"
+
+	"github.com/lex00/cfn-lint-go/pkg/regions"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func init() {
+	rules.Register(&E3070{})
+}
+
+// E3070 checks that resource types are available in the target region.
+type E3070 struct{}
+
+func (r *E3070) ID() string { return "E3070" }
+
+func (r *E3070) ShortDesc() string {
+	return "Resource type not available in region"
+}
+
+func (r *E3070) Description() string {
+	return "Checks that each resource type is supported by CloudFormation in the regions being validated."
+}
+
+func (r *E3070) Source() string {
+	return "https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-template-resource-type-ref.html"
+}
+
+func (r *E3070) Tags() []string {
+	return []string{"resources", "type", "regions"}
+}
+
+func (r *E3070) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, regions.Default)
+}
+
+func (r *E3070) MatchRegion(tmpl *template.Template, region string) []rules.Match {
+	var matches []rules.Match
+
+	for name, res := range tmpl.Resources {
+		if res.Type == "" {
+			continue
+		}
+
+		if !regions.IsResourceTypeAvailable(res.Type, region) {
+			line, column := 0, 0
+			if res.Node != nil {
+				line, column = res.Node.Line, res.Node.Column
+			}
+			matches = append(matches, rules.Match{
+				Message: fmt.Sprintf("Resource type '%s' of resource '%s' is not available in the target region", res.Type, name),
+				Line:    line,
+				Column:  column,
+				Path:    []string{"Resources", name, "Type"},
+			})
+		}
+	}
+
+	return matches
+}
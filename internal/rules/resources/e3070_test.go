@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func TestE3070_AvailableType(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3070{}
+	for _, region := range []string{"us-east-1", "cn-north-1", "us-gov-west-1"} {
+		if matches := rule.MatchRegion(tmpl, region); len(matches) != 0 {
+			t.Errorf("Expected 0 matches in %s, got %d", region, len(matches))
+		}
+	}
+}
+
+func TestE3070_UnavailableInChina(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  Service:
+    Type: AWS::AppRunner::Service
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3070{}
+	if matches := rule.MatchRegion(tmpl, "us-east-1"); len(matches) != 0 {
+		t.Errorf("Expected 0 matches in us-east-1, got %d", len(matches))
+	}
+
+	matches := rule.MatchRegion(tmpl, "cn-north-1")
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match in cn-north-1, got %d", len(matches))
+	}
+	if matches[0].Line != 5 {
+		t.Errorf("Expected line 5, got %d", matches[0].Line)
+	}
+}
+
+func TestE3070_Metadata(t *testing.T) {
+	rule := &E3070{}
+
+	if rule.ID() != "E3070" {
+		t.Errorf("Expected ID E3070, got %s", rule.ID())
+	}
+	if rule.ShortDesc() == "" {
+		t.Error("ShortDesc should not be empty")
+	}
+}
@@ -0,0 +1,91 @@
+// Package resources contains resource validation rules (E3xxx).
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/regions"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func init() {
+	rules.Register(&E3071{})
+}
+
+// E3071 checks that hardcoded ARN partitions match the target region's partition.
+// An ARN such as arn:aws:s3:::bucket is invalid when deployed to aws-cn or aws-us-gov.
+type E3071 struct{}
+
+func (r *E3071) ID() string { return "E3071" }
+
+func (r *E3071) ShortDesc() string {
+	return "ARN partition does not match region"
+}
+
+func (r *E3071) Description() string {
+	return "Checks that hardcoded ARN partitions match the partition of the regions being validated. Use ${AWS::Partition} for templates deployed across partitions."
+}
+
+func (r *E3071) Source() string {
+	return "https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/pseudo-parameter-reference.html#cfn-pseudo-param-partition"
+}
+
+func (r *E3071) Tags() []string {
+	return []string{"resources", "arn", "partition", "regions"}
+}
+
+func (r *E3071) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, regions.Default)
+}
+
+func (r *E3071) MatchRegion(tmpl *template.Template, region string) []rules.Match {
+	var matches []rules.Match
+	partition := regions.Partition(region)
+
+	for resName, res := range tmpl.Resources {
+		checkARNPartitions(res.Properties, partition, []string{"Resources", resName, "Properties"}, &matches)
+	}
+
+	for outName, out := range tmpl.Outputs {
+		if out.Value != nil {
+			checkARNPartitions(out.Value, partition, []string{"Outputs", outName, "Value"}, &matches)
+		}
+	}
+
+	return matches
+}
+
+func checkARNPartitions(v any, partition string, path []string, matches *[]rules.Match) {
+	switch val := v.(type) {
+	case string:
+		if arnPartition, ok := arnPartitionOf(val); ok && arnPartition != partition {
+			*matches = append(*matches, rules.Match{
+				Message: fmt.Sprintf("ARN '%s' uses partition '%s' but the target region is in partition '%s'; use ${AWS::Partition}", val, arnPartition, partition),
+				Path:    path,
+			})
+		}
+	case map[string]any:
+		for key, child := range val {
+			checkARNPartitions(child, partition, append(append([]string{}, path...), key), matches)
+		}
+	case []any:
+		for i, child := range val {
+			checkARNPartitions(child, partition, append(append([]string{}, path...), fmt.Sprintf("[%d]", i)), matches)
+		}
+	}
+}
+
+// arnPartitionOf returns the hardcoded partition of an ARN string.
+// ARNs whose partition is a substitution such as ${AWS::Partition} are ignored.
+func arnPartitionOf(s string) (string, bool) {
+	if !strings.HasPrefix(s, "arn:") {
+		return "", false
+	}
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) < 3 || parts[1] == "" || strings.Contains(parts[1], "${") {
+		return "", false
+	}
+	return parts[1], true
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func TestE3071_PartitionMismatch(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  MyRole:
+    Type: AWS::IAM::Role
+    Properties:
+      ManagedPolicyArns:
+        - arn:aws:iam::aws:policy/ReadOnlyAccess
+Outputs:
+  Topic:
+    Value: !Sub arn:aws-cn:sns:${AWS::Region}:${AWS::AccountId}:topic
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3071{}
+
+	matches := rule.MatchRegion(tmpl, "us-east-1")
+	if len(matches) != 1 {
+		t.Errorf("Expected 1 match in us-east-1 (aws-cn output), got %d: %v", len(matches), matches)
+	}
+
+	matches = rule.MatchRegion(tmpl, "cn-north-1")
+	if len(matches) != 1 {
+		t.Errorf("Expected 1 match in cn-north-1 (aws policy ARN), got %d: %v", len(matches), matches)
+	}
+
+	matches = rule.MatchRegion(tmpl, "us-gov-west-1")
+	if len(matches) != 2 {
+		t.Errorf("Expected 2 matches in us-gov-west-1, got %d: %v", len(matches), matches)
+	}
+}
+
+func TestE3071_PartitionPseudoParameter(t *testing.T) {
+	yaml := `
+AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  MyRole:
+    Type: AWS::IAM::Role
+    Properties:
+      ManagedPolicyArns:
+        - !Sub arn:${AWS::Partition}:iam::aws:policy/ReadOnlyAccess
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	rule := &E3071{}
+	if matches := rule.MatchRegion(tmpl, "cn-north-1"); len(matches) != 0 {
+		t.Errorf("Expected 0 matches, got %d: %v", len(matches), matches)
+	}
+}
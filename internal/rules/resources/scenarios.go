@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// matchScenarios runs check on a resource's properties once per condition
+// scenario, with each Fn::If resolved to the branch taken and
+// AWS::NoValue removed. A finding made in every scenario is reported as
+// is; one made in only some names the first of them, e.g.
+// "... when IsProd=false".
+func matchScenarios(solver *condlogic.Solver, res *template.Resource, check func(props map[string]any) []rules.Match) []rules.Match {
+	scenarios := solver.Scenarios(res.Properties, condlogic.Assumption{Condition: res.Condition, Value: true})
+
+	type finding struct {
+		match    rules.Match
+		scenario condlogic.Scenario
+		count    int
+	}
+	var findings []*finding
+	seen := make(map[string]*finding)
+	for _, sc := range scenarios {
+		props, _ := sc.Resolve(res.Properties).(map[string]any)
+		for _, m := range check(props) {
+			key := m.Message + "\x00" + strings.Join(m.Path, "/")
+			if f, ok := seen[key]; ok {
+				f.count++
+				continue
+			}
+			f := &finding{match: m, scenario: sc, count: 1}
+			seen[key] = f
+			findings = append(findings, f)
+		}
+	}
+
+	matches := make([]rules.Match, 0, len(findings))
+	for _, f := range findings {
+		if f.count < len(scenarios) {
+			f.match.Message += " when " + f.scenario.String()
+		}
+		matches = append(matches, f.match)
+	}
+	return matches
+}
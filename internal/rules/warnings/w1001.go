@@ -3,7 +3,9 @@ package warnings
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -14,6 +16,8 @@ func init() {
 
 // W1001 warns when Ref/GetAtt references a resource that has a Condition.
 // The referenced resource might not exist if its condition evaluates to false.
+// References are fine where the target's condition is implied, by the
+// condition of the referencing resource or output or by an enclosing Fn::If.
 type W1001 struct{}
 
 func (r *W1001) ID() string { return "W1001" }
@@ -23,7 +27,7 @@ func (r *W1001) ShortDesc() string {
 }
 
 func (r *W1001) Description() string {
-	return "Warns when Ref or GetAtt references a resource with a Condition, as the resource may not exist."
+	return "Warns when Ref or GetAtt references a resource with a Condition that is not implied where the reference is used, as the resource may not exist."
 }
 
 func (r *W1001) Source() string {
@@ -49,112 +53,98 @@ func (r *W1001) Match(tmpl *template.Template) []rules.Match {
 	if len(conditionalResources) == 0 {
 		return matches
 	}
+	solver := condlogic.New(tmpl)
+
+	// exists reports whether the target of a reference always exists where
+	// the reference is used
+	exists := func(ref conditionalRef, condition string) bool {
+		return !solver.Satisfiable(withAssumption(ref.context, condition, false)...)
+	}
 
 	// Check all resources for Refs/GetAtts to conditional resources
 	for resName, res := range tmpl.Resources {
-		// Skip checking within the same conditional resource
-		refs := findAllRefTargets(res.Properties)
+		var refs []conditionalRef
+		findConditionalRefs(res.Properties, []condlogic.Assumption{{Condition: res.Condition, Value: true}}, &refs)
 		for _, ref := range refs {
-			if condition, ok := conditionalResources[ref]; ok && res.Condition != condition {
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf("Ref to '%s' in resource '%s' references a conditional resource (Condition: %s)", ref, resName, condition),
-					Path:    []string{"Resources", resName, "Properties"},
-				})
-			}
-		}
-
-		getAtts := findAllGetAttTargets(res.Properties)
-		for _, target := range getAtts {
-			if condition, ok := conditionalResources[target]; ok && res.Condition != condition {
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf("GetAtt to '%s' in resource '%s' references a conditional resource (Condition: %s)", target, resName, condition),
-					Path:    []string{"Resources", resName, "Properties"},
-				})
+			condition, ok := conditionalResources[ref.target]
+			if !ok || exists(ref, condition) {
+				continue
 			}
+			matches = append(matches, rules.Match{
+				Message: fmt.Sprintf("%s to '%s' in resource '%s' references a conditional resource (Condition: %s)", ref.function, ref.target, resName, condition),
+				Path:    []string{"Resources", resName, "Properties"},
+			})
 		}
 	}
 
 	// Check outputs
 	for outName, out := range tmpl.Outputs {
-		refs := findAllRefTargets(out.Value)
+		var refs []conditionalRef
+		findConditionalRefs(out.Value, []condlogic.Assumption{{Condition: out.Condition, Value: true}}, &refs)
 		for _, ref := range refs {
-			if condition, ok := conditionalResources[ref]; ok && out.Condition != condition {
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf("Ref to '%s' in output '%s' references a conditional resource (Condition: %s)", ref, outName, condition),
-					Path:    []string{"Outputs", outName, "Value"},
-				})
-			}
-		}
-
-		getAtts := findAllGetAttTargets(out.Value)
-		for _, target := range getAtts {
-			if condition, ok := conditionalResources[target]; ok && out.Condition != condition {
-				matches = append(matches, rules.Match{
-					Message: fmt.Sprintf("GetAtt to '%s' in output '%s' references a conditional resource (Condition: %s)", target, outName, condition),
-					Path:    []string{"Outputs", outName, "Value"},
-				})
+			condition, ok := conditionalResources[ref.target]
+			if !ok || exists(ref, condition) {
+				continue
 			}
+			matches = append(matches, rules.Match{
+				Message: fmt.Sprintf("%s to '%s' in output '%s' references a conditional resource (Condition: %s)", ref.function, ref.target, outName, condition),
+				Path:    []string{"Outputs", outName, "Value"},
+			})
 		}
 	}
 
 	return matches
 }
 
-func findAllRefTargets(v any) []string {
-	var refs []string
-	findRefTargetsRecursive(v, &refs)
-	return refs
+// conditionalRef is a Ref or GetAtt together with the conditions that hold
+// where it is used: the condition of its resource or output and the
+// branches of the Fn::Ifs around it.
+type conditionalRef struct {
+	function string // "Ref" or "GetAtt"
+	target   string
+	context  []condlogic.Assumption
 }
 
-func findRefTargetsRecursive(v any, refs *[]string) {
+func findConditionalRefs(v any, context []condlogic.Assumption, refs *[]conditionalRef) {
 	switch val := v.(type) {
 	case map[string]any:
 		if ref, ok := val["Ref"].(string); ok {
-			*refs = append(*refs, ref)
+			*refs = append(*refs, conditionalRef{function: "Ref", target: ref, context: context})
+		}
+		if target := getAttTarget(val["Fn::GetAtt"]); target != "" {
+			*refs = append(*refs, conditionalRef{function: "GetAtt", target: target, context: context})
+		}
+		if args, ok := val["Fn::If"].([]any); ok && len(args) == 3 {
+			if condName, ok := args[0].(string); ok {
+				findConditionalRefs(args[1], withAssumption(context, condName, true), refs)
+				findConditionalRefs(args[2], withAssumption(context, condName, false), refs)
+				return
+			}
 		}
 		for _, child := range val {
-			findRefTargetsRecursive(child, refs)
+			findConditionalRefs(child, context, refs)
 		}
 	case []any:
 		for _, child := range val {
-			findRefTargetsRecursive(child, refs)
+			findConditionalRefs(child, context, refs)
 		}
 	}
 }
 
-func findAllGetAttTargets(v any) []string {
-	var targets []string
-	findGetAttTargetsRecursive(v, &targets)
-	return targets
-}
-
-func findGetAttTargetsRecursive(v any, targets *[]string) {
-	switch val := v.(type) {
-	case map[string]any:
-		if getAtt, ok := val["Fn::GetAtt"]; ok {
-			switch ga := getAtt.(type) {
-			case []any:
-				if len(ga) >= 1 {
-					if resName, ok := ga[0].(string); ok {
-						*targets = append(*targets, resName)
-					}
-				}
-			case string:
-				// "Resource.Attribute" format
-				for i, c := range ga {
-					if c == '.' {
-						*targets = append(*targets, ga[:i])
-						break
-					}
-				}
+// getAttTarget returns the resource of an Fn::GetAtt argument, in its
+// [Resource, Attribute] or "Resource.Attribute" form.
+func getAttTarget(getAtt any) string {
+	switch ga := getAtt.(type) {
+	case []any:
+		if len(ga) >= 1 {
+			if resName, ok := ga[0].(string); ok {
+				return resName
 			}
 		}
-		for _, child := range val {
-			findGetAttTargetsRecursive(child, targets)
-		}
-	case []any:
-		for _, child := range val {
-			findGetAttTargetsRecursive(child, targets)
+	case string:
+		if i := strings.Index(ga, "."); i > 0 {
+			return ga[:i]
 		}
 	}
+	return ""
 }
@@ -33,9 +33,12 @@ Resources:
 func TestW1001_RefToConditionalResource(t *testing.T) {
 	tmpl := `
 AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  Env:
+    Type: String
 Conditions:
   CreateBucket:
-    Fn::Equals: [true, true]
+    Fn::Equals: [!Ref Env, prod]
 Resources:
   MyBucket:
     Type: AWS::S3::Bucket
@@ -86,3 +89,59 @@ Resources:
 		t.Errorf("Expected 0 matches when both resources have same condition, got %d: %v", len(matches), matches)
 	}
 }
+
+func TestW1001_ImpliedCondition(t *testing.T) {
+	tmpl := `
+Parameters:
+  Env:
+    Type: String
+    AllowedValues: [dev, prod]
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsDev: !Equals [!Ref Env, dev]
+  IsProdInEU: !And [!Condition IsProd, !Equals [!Ref "AWS::Region", eu-west-1]]
+  HasKey: !Not [!Condition IsDev]
+Resources:
+  Key:
+    Type: AWS::KMS::Key
+    Condition: IsProd
+  Bucket:
+    Type: AWS::S3::Bucket
+    Condition: IsProdInEU
+    Properties:
+      BucketEncryption:
+        ServerSideEncryptionConfiguration:
+          - ServerSideEncryptionByDefault:
+              SSEAlgorithm: aws:kms
+              KMSMasterKeyID: !GetAtt Key.Arn
+  Queue:
+    Type: AWS::SQS::Queue
+    Properties:
+      KmsMasterKeyId: !If [HasKey, !Ref Key, !Ref AWS::NoValue]
+  Topic:
+    Type: AWS::SNS::Topic
+    Properties:
+      KmsMasterKeyId: !If [IsDev, !Ref Key, alias/aws/sns]
+Outputs:
+  KeyArn:
+    Value: !GetAtt Key.Arn
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W1001{}
+	matches := rule.Match(parsed)
+
+	// Bucket and Queue only use Key where IsProd holds; Topic uses it when
+	// IsDev holds and KeyArn always does
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches (Topic, KeyArn), got %d: %v", len(matches), matches)
+	}
+	for _, m := range matches {
+		if m.Path[1] != "Topic" && m.Path[1] != "KeyArn" {
+			t.Errorf("Unexpected match for %v: %s", m.Path, m.Message)
+		}
+	}
+}
@@ -3,9 +3,11 @@ package warnings
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
+	"gopkg.in/yaml.v3"
 )
 
 func init() {
@@ -92,7 +94,77 @@ func (r *W1020) checkSub(sub any, path []string, matches *[]rules.Match) {
 	if !subVarPatternW1020.MatchString(subStr) {
 		*matches = append(*matches, rules.Match{
 			Message: "Fn::Sub is used but contains no variable substitutions; consider using a plain string instead",
-			Path:    path,
+			Path:    append([]string(nil), path...),
 		})
 	}
 }
+
+// Fix replaces the Fn::Sub with its string. Strings with ${!Literal}
+// escapes are left alone since Fn::Sub is what unescapes them.
+func (r *W1020) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	resolved := tmpl.ResolvePath(m.Path)
+	if resolved == nil || !resolved.Exact(len(m.Path)) {
+		return nil
+	}
+	node := resolved.Value
+
+	var str *yaml.Node
+	switch {
+	case node.Tag == "!Sub" && node.Kind == yaml.ScalarNode:
+		// !Sub "text": dropping the tag leaves the string
+		if !isPlainString(node) || strings.Contains(node.Value, "${!") {
+			return nil
+		}
+		content := tmpl.ContentSpan(node)
+		return &rules.Fix{
+			Description: "Replace Fn::Sub with a plain string",
+			Edits: []rules.TextEdit{{
+				StartLine:   node.Line,
+				StartColumn: node.Column,
+				EndLine:     content.StartLine,
+				EndColumn:   content.StartColumn,
+			}},
+		}
+	case node.Tag == "!Sub" && node.Kind == yaml.SequenceNode && len(node.Content) > 0:
+		str = node.Content[0]
+	case node.Kind == yaml.MappingNode && len(node.Content) == 2 && node.Content[0].Value == "Fn::Sub":
+		str = node.Content[1]
+		if str.Kind == yaml.SequenceNode && len(str.Content) > 0 {
+			str = str.Content[0]
+		}
+	}
+	if str == nil || str.Kind != yaml.ScalarNode || !isPlainString(str) || strings.Contains(str.Value, "${!") {
+		return nil
+	}
+	if str.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		// Block scalars depend on their indentation
+		return nil
+	}
+	text := tmpl.SourceText(tmpl.ContentSpan(str))
+
+	edit := rules.ReplaceNode(tmpl, node, text)
+	if resolved.Key != nil && node.Line > resolved.Key.Line && node.Style&yaml.FlowStyle == 0 {
+		// The value was on the lines below its key: move the string up
+		key := tmpl.NodeSpan(resolved.Key)
+		line, _ := tmpl.SourceLine(key.EndLine)
+		if strings.TrimSpace(string([]rune(line)[min(key.EndColumn-1, len([]rune(line))):])) == ":" {
+			edit.StartLine, edit.StartColumn = key.EndLine, key.EndColumn
+			edit.NewText = ": " + text
+		}
+	}
+	return &rules.Fix{Description: "Replace Fn::Sub with a plain string", Edits: []rules.TextEdit{edit}}
+}
+
+// isPlainString reports whether a scalar is a string even without a tag,
+// so that removing the tag or the enclosing Fn::Sub keeps its type.
+func isPlainString(node *yaml.Node) bool {
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return true
+	}
+	var v any
+	if err := yaml.Unmarshal([]byte(node.Value), &v); err != nil {
+		return false
+	}
+	_, ok := v.(string)
+	return ok
+}
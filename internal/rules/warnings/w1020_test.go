@@ -3,6 +3,7 @@ package warnings
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -49,3 +50,38 @@ Resources:
 		t.Errorf("Expected matches for Sub without variables, got 0")
 	}
 }
+
+func TestW1020_Fix(t *testing.T) {
+	tmpl := `Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub "static-bucket-name" # named
+      Tags:
+        - Key:
+            Fn::Sub: 'team'
+          Value: !Sub [platform, {}]
+        - Key: !Sub "keep-${!Literal}"
+          Value: !Sub 123
+Outputs:
+  Name:
+    Value: {"Fn::Sub": "plain"}
+`
+	want := `Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: "static-bucket-name" # named
+      Tags:
+        - Key: 'team'
+          Value: platform
+        - Key: !Sub "keep-${!Literal}"
+          Value: !Sub 123
+Outputs:
+  Name:
+    Value: "plain"
+`
+	if got := testutil.ApplyFixes(t, &W1020{}, tmpl); got != want {
+		t.Errorf("Unexpected fixed template:\n%s", got)
+	}
+}
@@ -2,7 +2,10 @@ package warnings
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -11,7 +14,8 @@ func init() {
 	rules.Register(&W1028{})
 }
 
-// W1028 warns when Fn::If has an unreachable path due to static condition evaluation.
+// W1028 warns when Fn::If has a path that can never be taken, because its
+// condition is always true or false, or always is where the Fn::If is used.
 type W1028 struct{}
 
 func (r *W1028) ID() string { return "W1028" }
@@ -34,95 +38,81 @@ func (r *W1028) Tags() []string {
 
 func (r *W1028) Match(tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
+	if len(tmpl.Conditions) == 0 {
+		return matches
+	}
+	solver := condlogic.New(tmpl)
 
-	// Analyze conditions that are always true or false
-	staticConditions := r.findStaticConditions(tmpl)
-
-	// Check resources for Fn::If using static conditions
+	// A value is only used when its resource or output exists
 	for resName, res := range tmpl.Resources {
-		r.checkValue(res.Properties, []string{"Resources", resName, "Properties"}, staticConditions, &matches)
+		context := []condlogic.Assumption{{Condition: res.Condition, Value: true}}
+		r.checkValue(res.Properties, []string{"Resources", resName, "Properties"}, solver, context, &matches)
 	}
-
-	// Check outputs
 	for outName, out := range tmpl.Outputs {
-		r.checkValue(out.Value, []string{"Outputs", outName, "Value"}, staticConditions, &matches)
+		context := []condlogic.Assumption{{Condition: out.Condition, Value: true}}
+		r.checkValue(out.Value, []string{"Outputs", outName, "Value"}, solver, context, &matches)
 	}
 
 	return matches
 }
 
-func (r *W1028) findStaticConditions(tmpl *template.Template) map[string]bool {
-	static := make(map[string]bool)
-
-	for condName, cond := range tmpl.Conditions {
-		if result, isStatic := r.evaluateCondition(cond.Expression); isStatic {
-			static[condName] = result
+// checkValue reports the Fn::If branches in a value that cannot be taken
+// given context: the condition of the enclosing resource or output and the
+// branches of the enclosing Fn::Ifs.
+func (r *W1028) checkValue(v any, path []string, solver *condlogic.Solver, context []condlogic.Assumption, matches *[]rules.Match) {
+	switch val := v.(type) {
+	case map[string]any:
+		if args, ok := val["Fn::If"].([]any); ok && len(args) == 3 {
+			if condName, ok := args[0].(string); ok {
+				r.checkIf(condName, path, solver, context, matches)
+				r.checkValue(args[1], append(append([]string{}, path...), "Fn::If", "[1]"), solver, withAssumption(context, condName, true), matches)
+				r.checkValue(args[2], append(append([]string{}, path...), "Fn::If", "[2]"), solver, withAssumption(context, condName, false), matches)
+				return
+			}
+		}
+		for key, child := range val {
+			r.checkValue(child, append(append([]string{}, path...), key), solver, context, matches)
+		}
+	case []any:
+		for i, child := range val {
+			r.checkValue(child, append(append([]string{}, path...), fmt.Sprintf("[%d]", i)), solver, context, matches)
 		}
 	}
-
-	return static
 }
 
-func (r *W1028) evaluateCondition(v any) (bool, bool) {
-	condMap, ok := v.(map[string]any)
-	if !ok {
-		return false, false
+func (r *W1028) checkIf(condName string, path []string, solver *condlogic.Solver, context []condlogic.Assumption, matches *[]rules.Match) {
+	canBeTrue := solver.Satisfiable(withAssumption(context, condName, true)...)
+	canBeFalse := solver.Satisfiable(withAssumption(context, condName, false)...)
+	if canBeTrue == canBeFalse {
+		// Either both branches can be taken, or the value is never used
+		return
 	}
 
-	// Check Fn::Equals with two identical literal values
-	if equals, ok := condMap["Fn::Equals"]; ok {
-		if arr, ok := equals.([]any); ok && len(arr) == 2 {
-			// Both values are identical strings
-			if str1, ok1 := arr[0].(string); ok1 {
-				if str2, ok2 := arr[1].(string); ok2 {
-					return str1 == str2, true
-				}
-			}
-			// Both values are identical numbers
-			if num1, ok1 := arr[0].(float64); ok1 {
-				if num2, ok2 := arr[1].(float64); ok2 {
-					return num1 == num2, true
-				}
-			}
-			if num1, ok1 := arr[0].(int); ok1 {
-				if num2, ok2 := arr[1].(int); ok2 {
-					return num1 == num2, true
-				}
-			}
-		}
+	value, unreachable := "true", "false"
+	if !canBeTrue {
+		value, unreachable = "false", "true"
 	}
-
-	return false, false
+	var message string
+	if solver.AlwaysTrue(condName) || solver.NeverTrue(condName) {
+		message = fmt.Sprintf("Fn::If condition '%s' always evaluates to %s; the %s branch is unreachable", condName, value, unreachable)
+	} else {
+		message = fmt.Sprintf("Fn::If condition '%s' always evaluates to %s when %s; the %s branch is unreachable", condName, value, describeContext(context), unreachable)
+	}
+	*matches = append(*matches, rules.Match{Message: message, Path: path})
 }
 
-func (r *W1028) checkValue(v any, path []string, staticConditions map[string]bool, matches *[]rules.Match) {
-	switch val := v.(type) {
-	case map[string]any:
-		if fnIf, ok := val["Fn::If"]; ok {
-			if arr, ok := fnIf.([]any); ok && len(arr) >= 1 {
-				if condName, ok := arr[0].(string); ok {
-					if result, isStatic := staticConditions[condName]; isStatic {
-						if result {
-							*matches = append(*matches, rules.Match{
-								Message: fmt.Sprintf("Fn::If condition '%s' always evaluates to true; the false branch is unreachable", condName),
-								Path:    path,
-							})
-						} else {
-							*matches = append(*matches, rules.Match{
-								Message: fmt.Sprintf("Fn::If condition '%s' always evaluates to false; the true branch is unreachable", condName),
-								Path:    path,
-							})
-						}
-					}
-				}
-			}
-		}
-		for key, child := range val {
-			r.checkValue(child, append(path, key), staticConditions, matches)
-		}
-	case []any:
-		for i, child := range val {
-			r.checkValue(child, append(path, fmt.Sprintf("[%d]", i)), staticConditions, matches)
+// describeContext lists the conditions of a context for messages.
+func describeContext(context []condlogic.Assumption) string {
+	var parts []string
+	for _, a := range context {
+		if a.Condition != "" {
+			parts = append(parts, fmt.Sprintf("'%s' is %t", a.Condition, a.Value))
 		}
 	}
+	return strings.Join(parts, " and ")
+}
+
+// withAssumption returns a copy of context with one more assumption.
+func withAssumption(context []condlogic.Assumption, condition string, value bool) []condlogic.Assumption {
+	return append(slices.Clip(context), condlogic.Assumption{Condition: condition, Value: value})
 }
@@ -3,6 +3,7 @@ package warnings
 import (
 	"fmt"
 
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -21,7 +22,7 @@ func (r *W1034) ShortDesc() string {
 }
 
 func (r *W1034) Description() string {
-	return "Warns about potential issues with Fn::FindInMap values, such as hardcoded keys that could use parameters."
+	return "Warns about potential issues with Fn::FindInMap values, such as hardcoded region keys that could use AWS::Region and region lookups the mapping has no key for."
 }
 
 func (r *W1034) Source() string {
@@ -33,38 +34,42 @@ func (r *W1034) Tags() []string {
 }
 
 func (r *W1034) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, regions.Default)
+}
+
+func (r *W1034) MatchRegion(tmpl *template.Template, region string) []rules.Match {
 	var matches []rules.Match
 
 	// Check resources
 	for resName, res := range tmpl.Resources {
-		r.checkValue(res.Properties, []string{"Resources", resName, "Properties"}, tmpl, &matches)
+		r.checkValue(res.Properties, []string{"Resources", resName, "Properties"}, tmpl, region, &matches)
 	}
 
 	// Check outputs
 	for outName, out := range tmpl.Outputs {
-		r.checkValue(out.Value, []string{"Outputs", outName, "Value"}, tmpl, &matches)
+		r.checkValue(out.Value, []string{"Outputs", outName, "Value"}, tmpl, region, &matches)
 	}
 
 	return matches
 }
 
-func (r *W1034) checkValue(v any, path []string, tmpl *template.Template, matches *[]rules.Match) {
+func (r *W1034) checkValue(v any, path []string, tmpl *template.Template, region string, matches *[]rules.Match) {
 	switch val := v.(type) {
 	case map[string]any:
 		if findInMap, ok := val["Fn::FindInMap"]; ok {
-			r.checkFindInMap(findInMap, path, tmpl, matches)
+			r.checkFindInMap(findInMap, path, tmpl, region, matches)
 		}
 		for key, child := range val {
-			r.checkValue(child, append(path, key), tmpl, matches)
+			r.checkValue(child, append(path, key), tmpl, region, matches)
 		}
 	case []any:
 		for i, child := range val {
-			r.checkValue(child, append(path, fmt.Sprintf("[%d]", i)), tmpl, matches)
+			r.checkValue(child, append(path, fmt.Sprintf("[%d]", i)), tmpl, region, matches)
 		}
 	}
 }
 
-func (r *W1034) checkFindInMap(findInMap any, path []string, tmpl *template.Template, matches *[]rules.Match) {
+func (r *W1034) checkFindInMap(findInMap any, path []string, tmpl *template.Template, region string, matches *[]rules.Match) {
 	arr, ok := findInMap.([]any)
 	if !ok || len(arr) < 3 {
 		return
@@ -77,19 +82,23 @@ func (r *W1034) checkFindInMap(findInMap any, path []string, tmpl *template.Temp
 	}
 
 	// Check if second level key is hardcoded when it could use AWS::Region
-	if secondKey, ok := arr[1].(string); ok {
-		// Check if the key looks like a region and could use !Ref AWS::Region
-		regions := map[string]bool{
-			"us-east-1": true, "us-east-2": true, "us-west-1": true, "us-west-2": true,
-			"eu-west-1": true, "eu-west-2": true, "eu-west-3": true, "eu-central-1": true,
-			"ap-northeast-1": true, "ap-northeast-2": true, "ap-southeast-1": true, "ap-southeast-2": true,
-			"ap-south-1": true, "sa-east-1": true, "ca-central-1": true,
-		}
-		if regions[secondKey] {
-			*matches = append(*matches, rules.Match{
-				Message: fmt.Sprintf("Fn::FindInMap uses hardcoded region '%s'; consider using { Ref: AWS::Region } for portability", secondKey),
-				Path:    path,
-			})
+	if secondKey, ok := arr[1].(string); ok && regions.IsValid(secondKey) {
+		*matches = append(*matches, rules.Match{
+			Message: fmt.Sprintf("Fn::FindInMap uses hardcoded region '%s'; consider using { Ref: AWS::Region } for portability", secondKey),
+			Path:    path,
+		})
+	}
+
+	// A lookup by AWS::Region without a DefaultValue fails in regions the
+	// mapping has no key for
+	if ref, ok := arr[1].(map[string]any); ok && ref["Ref"] == "AWS::Region" && len(arr) == 3 {
+		if mapping, ok := tmpl.Mappings[mapName]; ok {
+			if _, exists := mapping.Values[region]; !exists {
+				*matches = append(*matches, rules.Match{
+					Message: fmt.Sprintf("Fn::FindInMap looks up AWS::Region in mapping '%s', which has no key for the target region", mapName),
+					Path:    path,
+				})
+			}
 		}
 	}
 
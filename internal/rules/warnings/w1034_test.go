@@ -64,3 +64,30 @@ Resources:
 		t.Errorf("Expected 0 matches for FindInMap with default, got %d: %v", len(matches), matches)
 	}
 }
+
+func TestW1034_RegionMissingFromMapping(t *testing.T) {
+	tmpl := `
+AWSTemplateFormatVersion: "2010-09-09"
+Mappings:
+  RegionMap:
+    us-east-1:
+      AMI: ami-12345678
+Resources:
+  MyInstance:
+    Type: AWS::EC2::Instance
+    Properties:
+      ImageId: !FindInMap [RegionMap, !Ref "AWS::Region", AMI]
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W1034{}
+	if matches := rule.MatchRegion(parsed, "us-east-1"); len(matches) != 0 {
+		t.Errorf("Expected 0 matches in us-east-1, got %d: %v", len(matches), matches)
+	}
+	if matches := rule.MatchRegion(parsed, "eu-west-1"); len(matches) != 1 {
+		t.Errorf("Expected 1 match in eu-west-1, got %d: %v", len(matches), matches)
+	}
+}
@@ -3,6 +3,7 @@ package warnings
 import (
 	"fmt"
 
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -33,38 +34,42 @@ func (r *W1036) Tags() []string {
 }
 
 func (r *W1036) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, regions.Default)
+}
+
+func (r *W1036) MatchRegion(tmpl *template.Template, region string) []rules.Match {
 	var matches []rules.Match
 
 	// Check resources
 	for resName, res := range tmpl.Resources {
-		r.checkValue(res.Properties, []string{"Resources", resName, "Properties"}, &matches)
+		r.checkValue(res.Properties, []string{"Resources", resName, "Properties"}, region, &matches)
 	}
 
 	// Check outputs
 	for outName, out := range tmpl.Outputs {
-		r.checkValue(out.Value, []string{"Outputs", outName, "Value"}, &matches)
+		r.checkValue(out.Value, []string{"Outputs", outName, "Value"}, region, &matches)
 	}
 
 	return matches
 }
 
-func (r *W1036) checkValue(v any, path []string, matches *[]rules.Match) {
+func (r *W1036) checkValue(v any, path []string, region string, matches *[]rules.Match) {
 	switch val := v.(type) {
 	case map[string]any:
 		if getAZs, ok := val["Fn::GetAZs"]; ok {
-			r.checkGetAZs(getAZs, path, matches)
+			r.checkGetAZs(getAZs, path, region, matches)
 		}
 		for key, child := range val {
-			r.checkValue(child, append(path, key), matches)
+			r.checkValue(child, append(path, key), region, matches)
 		}
 	case []any:
 		for i, child := range val {
-			r.checkValue(child, append(path, fmt.Sprintf("[%d]", i)), matches)
+			r.checkValue(child, append(path, fmt.Sprintf("[%d]", i)), region, matches)
 		}
 	}
 }
 
-func (r *W1036) checkGetAZs(getAZs any, path []string, matches *[]rules.Match) {
+func (r *W1036) checkGetAZs(getAZs any, path []string, target string, matches *[]rules.Match) {
 	// Fn::GetAZs takes a region string or "" for current region
 	region, ok := getAZs.(string)
 	if !ok {
@@ -73,20 +78,15 @@ func (r *W1036) checkGetAZs(getAZs any, path []string, matches *[]rules.Match) {
 	}
 
 	// Check if a specific region is hardcoded
-	if region != "" {
-		regions := map[string]bool{
-			"us-east-1": true, "us-east-2": true, "us-west-1": true, "us-west-2": true,
-			"eu-west-1": true, "eu-west-2": true, "eu-west-3": true, "eu-central-1": true,
-			"ap-northeast-1": true, "ap-northeast-2": true, "ap-southeast-1": true, "ap-southeast-2": true,
-			"ap-south-1": true, "sa-east-1": true, "ca-central-1": true,
-			"af-south-1": true, "ap-east-1": true, "ap-northeast-3": true, "eu-north-1": true,
-			"eu-south-1": true, "me-south-1": true,
-		}
-		if regions[region] {
-			*matches = append(*matches, rules.Match{
-				Message: fmt.Sprintf("Fn::GetAZs uses hardcoded region '%s'; consider using '' or { Ref: AWS::Region } for portability", region),
-				Path:    path,
-			})
-		}
+	if !regions.IsValid(region) {
+		return
+	}
+	message := fmt.Sprintf("Fn::GetAZs uses hardcoded region '%s'; consider using '' or { Ref: AWS::Region } for portability", region)
+	if region != target {
+		message = fmt.Sprintf("Fn::GetAZs uses hardcoded region '%s', not the stack's region; use '' or { Ref: AWS::Region }", region)
 	}
+	*matches = append(*matches, rules.Match{
+		Message: message,
+		Path:    path,
+	})
 }
@@ -1,6 +1,7 @@
 package warnings
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -64,3 +65,30 @@ Resources:
 		t.Errorf("Expected 0 matches for GetAZs with region, got %d: %v", len(matches), matches)
 	}
 }
+
+func TestW1036_HardcodedRegion(t *testing.T) {
+	tmpl := `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  MySubnet:
+    Type: AWS::EC2::Subnet
+    Properties:
+      AvailabilityZone: !Select [0, !GetAZs ca-west-1]
+      CidrBlock: 10.0.0.0/24
+      VpcId: vpc-12345
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W1036{}
+	matches := rule.MatchRegion(parsed, "us-east-1")
+	if len(matches) != 1 || !strings.Contains(matches[0].Message, "not the stack's region") {
+		t.Errorf("Expected a region mismatch in us-east-1, got %v", matches)
+	}
+	matches = rule.MatchRegion(parsed, "ca-west-1")
+	if len(matches) != 1 || strings.Contains(matches[0].Message, "not the stack's region") {
+		t.Errorf("Expected a portability warning in ca-west-1, got %v", matches)
+	}
+}
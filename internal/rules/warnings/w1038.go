@@ -0,0 +1,94 @@
+package warnings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/regions"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func init() {
+	rules.Register(&W1038{})
+}
+
+// W1038 warns when a hardcoded AWS domain suffix does not match the
+// AWS::URLSuffix value of the target region.
+type W1038 struct{}
+
+func (r *W1038) ID() string { return "W1038" }
+
+func (r *W1038) ShortDesc() string {
+	return "Hardcoded URL suffix does not match region"
+}
+
+func (r *W1038) Description() string {
+	return "Warns when a value hardcodes an AWS domain suffix (e.g. amazonaws.com) that differs from the AWS::URLSuffix of the regions being validated."
+}
+
+func (r *W1038) Source() string {
+	return "https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/pseudo-parameter-reference.html#cfn-pseudo-param-urlsuffix"
+}
+
+func (r *W1038) Tags() []string {
+	return []string{"warnings", "pseudo-parameters", "regions"}
+}
+
+func (r *W1038) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, regions.Default)
+}
+
+func (r *W1038) MatchRegion(tmpl *template.Template, region string) []rules.Match {
+	var matches []rules.Match
+	suffix := regions.URLSuffix(region)
+
+	for resName, res := range tmpl.Resources {
+		r.checkValue(res.Properties, suffix, []string{"Resources", resName, "Properties"}, &matches)
+	}
+
+	for outName, out := range tmpl.Outputs {
+		r.checkValue(out.Value, suffix, []string{"Outputs", outName, "Value"}, &matches)
+	}
+
+	return matches
+}
+
+func (r *W1038) checkValue(v any, suffix string, path []string, matches *[]rules.Match) {
+	switch val := v.(type) {
+	case string:
+		if found := hardcodedURLSuffix(val); found != "" && found != suffix {
+			*matches = append(*matches, rules.Match{
+				Message: fmt.Sprintf("Value '%s' hardcodes '%s' but AWS::URLSuffix in the target region is '%s'; use ${AWS::URLSuffix}", val, found, suffix),
+				Path:    path,
+			})
+		}
+	case map[string]any:
+		for key, child := range val {
+			// Service principals do not follow AWS::URLSuffix in every partition
+			if key == "Principal" {
+				continue
+			}
+			r.checkValue(child, suffix, append(append([]string{}, path...), key), matches)
+		}
+	case []any:
+		for i, child := range val {
+			r.checkValue(child, suffix, append(append([]string{}, path...), fmt.Sprintf("[%d]", i)), matches)
+		}
+	}
+}
+
+// hardcodedURLSuffix returns the AWS domain suffix hardcoded in s, if any.
+func hardcodedURLSuffix(s string) string {
+	const commercial = "amazonaws.com"
+	const china = "amazonaws.com.cn"
+
+	idx := strings.Index(s, commercial)
+	if idx < 0 {
+		return ""
+	}
+	if strings.HasPrefix(s[idx:], china) {
+		return china
+	}
+	return commercial
+}
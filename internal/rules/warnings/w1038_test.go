@@ -0,0 +1,56 @@
+package warnings
+
+import (
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func TestW1038_HardcodedSuffix(t *testing.T) {
+	tmpl := `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+Outputs:
+  Endpoint:
+    Value: !Sub "https://sqs.${AWS::Region}.amazonaws.com/${AWS::AccountId}/queue"
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W1038{}
+	if matches := rule.MatchRegion(parsed, "us-east-1"); len(matches) != 0 {
+		t.Errorf("Expected 0 matches in us-east-1, got %d", len(matches))
+	}
+	if matches := rule.MatchRegion(parsed, "cn-north-1"); len(matches) != 1 {
+		t.Errorf("Expected 1 match in cn-north-1, got %d", len(matches))
+	}
+}
+
+func TestW1038_IgnoresPrincipals(t *testing.T) {
+	tmpl := `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  Role:
+    Type: AWS::IAM::Role
+    Properties:
+      AssumeRolePolicyDocument:
+        Statement:
+          - Effect: Allow
+            Principal:
+              Service: lambda.amazonaws.com
+            Action: sts:AssumeRole
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W1038{}
+	if matches := rule.MatchRegion(parsed, "cn-north-1"); len(matches) != 0 {
+		t.Errorf("Expected 0 matches for service principal, got %d", len(matches))
+	}
+}
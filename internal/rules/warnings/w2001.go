@@ -40,19 +40,9 @@ func (r *W2001) Match(tmpl *template.Template) []rules.Match {
 	// Find all parameter references in the template
 	usedParams := make(map[string]bool)
 
-	// Check Resources
-	for _, res := range tmpl.Resources {
-		findParamRefs(res.Properties, usedParams, tmpl.Parameters)
-	}
-
-	// Check Outputs
-	for _, out := range tmpl.Outputs {
-		findParamRefs(out.Value, usedParams, tmpl.Parameters)
-	}
-
-	// Check Conditions
-	for _, cond := range tmpl.Conditions {
-		findParamRefs(cond.Expression, usedParams, tmpl.Parameters)
+	// Check Resources, Outputs and Conditions
+	for _, v := range tmpl.ReferenceValues() {
+		findParamRefs(v, usedParams, tmpl.Parameters)
 	}
 
 	// Check Metadata
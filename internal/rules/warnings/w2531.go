@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/schema"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -33,32 +34,6 @@ func (r *W2531) Tags() []string {
 	return []string{"warnings", "lambda", "runtime", "deprecation"}
 }
 
-// Deprecated/EOL runtimes and their suggested replacements
-var deprecatedRuntimes = map[string]string{
-	"python2.7":     "python3.12",
-	"python3.6":     "python3.12",
-	"python3.7":     "python3.12",
-	"python3.8":     "python3.12",
-	"nodejs":        "nodejs20.x",
-	"nodejs4.3":     "nodejs20.x",
-	"nodejs6.10":    "nodejs20.x",
-	"nodejs8.10":    "nodejs20.x",
-	"nodejs10.x":    "nodejs20.x",
-	"nodejs12.x":    "nodejs20.x",
-	"nodejs14.x":    "nodejs20.x",
-	"nodejs16.x":    "nodejs20.x",
-	"dotnetcore1.0": "dotnet8",
-	"dotnetcore2.0": "dotnet8",
-	"dotnetcore2.1": "dotnet8",
-	"dotnetcore3.1": "dotnet8",
-	"dotnet5.0":     "dotnet8",
-	"dotnet6":       "dotnet8",
-	"ruby2.5":       "ruby3.3",
-	"ruby2.7":       "ruby3.3",
-	"java8":         "java21",
-	"go1.x":         "provided.al2023",
-}
-
 // Runtimes nearing EOL (warning level)
 var nearingEOLRuntimes = map[string]string{
 	"python3.9":  "python3.12",
@@ -83,7 +58,7 @@ func (r *W2531) Match(tmpl *template.Template) []rules.Match {
 		runtimeLower := strings.ToLower(runtime)
 
 		// Check for deprecated/EOL runtimes
-		if replacement, deprecated := deprecatedRuntimes[runtimeLower]; deprecated {
+		if replacement, deprecated := schema.LambdaRuntimeSuccessor(runtime); deprecated {
 			matches = append(matches, rules.Match{
 				Message: fmt.Sprintf("Lambda function '%s' uses deprecated runtime '%s'; consider upgrading to '%s'", resName, runtime, replacement),
 				Path:    []string{"Resources", resName, "Properties", "Runtime"},
@@ -102,3 +77,23 @@ func (r *W2531) Match(tmpl *template.Template) []rules.Match {
 
 	return matches
 }
+
+// Fix replaces the runtime with the suggested one.
+func (r *W2531) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	resolved := tmpl.ResolvePath(m.Path)
+	if resolved == nil || !resolved.Exact(len(m.Path)) || resolved.Value.ShortTag() != "!!str" {
+		return nil
+	}
+	runtime := resolved.Value.Value
+	replacement, ok := schema.LambdaRuntimeSuccessor(runtime)
+	if !ok {
+		replacement, ok = nearingEOLRuntimes[strings.ToLower(runtime)]
+	}
+	if !ok {
+		return nil
+	}
+	return &rules.Fix{
+		Description: fmt.Sprintf("Replace runtime %s with %s", runtime, replacement),
+		Edits:       []rules.TextEdit{rules.ReplaceScalar(tmpl, resolved.Value, replacement)},
+	}
+}
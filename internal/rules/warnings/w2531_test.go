@@ -3,6 +3,7 @@ package warnings
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -81,3 +82,29 @@ Resources:
 		t.Errorf("Expected matches for deprecated runtime, got 0")
 	}
 }
+
+func TestW2531_Fix(t *testing.T) {
+	tmpl := `Resources:
+  Old:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: "python3.7"
+  Ageing:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: nodejs18.x
+`
+	want := `Resources:
+  Old:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: "python3.12"
+  Ageing:
+    Type: AWS::Lambda::Function
+    Properties:
+      Runtime: nodejs20.x
+`
+	if got := testutil.ApplyFixes(t, &W2531{}, tmpl); got != want {
+		t.Errorf("Unexpected fixed template:\n%s", got)
+	}
+}
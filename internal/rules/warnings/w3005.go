@@ -3,9 +3,11 @@ package warnings
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
+	"gopkg.in/yaml.v3"
 )
 
 func init() {
@@ -59,6 +61,77 @@ func (r *W3005) Match(tmpl *template.Template) []rules.Match {
 	return matches
 }
 
+// Fix removes every redundant DependsOn entry of the match's resource, so
+// the matches for one resource share a fix. The whole DependsOn is removed
+// when nothing else is left in it.
+func (r *W3005) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	if len(m.Path) != 3 {
+		return nil
+	}
+	res, ok := tmpl.Resources[m.Path[1]]
+	if !ok || res.Node == nil {
+		return nil
+	}
+	resolved := tmpl.ResolvePath(m.Path)
+	if resolved == nil || !resolved.Exact(len(m.Path)) {
+		return nil
+	}
+	node := resolved.Value
+
+	implicitDeps := make(map[string]bool)
+	findImplicitDependencies(res.Properties, implicitDeps, tmpl.Resources)
+
+	var keep []*yaml.Node
+	var drop []int
+	var removed []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if !implicitDeps[node.Value] {
+			return nil
+		}
+		removed = append(removed, node.Value)
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if item.Kind == yaml.ScalarNode && implicitDeps[item.Value] {
+				drop = append(drop, i)
+				removed = append(removed, item.Value)
+			} else {
+				keep = append(keep, item)
+			}
+		}
+	default:
+		return nil
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	fix := &rules.Fix{Description: fmt.Sprintf("Remove redundant DependsOn %s", strings.Join(removed, ", "))}
+
+	switch {
+	case len(keep) == 0:
+		edit, ok := rules.DeleteEntry(tmpl, res.Node, "DependsOn")
+		if !ok {
+			return nil
+		}
+		fix.Edits = append(fix.Edits, edit)
+	case node.Style&yaml.FlowStyle != 0:
+		texts := make([]string, len(keep))
+		for i, item := range keep {
+			texts[i] = tmpl.SourceText(tmpl.NodeSpan(item))
+		}
+		fix.Edits = append(fix.Edits, rules.ReplaceNode(tmpl, node, "["+strings.Join(texts, ", ")+"]"))
+	default:
+		for _, i := range drop {
+			edit, ok := rules.DeleteItem(tmpl, node, i)
+			if !ok {
+				return nil
+			}
+			fix.Edits = append(fix.Edits, edit)
+		}
+	}
+	return fix
+}
+
 func findImplicitDependencies(v any, deps map[string]bool, resources map[string]*template.Resource) {
 	switch val := v.(type) {
 	case map[string]any:
@@ -3,6 +3,7 @@ package warnings
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -83,3 +84,57 @@ Resources:
 		t.Errorf("Expected 0 matches for valid DependsOn, got %d: %v", len(matches), matches)
 	}
 }
+
+func TestW3005_Fix(t *testing.T) {
+	tmpl := `Resources:
+  Topic:
+    Type: AWS::SNS::Topic
+  Queue:
+    Type: AWS::SQS::Queue
+  Single:
+    Type: AWS::SNS::Subscription
+    DependsOn: Topic  # why
+    Properties:
+      TopicArn: !Ref Topic
+  Block:
+    Type: AWS::SNS::Subscription
+    DependsOn:
+      - Topic # implicit
+      - Queue
+    Properties:
+      TopicArn: !Ref Topic
+  Flow:
+    Type: AWS::SNS::Subscription
+    DependsOn: [Topic, Queue, Single]
+    Properties:
+      TopicArn: !Ref Topic
+      Endpoint: !GetAtt Queue.Arn
+`
+	want := `Resources:
+  Topic:
+    Type: AWS::SNS::Topic
+  Queue:
+    Type: AWS::SQS::Queue
+  Single:
+    Type: AWS::SNS::Subscription
+    # why
+    Properties:
+      TopicArn: !Ref Topic
+  Block:
+    Type: AWS::SNS::Subscription
+    DependsOn:
+      # implicit
+      - Queue
+    Properties:
+      TopicArn: !Ref Topic
+  Flow:
+    Type: AWS::SNS::Subscription
+    DependsOn: [Single]
+    Properties:
+      TopicArn: !Ref Topic
+      Endpoint: !GetAtt Queue.Arn
+`
+	if got := testutil.ApplyFixes(t, &W3005{}, tmpl); got != want {
+		t.Errorf("Unexpected fixed template:\n%s", got)
+	}
+}
@@ -3,8 +3,8 @@ package warnings
 
 import (
 	"fmt"
-	"regexp"
 
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -13,7 +13,8 @@ func init() {
 	rules.Register(&W3010{})
 }
 
-// W3010 warns about hardcoded availability zones.
+// W3010 warns about hardcoded availability zones, and that the zone is not
+// in the target region when it is not.
 type W3010 struct{}
 
 func (r *W3010) ID() string { return "W3010" }
@@ -34,17 +35,22 @@ func (r *W3010) Tags() []string {
 	return []string{"warnings", "resources", "availability-zones"}
 }
 
-// Pattern to match AWS availability zone format (e.g., us-east-1a, eu-west-2b)
-var azPattern = regexp.MustCompile(`^[a-z]{2}-[a-z]+-\d[a-z]$`)
-
 func (r *W3010) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, regions.Default)
+}
+
+func (r *W3010) MatchRegion(tmpl *template.Template, region string) []rules.Match {
 	var matches []rules.Match
 
 	for resName, res := range tmpl.Resources {
 		hardcodedAZs := findHardcodedAZs(res.Properties, nil)
 		for _, az := range hardcodedAZs {
+			message := fmt.Sprintf("Hardcoded availability zone '%s' in resource '%s'. Use Fn::GetAZs or a parameter for portability.", az.value, resName)
+			if azRegion, _ := regions.AvailabilityZoneRegion(az.value); azRegion != region {
+				message = fmt.Sprintf("Hardcoded availability zone '%s' in resource '%s' is not in the target region. Use Fn::GetAZs or a parameter.", az.value, resName)
+			}
 			matches = append(matches, rules.Match{
-				Message: fmt.Sprintf("Hardcoded availability zone '%s' in resource '%s'. Use Fn::GetAZs or a parameter for portability.", az.value, resName),
+				Message: message,
 				Path:    append([]string{"Resources", resName, "Properties"}, az.path...),
 			})
 		}
@@ -63,7 +69,7 @@ func findHardcodedAZs(v any, path []string) []azInfo {
 
 	switch val := v.(type) {
 	case string:
-		if azPattern.MatchString(val) {
+		if _, ok := regions.AvailabilityZoneRegion(val); ok {
 			results = append(results, azInfo{value: val, path: path})
 		}
 	case map[string]any:
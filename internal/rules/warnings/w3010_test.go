@@ -1,6 +1,7 @@
 package warnings
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -77,3 +78,31 @@ Resources:
 		t.Errorf("Expected 0 matches for parameter AZ, got %d: %v", len(matches), matches)
 	}
 }
+
+func TestW3010_ZoneOutsideRegion(t *testing.T) {
+	tmpl := `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  MySubnet:
+    Type: AWS::EC2::Subnet
+    Properties:
+      VpcId: vpc-12345
+      AvailabilityZone: us-gov-west-1a
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W3010{}
+	matches := rule.MatchRegion(parsed, "eu-west-1")
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(matches))
+	}
+	if !strings.Contains(matches[0].Message, "is not in the target region") {
+		t.Errorf("Expected a region mismatch, got %q", matches[0].Message)
+	}
+	if matches := rule.MatchRegion(parsed, "us-gov-west-1"); len(matches) != 1 || strings.Contains(matches[0].Message, "not in the target region") {
+		t.Errorf("Expected a portability warning in us-gov-west-1, got %v", matches)
+	}
+}
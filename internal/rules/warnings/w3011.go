@@ -33,6 +33,13 @@ func (r *W3011) Tags() []string {
 	return []string{"warnings", "resources", "deletion-policy", "update-replace-policy"}
 }
 
+// snapshotUnsupportedTypes cannot be snapshotted on deletion or replacement.
+var snapshotUnsupportedTypes = map[string]bool{
+	"AWS::S3::Bucket":       true,
+	"AWS::Lambda::Function": true,
+	"AWS::IAM::Role":        true,
+}
+
 func (r *W3011) Match(tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
 
@@ -80,12 +87,6 @@ func (r *W3011) Match(tmpl *template.Template) []rules.Match {
 		}
 
 		// Check for Snapshot policy on resources that don't support it
-		snapshotUnsupportedTypes := map[string]bool{
-			"AWS::S3::Bucket":       true,
-			"AWS::Lambda::Function": true,
-			"AWS::IAM::Role":        true,
-		}
-
 		if deletionPolicy == "Snapshot" && snapshotUnsupportedTypes[res.Type] {
 			matches = append(matches, rules.Match{
 				Message: fmt.Sprintf("Resource '%s' of type '%s' has DeletionPolicy 'Snapshot' but this resource type does not support snapshots", resName, res.Type),
@@ -125,3 +126,41 @@ func (r *W3011) getPolicies(res *template.Resource) (string, string) {
 
 	return deletionPolicy, updateReplacePolicy
 }
+
+// Fix resolves the findings with a clear intent: a Retain DeletionPolicy
+// also retains on replacement, and unsupported Snapshot policies become
+// Retain. A Delete DeletionPolicy with a Retain UpdateReplacePolicy may be
+// intentional and is left alone.
+func (r *W3011) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	if len(m.Path) < 2 {
+		return nil
+	}
+	res, ok := tmpl.Resources[m.Path[1]]
+	if !ok || res.Node == nil || res.Node.Kind != yaml.MappingNode {
+		return nil
+	}
+	deletionPolicy, updateReplacePolicy := r.getPolicies(res)
+
+	switch {
+	case len(m.Path) == 3 && snapshotUnsupportedTypes[res.Type]:
+		// DeletionPolicy or UpdateReplacePolicy: Snapshot
+		return r.replacePolicy(tmpl, m.Path, "Retain")
+	case len(m.Path) != 2:
+		return nil
+	case deletionPolicy == "Retain" && updateReplacePolicy == "Delete":
+		return r.replacePolicy(tmpl, append(m.Path[:2:2], "UpdateReplacePolicy"), "Retain")
+	}
+	return nil
+}
+
+// replacePolicy returns a fix setting the policy at path to value.
+func (r *W3011) replacePolicy(tmpl *template.Template, path []string, value string) *rules.Fix {
+	resolved := tmpl.ResolvePath(path)
+	if resolved == nil || !resolved.Exact(len(path)) || resolved.Value.ShortTag() != "!!str" {
+		return nil
+	}
+	return &rules.Fix{
+		Description: fmt.Sprintf("Set %s to %s", path[len(path)-1], value),
+		Edits:       []rules.TextEdit{rules.ReplaceScalar(tmpl, resolved.Value, value)},
+	}
+}
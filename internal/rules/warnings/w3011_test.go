@@ -3,6 +3,7 @@ package warnings
 import (
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -49,3 +50,37 @@ Resources:
 		t.Errorf("Expected matches for conflicting policies, got 0")
 	}
 }
+
+func TestW3011_Fix(t *testing.T) {
+	tmpl := `Resources:
+  Topic:
+    Type: AWS::SNS::Topic
+    DeletionPolicy: Retain
+    UpdateReplacePolicy: Delete
+  Bucket:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: Snapshot
+    UpdateReplacePolicy: Snapshot
+  Queue:
+    Type: AWS::SQS::Queue
+    DeletionPolicy: Delete
+    UpdateReplacePolicy: Retain
+`
+	want := `Resources:
+  Topic:
+    Type: AWS::SNS::Topic
+    DeletionPolicy: Retain
+    UpdateReplacePolicy: Retain
+  Bucket:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: Retain
+    UpdateReplacePolicy: Retain
+  Queue:
+    Type: AWS::SQS::Queue
+    DeletionPolicy: Delete
+    UpdateReplacePolicy: Retain
+`
+	if got := testutil.ApplyFixes(t, &W3011{}, tmpl); got != want {
+		t.Errorf("Unexpected fixed template:\n%s", got)
+	}
+}
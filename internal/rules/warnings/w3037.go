@@ -2,6 +2,7 @@ package warnings
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/lex00/cfn-lint-go/pkg/rules"
@@ -40,29 +41,60 @@ var overlyPermissivePolicies = map[string]string{
 	"arn:aws:iam::aws:policy/IAMFullAccess":       "grants full IAM access",
 }
 
+// Options returns the configurable options for W3037.
+func (r *W3037) Options() []rules.Option {
+	return []rules.Option{
+		{Name: "deny_policies", Type: rules.OptionStringList, Default: defaultDeniedPolicies(), Description: "Managed policy ARNs considered overly permissive"},
+	}
+}
+
+// defaultDeniedPolicies returns the overly permissive policies, sorted.
+func defaultDeniedPolicies() []string {
+	policies := make([]string, 0, len(overlyPermissivePolicies))
+	for arn := range overlyPermissivePolicies {
+		policies = append(policies, arn)
+	}
+	sort.Strings(policies)
+	return policies
+}
+
 func (r *W3037) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchWithContext(rules.DefaultContext(r), tmpl)
+}
+
+// MatchWithContext checks IAM resources against the configured deny list.
+func (r *W3037) MatchWithContext(ctx *rules.Context, tmpl *template.Template) []rules.Match {
 	var matches []rules.Match
 
+	denied := make(map[string]string)
+	for _, arn := range ctx.StringList("deny_policies") {
+		reason, ok := overlyPermissivePolicies[arn]
+		if !ok {
+			reason = "is on the configured deny list"
+		}
+		denied[arn] = reason
+	}
+
 	for resName, res := range tmpl.Resources {
 		switch res.Type {
 		case "AWS::IAM::Role":
-			r.checkRole(resName, res, &matches)
+			r.checkRole(resName, res, denied, &matches)
 		case "AWS::IAM::User":
-			r.checkUser(resName, res, &matches)
+			r.checkUser(resName, res, denied, &matches)
 		case "AWS::IAM::Group":
-			r.checkGroup(resName, res, &matches)
+			r.checkGroup(resName, res, denied, &matches)
 		}
 	}
 
 	return matches
 }
 
-func (r *W3037) checkRole(resName string, res *template.Resource, matches *[]rules.Match) {
+func (r *W3037) checkRole(resName string, res *template.Resource, denied map[string]string, matches *[]rules.Match) {
 	// Check for overly permissive managed policies
 	if managedPolicies, ok := res.Properties["ManagedPolicyArns"].([]any); ok {
 		for _, policy := range managedPolicies {
 			if policyArn, ok := policy.(string); ok {
-				if reason, found := overlyPermissivePolicies[policyArn]; found {
+				if reason, found := denied[policyArn]; found {
 					*matches = append(*matches, rules.Match{
 						Message: fmt.Sprintf("IAM Role '%s' uses '%s' which %s; consider using more restrictive policies", resName, policyArn, reason),
 						Path:    []string{"Resources", resName, "Properties", "ManagedPolicyArns"},
@@ -76,7 +108,7 @@ func (r *W3037) checkRole(resName string, res *template.Resource, matches *[]rul
 	_, hasBoundary := res.Properties["PermissionsBoundary"]
 	if !hasBoundary {
 		// Check if the role has admin-like policies
-		if r.hasAdminLikePolicies(res) {
+		if r.hasAdminLikePolicies(res, denied) {
 			*matches = append(*matches, rules.Match{
 				Message: fmt.Sprintf("IAM Role '%s' has broad permissions but no PermissionsBoundary; consider adding a permissions boundary", resName),
 				Path:    []string{"Resources", resName, "Properties"},
@@ -103,7 +135,7 @@ func (r *W3037) checkRole(resName string, res *template.Resource, matches *[]rul
 	}
 }
 
-func (r *W3037) checkUser(resName string, res *template.Resource, matches *[]rules.Match) {
+func (r *W3037) checkUser(resName string, res *template.Resource, denied map[string]string, matches *[]rules.Match) {
 	// Warn about creating IAM users (prefer roles)
 	*matches = append(*matches, rules.Match{
 		Message: fmt.Sprintf("Resource '%s' creates an IAM User; consider using IAM Roles with temporary credentials instead", resName),
@@ -114,7 +146,7 @@ func (r *W3037) checkUser(resName string, res *template.Resource, matches *[]rul
 	if managedPolicies, ok := res.Properties["ManagedPolicyArns"].([]any); ok {
 		for _, policy := range managedPolicies {
 			if policyArn, ok := policy.(string); ok {
-				if reason, found := overlyPermissivePolicies[policyArn]; found {
+				if reason, found := denied[policyArn]; found {
 					*matches = append(*matches, rules.Match{
 						Message: fmt.Sprintf("IAM User '%s' uses '%s' which %s; consider using more restrictive policies", resName, policyArn, reason),
 						Path:    []string{"Resources", resName, "Properties", "ManagedPolicyArns"},
@@ -130,12 +162,12 @@ func (r *W3037) checkUser(resName string, res *template.Resource, matches *[]rul
 	}
 }
 
-func (r *W3037) checkGroup(resName string, res *template.Resource, matches *[]rules.Match) {
+func (r *W3037) checkGroup(resName string, res *template.Resource, denied map[string]string, matches *[]rules.Match) {
 	// Check for overly permissive managed policies
 	if managedPolicies, ok := res.Properties["ManagedPolicyArns"].([]any); ok {
 		for _, policy := range managedPolicies {
 			if policyArn, ok := policy.(string); ok {
-				if reason, found := overlyPermissivePolicies[policyArn]; found {
+				if reason, found := denied[policyArn]; found {
 					*matches = append(*matches, rules.Match{
 						Message: fmt.Sprintf("IAM Group '%s' uses '%s' which %s; consider using more restrictive policies", resName, policyArn, reason),
 						Path:    []string{"Resources", resName, "Properties", "ManagedPolicyArns"},
@@ -146,11 +178,11 @@ func (r *W3037) checkGroup(resName string, res *template.Resource, matches *[]ru
 	}
 }
 
-func (r *W3037) hasAdminLikePolicies(res *template.Resource) bool {
+func (r *W3037) hasAdminLikePolicies(res *template.Resource, denied map[string]string) bool {
 	if managedPolicies, ok := res.Properties["ManagedPolicyArns"].([]any); ok {
 		for _, policy := range managedPolicies {
 			if policyArn, ok := policy.(string); ok {
-				if _, found := overlyPermissivePolicies[policyArn]; found {
+				if _, found := denied[policyArn]; found {
 					return true
 				}
 			}
@@ -1,8 +1,10 @@
 package warnings
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -65,3 +67,36 @@ Resources:
 		t.Errorf("Expected matches for AdministratorAccess policy, got 0")
 	}
 }
+
+func TestW3037_ConfiguredDenyList(t *testing.T) {
+	tmpl := `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  MyGroup:
+    Type: AWS::IAM::Group
+    Properties:
+      ManagedPolicyArns:
+        - arn:aws:iam::aws:policy/AdministratorAccess
+        - arn:aws:iam::aws:policy/AmazonS3FullAccess
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W3037{}
+	ctx, err := rules.NewContext(rule, map[string]any{
+		"deny_policies": []any{"arn:aws:iam::aws:policy/AmazonS3FullAccess"},
+	})
+	if err != nil {
+		t.Fatalf("NewContext failed: %v", err)
+	}
+	matches := rule.MatchWithContext(ctx, parsed)
+
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match for configured deny list, got %d: %v", len(matches), matches)
+	}
+	if !strings.Contains(matches[0].Message, "AmazonS3FullAccess") {
+		t.Errorf("Expected denied policy in message, got %q", matches[0].Message)
+	}
+}
@@ -3,6 +3,7 @@ package warnings
 import (
 	"fmt"
 
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
@@ -172,19 +173,6 @@ func (r *W4005) checkRegions(v any, path []string, matches *[]rules.Match) {
 		return
 	}
 
-	validRegions := map[string]bool{
-		"us-east-1": true, "us-east-2": true, "us-west-1": true, "us-west-2": true,
-		"af-south-1": true, "ap-east-1": true, "ap-south-1": true, "ap-south-2": true,
-		"ap-southeast-1": true, "ap-southeast-2": true, "ap-southeast-3": true, "ap-southeast-4": true,
-		"ap-northeast-1": true, "ap-northeast-2": true, "ap-northeast-3": true,
-		"ca-central-1": true, "ca-west-1": true,
-		"eu-central-1": true, "eu-central-2": true,
-		"eu-west-1": true, "eu-west-2": true, "eu-west-3": true,
-		"eu-south-1": true, "eu-south-2": true, "eu-north-1": true,
-		"il-central-1": true, "me-south-1": true, "me-central-1": true,
-		"sa-east-1": true,
-	}
-
 	for i, item := range list {
 		region, ok := item.(string)
 		if !ok {
@@ -195,7 +183,7 @@ func (r *W4005) checkRegions(v any, path []string, matches *[]rules.Match) {
 			continue
 		}
 
-		if !validRegions[region] {
+		if region != regions.AllRegions && !regions.IsValid(region) {
 			*matches = append(*matches, rules.Match{
 				Message: fmt.Sprintf("Unknown AWS region '%s' in cfn-lint regions", region),
 				Path:    append(path, fmt.Sprintf("[%d]", i)),
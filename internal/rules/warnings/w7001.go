@@ -43,19 +43,9 @@ func (r *W7001) Match(tmpl *template.Template) []rules.Match {
 	// Find all mapping references
 	usedMappings := make(map[string]bool)
 
-	// Check Resources
-	for _, res := range tmpl.Resources {
-		findMappingRefs(res.Properties, usedMappings)
-	}
-
-	// Check Outputs
-	for _, out := range tmpl.Outputs {
-		findMappingRefs(out.Value, usedMappings)
-	}
-
-	// Check Conditions
-	for _, cond := range tmpl.Conditions {
-		findMappingRefs(cond.Expression, usedMappings)
+	// Check Resources, Outputs and Conditions
+	for _, v := range tmpl.ReferenceValues() {
+		findMappingRefs(v, usedMappings)
 	}
 
 	// Report unused mappings
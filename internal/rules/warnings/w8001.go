@@ -43,26 +43,22 @@ func (r *W8001) Match(tmpl *template.Template) []rules.Match {
 	// Find all condition references
 	usedConditions := make(map[string]bool)
 
-	// Check Resources for Condition attribute
+	// Check Resources and Outputs for Condition attribute
 	for _, res := range tmpl.Resources {
 		if res.Condition != "" {
 			usedConditions[res.Condition] = true
 		}
-		// Also check for Fn::If in properties
-		findConditionRefs(res.Properties, usedConditions)
 	}
-
-	// Check Outputs for Condition attribute
 	for _, out := range tmpl.Outputs {
 		if out.Condition != "" {
 			usedConditions[out.Condition] = true
 		}
-		findConditionRefs(out.Value, usedConditions)
 	}
 
-	// Check Conditions section for Fn::Condition references
-	for _, cond := range tmpl.Conditions {
-		findConditionRefs(cond.Expression, usedConditions)
+	// Check Resources, Outputs and Conditions for Fn::If and Condition
+	// references
+	for _, v := range tmpl.ReferenceValues() {
+		findConditionRefs(v, usedConditions)
 	}
 
 	// Report unused conditions
@@ -0,0 +1,62 @@
+// Package warnings contains warning-level rules (Wxxx).
+package warnings
+
+import (
+	"fmt"
+
+	"github.com/lex00/cfn-lint-go/pkg/condlogic"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func init() {
+	rules.Register(&W8002{})
+}
+
+// W8002 warns about conditions that are false for every deployment, such as
+// an Fn::And of two values of the same parameter, or an Fn::Equals with a
+// value the parameter's AllowedValues exclude.
+type W8002 struct{}
+
+func (r *W8002) ID() string { return "W8002" }
+
+func (r *W8002) ShortDesc() string {
+	return "Condition can never be true"
+}
+
+func (r *W8002) Description() string {
+	return "Warns when a condition is false for every combination of parameter values and region, so the resources, outputs and Fn::If branches that need it are never used."
+}
+
+func (r *W8002) Source() string {
+	return "https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/conditions-section-structure.html"
+}
+
+func (r *W8002) Tags() []string {
+	return []string{"warnings", "conditions"}
+}
+
+func (r *W8002) Match(tmpl *template.Template) []rules.Match {
+	var matches []rules.Match
+	if len(tmpl.Conditions) == 0 {
+		return matches
+	}
+
+	solver := condlogic.New(tmpl)
+	for condName, cond := range tmpl.Conditions {
+		// W8003 reports Fn::Equals of two static values
+		if expr, ok := cond.Expression.(map[string]any); ok {
+			if equals, ok := expr["Fn::Equals"].([]any); ok && len(equals) == 2 && isStaticValue(equals[0]) && isStaticValue(equals[1]) {
+				continue
+			}
+		}
+		if solver.NeverTrue(condName) {
+			matches = append(matches, rules.Match{
+				Message: fmt.Sprintf("Condition '%s' can never be true with any parameter values or region", condName),
+				Path:    []string{"Conditions", condName},
+			})
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,97 @@
+package warnings
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+func TestW8002_SatisfiableConditions(t *testing.T) {
+	tmpl := `
+Parameters:
+  Env:
+    Type: String
+    AllowedValues: [dev, prod]
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsProdInEU: !And [!Condition IsProd, !Equals [!Ref "AWS::Region", eu-west-1]]
+  IsDevOrEU: !Or [!Not [!Condition IsProd], !Equals [!Ref "AWS::Region", eu-west-1]]
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Condition: IsProdInEU
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W8002{}
+	matches := rule.Match(parsed)
+
+	if len(matches) != 0 {
+		t.Errorf("Expected 0 matches for satisfiable conditions, got %d: %v", len(matches), matches)
+	}
+}
+
+func TestW8002_NeverTrue(t *testing.T) {
+	tmpl := `
+Parameters:
+  Env:
+    Type: String
+    AllowedValues: [dev, prod]
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsDev: !Equals [!Ref Env, dev]
+  ProdAndDev: !And [!Condition IsProd, !Condition IsDev]
+  IsTest: !Equals [!Ref Env, test]
+  Static: !Equals [a, b]
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Condition: ProdAndDev
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W8002{}
+	matches := rule.Match(parsed)
+
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches (ProdAndDev, IsTest), got %d: %v", len(matches), matches)
+	}
+	for _, m := range matches {
+		if m.Path[1] != "ProdAndDev" && m.Path[1] != "IsTest" {
+			t.Errorf("Unexpected match for %v: %s", m.Path, m.Message)
+		}
+		if !strings.Contains(m.Message, "can never be true") {
+			t.Errorf("Unexpected message %q", m.Message)
+		}
+	}
+}
+
+func TestW8002_NonMapExpression(t *testing.T) {
+	tmpl := `
+Conditions:
+  Scalar: true
+  List: [a, b]
+  Empty: null
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+`
+	parsed, err := template.Parse([]byte(tmpl))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	rule := &W8002{}
+	matches := rule.Match(parsed)
+
+	if len(matches) != 0 {
+		t.Errorf("Expected 0 matches for conditions that are not functions, got %d: %v", len(matches), matches)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/fix"
 	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -73,6 +75,35 @@ func LoadTemplateBytes(t *testing.T, content []byte) *template.Template {
 	return tmpl
 }
 
+// ApplyFixes runs a fixable rule on a template and returns the source with
+// the fixes for all its matches applied. Identical edits from several
+// matches are applied once.
+func ApplyFixes(t *testing.T, rule rules.FixableRule, source string) string {
+	t.Helper()
+	tmpl := LoadTemplateBytes(t, []byte(source))
+
+	var edits []rules.TextEdit
+	seen := make(map[rules.TextEdit]bool)
+	for _, m := range rule.Match(tmpl) {
+		f := rule.Fix(tmpl, m)
+		if f == nil {
+			continue
+		}
+		for _, e := range f.Edits {
+			if !seen[e] {
+				seen[e] = true
+				edits = append(edits, e)
+			}
+		}
+	}
+
+	fixed, err := fix.ApplyEdits([]byte(source), edits)
+	if err != nil {
+		t.Fatalf("Failed to apply fixes: %v", err)
+	}
+	return string(fixed)
+}
+
 // LintFile lints a template file and returns the matches.
 func LintFile(t *testing.T, path string, opts lint.Options) []lint.Match {
 	t.Helper()
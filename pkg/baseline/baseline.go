@@ -0,0 +1,188 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+)
+
+// Version is the baseline file format version.
+const Version = 1
+
+// Baseline is a recorded set of findings.
+type Baseline struct {
+	// Version is the file format version.
+	Version int `json:"version"`
+
+	// Findings lists the recorded findings. A finding reported several
+	// times is recorded once per occurrence.
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a single recorded finding. Only the fingerprint is used for
+// matching; the other fields keep the baseline file readable in reviews.
+type Finding struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	File        string `json:"file"`
+	Path        string `json:"path,omitempty"`
+	Message     string `json:"message"`
+}
+
+// Result is the outcome of filtering matches against a baseline.
+type Result struct {
+	// Matches holds the new findings, plus any suppressed matches.
+	Matches []lint.Match
+
+	// Existing is the number of findings that were already in the baseline.
+	Existing int
+
+	// Fixed is the number of baseline findings that are no longer reported.
+	Fixed int
+}
+
+// New records the unsuppressed matches as a baseline.
+func New(matches []lint.Match) *Baseline {
+	b := &Baseline{Version: Version, Findings: []Finding{}}
+	for _, m := range matches {
+		if m.Suppression != nil {
+			continue
+		}
+		b.Findings = append(b.Findings, Finding{
+			Fingerprint: Fingerprint(m),
+			Rule:        m.Rule.ID,
+			File:        normalizeFile(m.Location.Filename),
+			Path:        joinPath(m.Location.Path),
+			Message:     m.Message,
+		})
+	}
+
+	// Keep the file stable across runs so it diffs cleanly
+	sort.SliceStable(b.Findings, func(i, j int) bool {
+		a, c := b.Findings[i], b.Findings[j]
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		if a.Rule != c.Rule {
+			return a.Rule < c.Rule
+		}
+		if a.Path != c.Path {
+			return a.Path < c.Path
+		}
+		return a.Message < c.Message
+	})
+	return b
+}
+
+// Load reads a baseline file. A JSON array of matches, as written by
+// --format json, is also accepted.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline file: %w", err)
+	}
+
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		var matches []lint.Match
+		if err := json.Unmarshal(data, &matches); err != nil {
+			return nil, fmt.Errorf("parsing baseline matches: %w", err)
+		}
+		return New(matches), nil
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing baseline file: %w", err)
+	}
+	if b.Version != Version {
+		return nil, fmt.Errorf("unsupported baseline version %d (expected %d)", b.Version, Version)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline to path as indented JSON.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing baseline file: %w", err)
+	}
+	return nil
+}
+
+// Filter removes the matches recorded in the baseline. Each recorded
+// finding absorbs one match with the same fingerprint, so a second
+// occurrence of a known problem is still reported as new. Suppressed
+// matches are passed through unchanged.
+func (b *Baseline) Filter(matches []lint.Match) Result {
+	remaining := make(map[string]int, len(b.Findings))
+	for _, f := range b.Findings {
+		remaining[f.Fingerprint]++
+	}
+
+	var result Result
+	for _, m := range matches {
+		if m.Suppression == nil {
+			fp := Fingerprint(m)
+			if remaining[fp] > 0 {
+				remaining[fp]--
+				result.Existing++
+				continue
+			}
+		}
+		result.Matches = append(result.Matches, m)
+	}
+
+	for _, n := range remaining {
+		result.Fixed += n
+	}
+	return result
+}
+
+// Fingerprint returns a stable identifier for a match built from its rule
+// ID, file, logical path and normalized message. Line and column numbers
+// are left out so that unrelated edits to a template do not turn existing
+// findings into new ones.
+func Fingerprint(m lint.Match) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		m.Rule.ID,
+		normalizeFile(m.Location.Filename),
+		joinPath(m.Location.Path),
+		normalizeMessage(m.Message),
+	}, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+var numberPattern = regexp.MustCompile(`[0-9]+`)
+
+// normalizeMessage removes the parts of a message that change without the
+// finding changing: numbers (line numbers, counts) and whitespace.
+func normalizeMessage(message string) string {
+	message = numberPattern.ReplaceAllString(message, "#")
+	return strings.Join(strings.Fields(message), " ")
+}
+
+func normalizeFile(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
+func joinPath(path []any) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = fmt.Sprint(p)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,168 @@
+package baseline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+)
+
+func match(rule, file string, line int, message string, path ...any) lint.Match {
+	return lint.Match{
+		Rule: lint.MatchRule{ID: rule},
+		Location: lint.MatchLocation{
+			Filename: file,
+			Start:    lint.MatchPosition{LineNumber: line, ColumnNumber: 1},
+			Path:     path,
+		},
+		Level:   "Error",
+		Message: message,
+	}
+}
+
+func TestFingerprint_IgnoresPosition(t *testing.T) {
+	a := match("E3012", "template.yaml", 10, "Property 'Port' at line 10 should be Integer", "Resources", "SG", "Properties", "Port")
+	b := match("E3012", "./template.yaml", 42, "Property  'Port' at line 42 should be Integer", "Resources", "SG", "Properties", "Port")
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("Expected fingerprints to ignore line numbers, whitespace and path cleanup")
+	}
+
+	tests := []struct {
+		name  string
+		other lint.Match
+	}{
+		{"rule", match("E3013", "template.yaml", 10, a.Message, a.Location.Path...)},
+		{"file", match("E3012", "other.yaml", 10, a.Message, a.Location.Path...)},
+		{"path", match("E3012", "template.yaml", 10, a.Message, "Resources", "Other")},
+		{"message", match("E3012", "template.yaml", 10, "Property 'Port' should be String", a.Location.Path...)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if Fingerprint(a) == Fingerprint(tc.other) {
+				t.Errorf("Expected a different %s to change the fingerprint", tc.name)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	old := []lint.Match{
+		match("W2001", "template.yaml", 3, "Parameter Env not used", "Parameters", "Env"),
+		match("E3012", "template.yaml", 10, "Wrong type", "Resources", "SG"),
+		match("E3012", "template.yaml", 11, "Wrong type", "Resources", "SG"),
+		match("W3005", "template.yaml", 20, "Obsolete DependsOn", "Resources", "Fn"),
+	}
+	b := New(old)
+
+	suppressed := match("E1001", "template.yaml", 1, "Suppressed")
+	suppressed.Suppression = &lint.MatchSuppression{Justification: "legacy"}
+
+	current := []lint.Match{
+		// Moved down two lines, still known
+		match("W2001", "template.yaml", 5, "Parameter Env not used", "Parameters", "Env"),
+		// Reported three times now, baselined twice
+		match("E3012", "template.yaml", 12, "Wrong type", "Resources", "SG"),
+		match("E3012", "template.yaml", 13, "Wrong type", "Resources", "SG"),
+		match("E3012", "template.yaml", 14, "Wrong type", "Resources", "SG"),
+		// New finding
+		match("E3002", "template.yaml", 30, "Unknown property", "Resources", "Queue"),
+		suppressed,
+	}
+
+	result := b.Filter(current)
+	if result.Existing != 3 {
+		t.Errorf("Expected 3 existing findings, got %d", result.Existing)
+	}
+	if result.Fixed != 1 {
+		t.Errorf("Expected 1 fixed finding (W3005), got %d", result.Fixed)
+	}
+
+	var ids []string
+	for _, m := range result.Matches {
+		ids = append(ids, m.Rule.ID)
+	}
+	want := []string{"E3012", "E3002", "E1001"}
+	if len(ids) != len(want) {
+		t.Fatalf("Expected matches %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("Expected matches %v, got %v", want, ids)
+			break
+		}
+	}
+}
+
+func TestNew_SkipsSuppressed(t *testing.T) {
+	m := match("E1001", "template.yaml", 1, "Suppressed")
+	m.Suppression = &lint.MatchSuppression{}
+	if b := New([]lint.Match{m}); len(b.Findings) != 0 {
+		t.Errorf("Expected suppressed matches to be left out, got %d findings", len(b.Findings))
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	matches := []lint.Match{
+		match("W2001", "b.yaml", 3, "Parameter Env not used", "Parameters", "Env"),
+		match("E3012", "a.yaml", 10, "Wrong type", "Resources", "SG", "Properties", "Tags", 0),
+	}
+
+	if err := New(matches).Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(b.Findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d", len(b.Findings))
+	}
+	if b.Findings[0].File != "a.yaml" {
+		t.Errorf("Expected findings sorted by file, got %s first", b.Findings[0].File)
+	}
+	if b.Findings[0].Path != "Resources/SG/Properties/Tags/0" {
+		t.Errorf("Unexpected path %q", b.Findings[0].Path)
+	}
+	if result := b.Filter(matches); len(result.Matches) != 0 || result.Fixed != 0 {
+		t.Errorf("Expected all matches to be baselined, got %d new and %d fixed", len(result.Matches), result.Fixed)
+	}
+}
+
+func TestLoad_MatchesJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	matches := []lint.Match{match("E3012", "template.yaml", 10, "Wrong type", "Resources", "SG")}
+	data, err := json.Marshal(matches)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if result := b.Filter(matches); len(result.Matches) != 0 {
+		t.Errorf("Expected the JSON output to work as a baseline, got %d new matches", len(result.Matches))
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+
+	path := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "findings": []}`), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected error for unsupported version")
+	}
+}
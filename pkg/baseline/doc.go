@@ -0,0 +1,32 @@
+// Package baseline records existing lint findings so that only new findings
+// fail a build.
+//
+// A baseline is a JSON file of findings, each identified by a fingerprint of
+// its rule ID, file, logical path and normalized message. Line numbers are
+// not part of the fingerprint, so findings survive unrelated edits that move
+// them around.
+//
+// # Writing a Baseline
+//
+//	matches, _ := linter.LintFile("template.yaml")
+//	if err := baseline.New(matches).Save(".cfnlint-baseline.json"); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// # Reporting New Findings
+//
+//	b, err := baseline.Load(".cfnlint-baseline.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result := b.Filter(matches)
+//	fmt.Printf("%d new, %d existing, %d fixed\n",
+//	    len(result.Matches), result.Existing, result.Fixed)
+//
+// The filtered matches have the usual lint.Match shape and can be passed to
+// any formatter in the output package. Load also accepts the JSON array
+// written by --format json.
+//
+// File names are recorded as given, so write and check a baseline from the
+// same working directory.
+package baseline
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dirName is the name of the cache directory below the user cache root.
+const dirName = "cfn-lint-go"
+
+// Cache is a directory of cached lint results, addressed by key.
+type Cache struct {
+	dir string
+}
+
+// DefaultDir returns the default cache directory: $XDG_CACHE_HOME/cfn-lint-go
+// when XDG_CACHE_HOME is set, otherwise cfn-lint-go in the platform's user
+// cache directory.
+func DefaultDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, dirName), nil
+	}
+	root, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("finding cache directory: %w", err)
+	}
+	return filepath.Join(root, dirName), nil
+}
+
+// Open returns a cache stored in dir, creating the directory if needed.
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get returns the data stored under key. A missing or unreadable entry is
+// a miss.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	path, ok := c.path(key)
+	if !ok {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores data under key. The entry is written to a temporary file and
+// renamed into place, so concurrent runs never see partial entries.
+func (c *Cache) Put(key string, data []byte) error {
+	path, ok := c.path(key)
+	if !ok {
+		return fmt.Errorf("invalid cache key %q", key)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every cache entry.
+func (c *Cache) Clear() error {
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("clearing cache: %w", err)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(c.dir, entry.Name())); err != nil {
+			return fmt.Errorf("clearing cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// path returns the file holding key. Entries are spread over
+// subdirectories named after the first two characters of the key.
+func (c *Cache) path(key string) (string, bool) {
+	if len(key) < 3 || strings.ContainsAny(key, `/\.`) {
+		return "", false
+	}
+	return filepath.Join(c.dir, key[:2], key[2:]+".json"), true
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCache_PutGet(t *testing.T) {
+	c, err := Open(filepath.Join(t.TempDir(), "cache"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if _, ok := c.Get("abcdef"); ok {
+		t.Fatal("Expected a miss on an empty cache")
+	}
+	if err := c.Put("abcdef", []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	data, ok := c.Get("abcdef")
+	if !ok || string(data) != `{"ok":true}` {
+		t.Errorf("Expected stored data, got %q (hit %v)", data, ok)
+	}
+	if _, err := os.Stat(filepath.Join(c.Dir(), "ab", "cdef.json")); err != nil {
+		t.Errorf("Expected sharded entry file: %v", err)
+	}
+}
+
+func TestCache_InvalidKeys(t *testing.T) {
+	c, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	for _, key := range []string{"", "ab", "../escape", `a\b\c`} {
+		if err := c.Put(key, []byte("x")); err == nil {
+			t.Errorf("Expected Put(%q) to fail", key)
+		}
+		if _, ok := c.Get(key); ok {
+			t.Errorf("Expected Get(%q) to miss", key)
+		}
+	}
+}
+
+func TestCache_Clear(t *testing.T) {
+	c, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for _, key := range []string{"aa11", "bb22"} {
+		if err := c.Put(key, []byte("x")); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	for _, key := range []string{"aa11", "bb22"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("Expected %s to be cleared", key)
+		}
+	}
+	if _, err := os.Stat(c.Dir()); err != nil {
+		t.Errorf("Expected cache directory to remain: %v", err)
+	}
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg")
+	dir, err := DefaultDir()
+	if err != nil {
+		t.Fatalf("DefaultDir failed: %v", err)
+	}
+	if want := filepath.Join("/tmp/xdg", "cfn-lint-go"); dir != want {
+		t.Errorf("Expected %s, got %s", want, dir)
+	}
+}
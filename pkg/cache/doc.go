@@ -0,0 +1,23 @@
+// Package cache stores lint results on disk between runs.
+//
+// Entries are opaque byte slices addressed by a key; the lint package
+// derives the key from the template content and everything else that can
+// change the result (see lint.Options.Cache). By default the cache lives in
+// $XDG_CACHE_HOME/cfn-lint-go, falling back to the platform's user cache
+// directory.
+//
+// # Usage
+//
+//	dir, _ := cache.DefaultDir()
+//	c, err := cache.Open(dir)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	linter := lint.New(lint.Options{Cache: c})
+//
+// Remove every entry with Clear:
+//
+//	if err := c.Clear(); err != nil {
+//	    log.Fatal(err)
+//	}
+package cache
@@ -0,0 +1,328 @@
+package condlogic
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/eval"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// maxWorlds bounds the number of assignments Satisfiable tries. Questions
+// that need more are not decided, and the assumptions count as
+// satisfiable.
+const maxWorlds = 1 << 16
+
+// inputPseudoParameters are the pseudo parameters a condition can compare
+// against; each takes a single value for a deployment.
+var inputPseudoParameters = map[string]bool{
+	"AWS::Region":    true,
+	"AWS::AccountId": true,
+	"AWS::StackName": true,
+	"AWS::Partition": true,
+	"AWS::URLSuffix": true,
+}
+
+// Assumption is a condition taken to have a value, such as the condition
+// of a resource or the branch of an Fn::If being taken. An empty Condition
+// stands for no condition, which is always true.
+type Assumption struct {
+	Condition string
+	Value     bool
+}
+
+// Solver answers questions about the conditions of a template.
+type Solver struct {
+	tmpl      *template.Template
+	evaluator *eval.Evaluator
+
+	// formulas caches the formula of each condition; building holds those
+	// being built, to cut cycles
+	formulas map[string]formula
+	building map[string]bool
+}
+
+// New returns a solver for a template's conditions.
+func New(tmpl *template.Template) *Solver {
+	return &Solver{
+		tmpl:      tmpl,
+		evaluator: eval.New(tmpl, eval.Options{}),
+		formulas:  make(map[string]formula),
+		building:  make(map[string]bool),
+	}
+}
+
+// Satisfiable reports whether some deployment, with parameter values
+// allowed by the template, makes all the assumptions hold at once. When
+// that cannot be decided, because a condition is invalid or too many
+// combinations would have to be tried, Satisfiable returns true, so the
+// answers derived from it are only given when they are certain.
+func (s *Solver) Satisfiable(assumptions ...Assumption) bool {
+	parts := make(conjunction, 0, len(assumptions))
+	for _, a := range assumptions {
+		var f formula = constant(true)
+		if a.Condition != "" {
+			f = s.condition(a.Condition)
+		}
+		if !a.Value {
+			f = negation{f}
+		}
+		parts = append(parts, f)
+	}
+	return s.satisfiable(parts)
+}
+
+// AlwaysTrue reports whether a condition is true for every deployment.
+func (s *Solver) AlwaysTrue(name string) bool {
+	return !s.Satisfiable(Assumption{Condition: name, Value: false})
+}
+
+// NeverTrue reports whether a condition is false for every deployment.
+func (s *Solver) NeverTrue(name string) bool {
+	return !s.Satisfiable(Assumption{Condition: name, Value: true})
+}
+
+// Implies reports whether condition b is true whenever condition a is. An
+// empty a is always true, so Implies("", b) reports whether b always is.
+func (s *Solver) Implies(a, b string) bool {
+	return !s.Satisfiable(Assumption{Condition: a, Value: true}, Assumption{Condition: b, Value: false})
+}
+
+// Exclusive reports whether conditions a and b are never true together.
+func (s *Solver) Exclusive(a, b string) bool {
+	return !s.Satisfiable(Assumption{Condition: a, Value: true}, Assumption{Condition: b, Value: true})
+}
+
+// condition returns the formula of a named condition. Undefined conditions
+// and conditions that depend on themselves are opaque.
+func (s *Solver) condition(name string) formula {
+	if f, ok := s.formulas[name]; ok {
+		return f
+	}
+	cond, ok := s.tmpl.Conditions[name]
+	if !ok || s.building[name] {
+		return atom("Condition:" + name)
+	}
+	s.building[name] = true
+	f := s.expression(cond.Expression)
+	delete(s.building, name)
+	s.formulas[name] = f
+	return f
+}
+
+// expression converts a condition function to a formula. Anything it does
+// not understand becomes an opaque atom that may be true or false.
+func (s *Solver) expression(v any) formula {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 1 {
+		return opaque(v)
+	}
+	for key, arg := range m {
+		switch key {
+		case "Condition":
+			if name, ok := arg.(string); ok {
+				return s.condition(name)
+			}
+		case "Fn::And", "Fn::Or":
+			args, ok := arg.([]any)
+			if !ok {
+				break
+			}
+			parts := make([]formula, len(args))
+			for i, item := range args {
+				parts[i] = s.expression(item)
+			}
+			if key == "Fn::And" {
+				return conjunction(parts)
+			}
+			return disjunction(parts)
+		case "Fn::Not":
+			if args, ok := arg.([]any); ok && len(args) == 1 {
+				return negation{s.expression(args[0])}
+			}
+		case "Fn::Equals":
+			if args, ok := arg.([]any); ok && len(args) == 2 {
+				return s.equals(v, args[0], args[1])
+			}
+		}
+	}
+	return opaque(v)
+}
+
+// equals converts Fn::Equals [a, b] to a formula.
+func (s *Solver) equals(expr, a, b any) formula {
+	aInput, aLiteral, aKind := s.operand(a)
+	bInput, bLiteral, bKind := s.operand(b)
+	switch {
+	case aKind == literalOperand && bKind == literalOperand:
+		return constant(aLiteral == bLiteral)
+	case aKind == inputOperand && bKind == literalOperand:
+		return equality{input: aInput, value: bLiteral}
+	case aKind == literalOperand && bKind == inputOperand:
+		return equality{input: bInput, value: aLiteral}
+	case aKind == inputOperand && bKind == inputOperand && aInput == bInput:
+		return constant(true)
+	}
+	return opaque(expr)
+}
+
+type operandKind int
+
+const (
+	opaqueOperand operandKind = iota
+	literalOperand
+	inputOperand
+)
+
+// operand classifies an Fn::Equals operand as a parameter or pseudo
+// parameter holding a single value, a value known before deployment, or
+// something else.
+func (s *Solver) operand(v any) (input, literal string, kind operandKind) {
+	if m, ok := v.(map[string]any); ok && len(m) == 1 {
+		if name, ok := m["Ref"].(string); ok {
+			if param, ok := s.tmpl.Parameters[name]; ok && !isListParameter(param) {
+				return name, "", inputOperand
+			}
+			if inputPseudoParameters[name] {
+				return name, "", inputOperand
+			}
+		}
+	}
+	value, err := s.evaluator.Evaluate(v, nil)
+	if err != nil {
+		return "", "", opaqueOperand
+	}
+	if literal, ok := literalString(value); ok {
+		return "", literal, literalOperand
+	}
+	return "", "", opaqueOperand
+}
+
+// satisfiable enumerates the assignments of the inputs and atoms of f and
+// reports whether any makes it true.
+func (s *Solver) satisfiable(f formula) bool {
+	literals := make(map[string]map[string]bool)
+	atoms := make(map[string]bool)
+	collect(f, literals, atoms)
+
+	inputs := make([]string, 0, len(literals))
+	for input := range literals {
+		inputs = append(inputs, input)
+	}
+	sort.Strings(inputs)
+	domains := make([][]string, len(inputs))
+	worlds := 1
+	for i, input := range inputs {
+		domains[i] = s.domain(input, literals[input])
+		worlds *= len(domains[i])
+		if worlds > maxWorlds {
+			return true
+		}
+	}
+	atomNames := make([]string, 0, len(atoms))
+	for name := range atoms {
+		atomNames = append(atomNames, name)
+	}
+	sort.Strings(atomNames)
+	if len(atomNames) > 16 || worlds<<len(atomNames) > maxWorlds {
+		return true
+	}
+	worlds <<= len(atomNames)
+
+	w := world{values: make(map[string]string, len(inputs)), atoms: make(map[atom]bool, len(atomNames))}
+	for n := 0; n < worlds; n++ {
+		rest := n
+		for i, input := range inputs {
+			w.values[input] = domains[i][rest%len(domains[i])]
+			rest /= len(domains[i])
+		}
+		for _, name := range atomNames {
+			w.atoms[atom(name)] = rest%2 == 1
+			rest /= 2
+		}
+		if f.holds(&w) {
+			return true
+		}
+	}
+	return false
+}
+
+// otherValue stands for every value of an input that no condition
+// compares it with.
+const otherValue = "\x00other"
+
+// domain returns the values an input is tried with: the values it is
+// compared with that it may take, and otherValue when it may take others.
+// A parameter with AllowedValues may only take those.
+func (s *Solver) domain(input string, compared map[string]bool) []string {
+	var allowed []any
+	if param, ok := s.tmpl.Parameters[input]; ok {
+		allowed = param.AllowedValues
+	}
+	var domain []string
+	if len(allowed) == 0 {
+		for value := range compared {
+			domain = append(domain, value)
+		}
+		sort.Strings(domain)
+		return append(domain, otherValue)
+	}
+
+	seen := make(map[string]bool)
+	hasOther := false
+	for _, value := range allowed {
+		str, ok := literalString(value)
+		switch {
+		case !ok || !compared[str]:
+			hasOther = true
+		case !seen[str]:
+			seen[str] = true
+			domain = append(domain, str)
+		}
+	}
+	sort.Strings(domain)
+	if hasOther {
+		domain = append(domain, otherValue)
+	}
+	return domain
+}
+
+// isListParameter reports whether a parameter's value is a list, which
+// Fn::Equals does not compare as a single value.
+func isListParameter(param *template.Parameter) bool {
+	return param.Type == "CommaDelimitedList" || strings.HasPrefix(param.Type, "List<") ||
+		strings.HasPrefix(param.Type, "AWS::SSM::Parameter::Value<List<") ||
+		strings.HasPrefix(param.Type, "AWS::SSM::Parameter::Value<CommaDelimitedList")
+}
+
+// literalString converts a string, number or boolean to the string
+// Fn::Equals compares.
+func literalString(v any) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return val, true
+	case int:
+		return strconv.Itoa(val), true
+	case int64:
+		return strconv.FormatInt(val, 10), true
+	case uint64:
+		return strconv.FormatUint(val, 10), true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(val), true
+	}
+	return "", false
+}
+
+// opaque returns the atom standing for an expression. Equal expressions
+// share an atom, so they take the same value in every world.
+func opaque(v any) formula {
+	key, err := json.Marshal(v)
+	if err != nil {
+		return atom("?")
+	}
+	return atom(key)
+}
@@ -0,0 +1,201 @@
+package condlogic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+const testTemplate = `
+Parameters:
+  Env:
+    Type: String
+    AllowedValues: [dev, staging, prod]
+  Name:
+    Type: String
+  Other:
+    Type: String
+  Zones:
+    Type: CommaDelimitedList
+Mappings:
+  Settings:
+    Global:
+      Mode: strict
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsDev: !Equals [dev, !Ref Env]
+  IsStaging: !Equals [!Ref Env, staging]
+  NotProd: !Not [!Condition IsProd]
+  IsProdInEU: !And [!Condition IsProd, !Equals [!Ref "AWS::Region", eu-west-1]]
+  IsProdInUS: !And [!Condition IsProd, !Equals [!Ref "AWS::Region", us-east-1]]
+  ProdInDev: !And [!Condition IsProd, !Condition IsDev]
+  AnyEnv: !Or [!Condition IsProd, !Condition IsDev, !Condition IsStaging]
+  IsTest: !Equals [!Ref Env, test]
+  HasName: !Not [!Equals [!Ref Name, ""]]
+  NoName: !Equals [!Ref Name, ""]
+  SameNames: !Equals [!Ref Name, !Ref Other]
+  DifferentNames: !Not [!Condition SameNames]
+  Strict: !Equals [!FindInMap [Settings, Global, Mode], strict]
+  OneZone: !Equals [!Join [",", !Ref Zones], a]
+  Loop: !Not [!Condition Loop]
+`
+
+func newSolver(t *testing.T) *Solver {
+	t.Helper()
+	tmpl, err := template.Parse([]byte(testTemplate))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	return New(tmpl)
+}
+
+func TestSolver_Constant(t *testing.T) {
+	s := newSolver(t)
+
+	tests := []struct {
+		name       string
+		alwaysTrue bool
+		neverTrue  bool
+	}{
+		{"IsProd", false, false},
+		{"NotProd", false, false},
+		{"ProdInDev", false, true},
+		{"AnyEnv", true, false},
+		{"IsTest", false, true},
+		{"HasName", false, false},
+		{"SameNames", false, false},
+		{"Strict", true, false},
+		{"OneZone", false, false},
+		{"Loop", false, false},
+		{"Undefined", false, false},
+	}
+	for _, tt := range tests {
+		if got := s.AlwaysTrue(tt.name); got != tt.alwaysTrue {
+			t.Errorf("AlwaysTrue(%s) = %v, want %v", tt.name, got, tt.alwaysTrue)
+		}
+		if got := s.NeverTrue(tt.name); got != tt.neverTrue {
+			t.Errorf("NeverTrue(%s) = %v, want %v", tt.name, got, tt.neverTrue)
+		}
+	}
+}
+
+func TestSolver_Relations(t *testing.T) {
+	s := newSolver(t)
+
+	implies := []struct {
+		a, b string
+		want bool
+	}{
+		{"IsProdInEU", "IsProd", true},
+		{"IsProd", "IsProdInEU", false},
+		{"IsDev", "NotProd", true},
+		{"IsProd", "IsProd", true},
+		{"", "AnyEnv", true},
+		{"", "IsProd", false},
+		{"SameNames", "SameNames", true},
+		{"ProdInDev", "IsStaging", true}, // never true, so implies anything
+	}
+	for _, tt := range implies {
+		if got := s.Implies(tt.a, tt.b); got != tt.want {
+			t.Errorf("Implies(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	exclusive := []struct {
+		a, b string
+		want bool
+	}{
+		{"IsProd", "IsDev", true},
+		{"IsProdInEU", "IsProdInUS", true},
+		{"HasName", "NoName", true},
+		{"SameNames", "DifferentNames", true},
+		{"IsProd", "IsProdInEU", false},
+		{"IsProd", "HasName", false},
+	}
+	for _, tt := range exclusive {
+		if got := s.Exclusive(tt.a, tt.b); got != tt.want {
+			t.Errorf("Exclusive(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSolver_Satisfiable(t *testing.T) {
+	s := newSolver(t)
+
+	if !s.Satisfiable() {
+		t.Error("Satisfiable() with no assumptions = false, want true")
+	}
+	if s.Satisfiable(Assumption{Condition: "NotProd", Value: true}, Assumption{Condition: "IsDev", Value: false}, Assumption{Condition: "IsStaging", Value: false}) {
+		t.Error("Expected not prod, not dev and not staging to be unsatisfiable with Env's AllowedValues")
+	}
+	if !s.Satisfiable(Assumption{Condition: "IsProd", Value: true}, Assumption{Condition: "", Value: true}) {
+		t.Error("Expected an empty condition to be always true")
+	}
+	if s.Satisfiable(Assumption{Condition: "", Value: false}) {
+		t.Error("Expected an empty condition that is false to be unsatisfiable")
+	}
+}
+
+func TestSolver_Scenarios(t *testing.T) {
+	s := newSolver(t)
+
+	value := map[string]any{
+		"A": map[string]any{"Fn::If": []any{"IsProd", map[string]any{"Fn::If": []any{"HasName", "x", "y"}}, "z"}},
+		"B": map[string]any{"Fn::If": []any{"IsDev", "dev", map[string]any{"Ref": "AWS::NoValue"}}},
+	}
+	var got []string
+	for _, sc := range s.Scenarios(value) {
+		got = append(got, sc.String())
+	}
+	// IsDev is false whenever IsProd is true, and HasName only matters in
+	// the IsProd branch
+	want := []string{
+		"HasName=true, IsDev=false, IsProd=true",
+		"HasName=false, IsDev=false, IsProd=true",
+		"IsDev=true, IsProd=false",
+		"IsDev=false, IsProd=false",
+	}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("Scenarios() = %q, want %q", got, want)
+	}
+
+	got = nil
+	for _, sc := range s.Scenarios(value, Assumption{Condition: "IsDev", Value: true}) {
+		got = append(got, sc.String())
+	}
+	if want := []string{"IsDev=true, IsProd=false"}; strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("Scenarios() with IsDev = %q, want %q", got, want)
+	}
+
+	if scenarios := s.Scenarios("plain"); len(scenarios) != 1 || len(scenarios[0]) != 0 {
+		t.Errorf("Expected a single empty scenario without Fn::If, got %v", scenarios)
+	}
+}
+
+func TestScenario_Resolve(t *testing.T) {
+	sc := Scenario{{Condition: "IsProd", Value: false}}
+	value := map[string]any{
+		"Size":    map[string]any{"Fn::If": []any{"IsProd", 100, map[string]any{"Ref": "AWS::NoValue"}}},
+		"Name":    map[string]any{"Fn::If": []any{"IsProd", "prod", "dev"}},
+		"Unknown": map[string]any{"Fn::If": []any{"HasName", "a", "b"}},
+		"List":    []any{"a", map[string]any{"Ref": "AWS::NoValue"}, map[string]any{"Fn::If": []any{"IsProd", "b", "c"}}},
+	}
+
+	got, _ := sc.Resolve(value).(map[string]any)
+	if _, ok := got["Size"]; ok {
+		t.Errorf("Expected Size to be removed, got %v", got["Size"])
+	}
+	if got["Name"] != "dev" {
+		t.Errorf("Name = %v, want dev", got["Name"])
+	}
+	if _, ok := got["Unknown"].(map[string]any)["Fn::If"]; !ok {
+		t.Errorf("Expected an Fn::If outside the scenario to be kept, got %v", got["Unknown"])
+	}
+	if list, _ := got["List"].([]any); len(list) != 2 || list[1] != "c" {
+		t.Errorf("List = %v, want [a c]", got["List"])
+	}
+	if sc.Resolve(map[string]any{"Ref": "AWS::NoValue"}) != nil {
+		t.Error("Expected AWS::NoValue to resolve to nil")
+	}
+}
@@ -0,0 +1,39 @@
+// Package condlogic reasons about the Conditions of a CloudFormation
+// template as boolean formulas.
+//
+// Each condition becomes a formula over equalities between parameters or
+// pseudo parameters (AWS::Region, AWS::AccountId, ...) and values. Values
+// known before deployment, such as literals and static Fn::FindInMap
+// lookups, are folded, and a parameter with AllowedValues only takes those
+// values. Parts the solver cannot look into, such as Fn::Equals of two
+// parameters, are opaque: they may be true or false.
+//
+//	s := condlogic.New(tmpl)
+//	s.NeverTrue("IsProdInDev")          // no deployment makes it true
+//	s.AlwaysTrue("HasRegion")
+//	s.Implies("IsProdInEU", "IsProd")  // IsProd holds whenever IsProdInEU does
+//	s.Exclusive("IsProd", "IsDev")
+//
+// Satisfiable answers the general question of whether some deployment
+// makes a set of assumptions hold at once, for example a resource's
+// condition together with the Fn::If branches around a value:
+//
+//	s.Satisfiable(
+//	    condlogic.Assumption{Condition: "IsProd", Value: true},
+//	    condlogic.Assumption{Condition: "UseCache", Value: false},
+//	)
+//
+// Scenarios lists the combinations of Fn::If conditions a value can be
+// deployed with, and Scenario.Resolve returns the value as deployed in one,
+// so a rule can check each branch of a property:
+//
+//	for _, sc := range s.Scenarios(res.Properties) {
+//	    props := sc.Resolve(res.Properties) // Fn::If resolved, AWS::NoValue removed
+//	    ...
+//	}
+//
+// The solver tries every combination of the values that matter, up to a
+// limit. Questions it cannot decide, because of that limit or an invalid
+// condition, count as satisfiable, so AlwaysTrue, NeverTrue, Implies and
+// Exclusive only return true when the answer is certain.
+package condlogic
@@ -0,0 +1,82 @@
+package condlogic
+
+// formula is a boolean formula over input equalities and opaque atoms.
+type formula interface {
+	holds(w *world) bool
+}
+
+// world assigns a value to every input and atom of a formula.
+type world struct {
+	values map[string]string
+	atoms  map[atom]bool
+}
+
+type constant bool
+
+func (c constant) holds(*world) bool { return bool(c) }
+
+type conjunction []formula
+
+func (c conjunction) holds(w *world) bool {
+	for _, f := range c {
+		if !f.holds(w) {
+			return false
+		}
+	}
+	return true
+}
+
+type disjunction []formula
+
+func (d disjunction) holds(w *world) bool {
+	for _, f := range d {
+		if f.holds(w) {
+			return true
+		}
+	}
+	return false
+}
+
+type negation struct {
+	f formula
+}
+
+func (n negation) holds(w *world) bool { return !n.f.holds(w) }
+
+// equality holds when a parameter or pseudo parameter has a value.
+type equality struct {
+	input string
+	value string
+}
+
+func (e equality) holds(w *world) bool { return w.values[e.input] == e.value }
+
+// atom is a condition the solver cannot look into, such as Fn::Equals of
+// two parameters. It may be true or false independently of the rest.
+type atom string
+
+func (a atom) holds(w *world) bool { return w.atoms[a] }
+
+// collect gathers the values each input is compared with, and the atoms,
+// of a formula.
+func collect(f formula, literals map[string]map[string]bool, atoms map[string]bool) {
+	switch val := f.(type) {
+	case conjunction:
+		for _, part := range val {
+			collect(part, literals, atoms)
+		}
+	case disjunction:
+		for _, part := range val {
+			collect(part, literals, atoms)
+		}
+	case negation:
+		collect(val.f, literals, atoms)
+	case equality:
+		if literals[val.input] == nil {
+			literals[val.input] = make(map[string]bool)
+		}
+		literals[val.input][val.value] = true
+	case atom:
+		atoms[string(val)] = true
+	}
+}
@@ -0,0 +1,183 @@
+package condlogic
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxScenarios bounds the number of scenarios Scenarios returns. A value
+// with more Fn::If combinations than that gets the single empty scenario.
+const maxScenarios = 64
+
+// Scenario assigns a value to the conditions of the Fn::If functions in a
+// value. Assumptions are sorted by condition name.
+type Scenario []Assumption
+
+// String returns the scenario as "IsProd=false, UseCache=true".
+func (sc Scenario) String() string {
+	parts := make([]string, len(sc))
+	for i, a := range sc {
+		parts[i] = a.Condition + "=" + strconv.FormatBool(a.Value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// value returns the value a scenario assigns to a condition.
+func (sc Scenario) value(name string) (value, ok bool) {
+	for _, a := range sc {
+		if a.Condition == name {
+			return a.Value, true
+		}
+	}
+	return false, false
+}
+
+// with returns a copy of the scenario with a condition set.
+func (sc Scenario) with(name string, value bool) Scenario {
+	next := make(Scenario, 0, len(sc)+1)
+	next = append(next, sc...)
+	next = append(next, Assumption{Condition: name, Value: value})
+	sort.Slice(next, func(i, j int) bool { return next[i].Condition < next[j].Condition })
+	return next
+}
+
+// Scenarios returns the combinations of Fn::If conditions that a value can
+// be deployed with, given assumptions such as the resource's condition.
+// Only the conditions of the Fn::If functions reached in each scenario are
+// set, so a condition nested in a branch that is not taken is left out, and
+// combinations that no deployment allows are dropped.
+//
+// A value without Fn::If, or with too many combinations, gets a single
+// empty scenario, which leaves every Fn::If in place.
+func (s *Solver) Scenarios(v any, assumptions ...Assumption) []Scenario {
+	var scenarios []Scenario
+	pending := []Scenario{nil}
+	for len(pending) > 0 {
+		sc := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		name, ok := sc.nextCondition(v)
+		if !ok {
+			scenarios = append(scenarios, sc)
+			if len(scenarios) > maxScenarios {
+				return []Scenario{nil}
+			}
+			continue
+		}
+		// Push false first so the true branch is explored, and
+		// listed, first.
+		for _, value := range []bool{false, true} {
+			next := sc.with(name, value)
+			if s.Satisfiable(append(append([]Assumption{}, assumptions...), next...)...) {
+				pending = append(pending, next)
+			}
+		}
+	}
+	if len(scenarios) == 0 {
+		return []Scenario{nil}
+	}
+	return scenarios
+}
+
+// nextCondition returns the condition of the first Fn::If reached in v
+// that the scenario does not set.
+func (sc Scenario) nextCondition(v any) (string, bool) {
+	switch val := v.(type) {
+	case map[string]any:
+		if name, branches, ok := ifFunction(val); ok {
+			value, set := sc.value(name)
+			if !set {
+				return name, true
+			}
+			if value {
+				return sc.nextCondition(branches[0])
+			}
+			return sc.nextCondition(branches[1])
+		}
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if name, ok := sc.nextCondition(val[key]); ok {
+				return name, true
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if name, ok := sc.nextCondition(item); ok {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Resolve returns a copy of v as deployed in the scenario: each Fn::If
+// whose condition the scenario sets is replaced by the branch taken, and
+// Ref AWS::NoValue removes the key or list item holding it. An Fn::If whose
+// condition is not set is kept as is. Resolve returns nil when v itself is
+// removed.
+func (sc Scenario) Resolve(v any) any {
+	resolved, _ := sc.resolve(v)
+	return resolved
+}
+
+// resolve resolves v, reporting false when it is removed.
+func (sc Scenario) resolve(v any) (any, bool) {
+	switch val := v.(type) {
+	case map[string]any:
+		if isNoValue(val) {
+			return nil, false
+		}
+		if name, branches, ok := ifFunction(val); ok {
+			if value, set := sc.value(name); set {
+				if value {
+					return sc.resolve(branches[0])
+				}
+				return sc.resolve(branches[1])
+			}
+			return val, true
+		}
+		result := make(map[string]any, len(val))
+		for key, item := range val {
+			if resolved, ok := sc.resolve(item); ok {
+				result[key] = resolved
+			}
+		}
+		return result, true
+	case []any:
+		result := make([]any, 0, len(val))
+		for _, item := range val {
+			if resolved, ok := sc.resolve(item); ok {
+				result = append(result, resolved)
+			}
+		}
+		return result, true
+	}
+	return v, true
+}
+
+// ifFunction returns the condition and branches of an Fn::If.
+func ifFunction(m map[string]any) (string, []any, bool) {
+	if len(m) != 1 {
+		return "", nil, false
+	}
+	args, ok := m["Fn::If"].([]any)
+	if !ok || len(args) != 3 {
+		return "", nil, false
+	}
+	name, ok := args[0].(string)
+	if !ok {
+		return "", nil, false
+	}
+	return name, args[1:], true
+}
+
+// isNoValue reports whether a value is Ref AWS::NoValue.
+func isNoValue(m map[string]any) bool {
+	ref, ok := m["Ref"].(string)
+	return ok && len(m) == 1 && ref == "AWS::NoValue"
+}
@@ -38,15 +38,22 @@ type SAMConfig struct {
 
 // Config represents the cfn-lint configuration.
 type Config struct {
-	// Templates is a list of template files or glob patterns to lint.
+	// Templates is a list of template files, directories or glob patterns
+	// to lint. In a config file, relative entries are resolved against the
+	// config file's directory.
 	Templates []string `yaml:"templates" json:"templates"`
 
-	// IgnoreTemplates is a list of templates or patterns to ignore.
+	// IgnoreTemplates is a list of templates, directories or patterns to
+	// ignore, resolved like Templates.
 	IgnoreTemplates []string `yaml:"ignore_templates" json:"ignore_templates"`
 
 	// Regions is a list of AWS regions to validate against.
 	Regions []string `yaml:"regions" json:"regions"`
 
+	// ParameterFiles is a list of stack parameter files to validate
+	// against the templates, resolved like Templates.
+	ParameterFiles []string `yaml:"parameter_files" json:"parameter_files"`
+
 	// IgnoreChecks is a list of rule IDs to ignore.
 	IgnoreChecks []string `yaml:"ignore_checks" json:"ignore_checks"`
 
@@ -56,6 +63,18 @@ type Config struct {
 	// IncludeExperimental enables experimental rules.
 	IncludeExperimental bool `yaml:"include_experimental" json:"include_experimental"`
 
+	// MandatoryChecks is a list of rule IDs that always run and cannot be
+	// ignored or suppressed.
+	MandatoryChecks []string `yaml:"mandatory_checks" json:"mandatory_checks"`
+
+	// SeverityOverrides maps rule IDs to the level their matches are
+	// reported at: error, warning or informational.
+	SeverityOverrides map[string]string `yaml:"severity_overrides" json:"severity_overrides"`
+
+	// NonZeroExitCode is the lowest match level that makes the run fail:
+	// informational (default), warning, error or none.
+	NonZeroExitCode string `yaml:"non_zero_exit_code" json:"non_zero_exit_code"`
+
 	// ConfigureRules contains rule-specific configuration.
 	ConfigureRules map[string]map[string]interface{} `yaml:"configure_rules" json:"configure_rules"`
 
@@ -110,6 +129,75 @@ func Load(path string) (*Config, error) {
 		}
 	}
 
+	cfg.resolveTemplatePaths(filepath.Dir(path))
+
+	return &cfg, nil
+}
+
+// resolveTemplatePaths makes relative template, ignore and parameter file
+// paths relative to dir, the config file's directory, rather than the working directory.
+func (c *Config) resolveTemplatePaths(dir string) {
+	for i, p := range c.Templates {
+		c.Templates[i] = resolvePath(dir, p)
+	}
+	for i, p := range c.IgnoreTemplates {
+		c.IgnoreTemplates[i] = resolvePath(dir, p)
+	}
+	for i, p := range c.ParameterFiles {
+		c.ParameterFiles[i] = resolvePath(dir, p)
+	}
+}
+
+// resolvePath joins a relative path to dir. The result is kept relative to
+// the working directory when it is inside it, so reported file names stay
+// short.
+func resolvePath(dir, p string) string {
+	if filepath.IsAbs(p) || dir == "." {
+		return p
+	}
+	joined := filepath.Join(dir, p)
+	if !filepath.IsAbs(joined) {
+		return joined
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return joined
+	}
+	rel, err := filepath.Rel(wd, joined)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return joined
+	}
+	return rel
+}
+
+// FromMetadata extracts the cfn-lint configuration embedded in a template or
+// resource Metadata section:
+//
+//	Metadata:
+//	  cfn-lint:
+//	    config:
+//	      ignore_checks: [W3005]
+//
+// It returns nil when the metadata contains no cfn-lint config block.
+func FromMetadata(metadata map[string]any) (*Config, error) {
+	cfnLint, ok := metadata["cfn-lint"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	block, ok := cfnLint["config"]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := yaml.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("encoding metadata config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing metadata config: %w", err)
+	}
 	return &cfg, nil
 }
 
@@ -197,6 +285,13 @@ func Merge(base, override *Config) *Config {
 		result.Regions = base.Regions
 	}
 
+	// ParameterFiles: override takes precedence if set
+	if len(override.ParameterFiles) > 0 {
+		result.ParameterFiles = override.ParameterFiles
+	} else {
+		result.ParameterFiles = base.ParameterFiles
+	}
+
 	// IgnoreChecks: append both
 	result.IgnoreChecks = append(result.IgnoreChecks, base.IgnoreChecks...)
 	result.IgnoreChecks = append(result.IgnoreChecks, override.IgnoreChecks...)
@@ -208,6 +303,28 @@ func Merge(base, override *Config) *Config {
 	// IncludeExperimental: override takes precedence
 	result.IncludeExperimental = base.IncludeExperimental || override.IncludeExperimental
 
+	// MandatoryChecks: append both
+	result.MandatoryChecks = append(result.MandatoryChecks, base.MandatoryChecks...)
+	result.MandatoryChecks = append(result.MandatoryChecks, override.MandatoryChecks...)
+
+	// SeverityOverrides: merge maps
+	if len(base.SeverityOverrides) > 0 || len(override.SeverityOverrides) > 0 {
+		result.SeverityOverrides = make(map[string]string)
+		for k, v := range base.SeverityOverrides {
+			result.SeverityOverrides[k] = v
+		}
+		for k, v := range override.SeverityOverrides {
+			result.SeverityOverrides[k] = v
+		}
+	}
+
+	// NonZeroExitCode: override takes precedence if set
+	if override.NonZeroExitCode != "" {
+		result.NonZeroExitCode = override.NonZeroExitCode
+	} else {
+		result.NonZeroExitCode = base.NonZeroExitCode
+	}
+
 	// ConfigureRules: merge maps
 	result.ConfigureRules = make(map[string]map[string]interface{})
 	for k, v := range base.ConfigureRules {
@@ -191,6 +191,7 @@ func TestMerge(t *testing.T) {
 		Templates:           []string{"base/*.yaml"},
 		IgnoreTemplates:     []string{"base/ignore/*.yaml"},
 		Regions:             []string{"us-east-1"},
+		ParameterFiles:      []string{"base.json"},
 		IgnoreChecks:        []string{"W1001"},
 		IncludeChecks:       []string{"E1001"},
 		IncludeExperimental: false,
@@ -205,6 +206,7 @@ func TestMerge(t *testing.T) {
 		Templates:           []string{"override/*.yaml"},
 		IgnoreTemplates:     []string{"override/ignore/*.yaml"},
 		Regions:             []string{"eu-west-1"},
+		ParameterFiles:      []string{"override.json"},
 		IgnoreChecks:        []string{"W2001"},
 		IncludeChecks:       []string{"E2001"},
 		IncludeExperimental: true,
@@ -233,6 +235,11 @@ func TestMerge(t *testing.T) {
 		t.Errorf("Expected regions from override, got %v", result.Regions)
 	}
 
+	// ParameterFiles should be from override
+	if len(result.ParameterFiles) != 1 || result.ParameterFiles[0] != "override.json" {
+		t.Errorf("Expected parameter files from override, got %v", result.ParameterFiles)
+	}
+
 	// IgnoreChecks should be combined
 	if len(result.IgnoreChecks) != 2 {
 		t.Errorf("Expected 2 ignore checks, got %d", len(result.IgnoreChecks))
@@ -264,6 +271,29 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMerge_SeverityAndMandatory(t *testing.T) {
+	base := &Config{
+		MandatoryChecks:   []string{"E3012"},
+		SeverityOverrides: map[string]string{"W3037": "error", "I3011": "warning"},
+	}
+	override := &Config{
+		MandatoryChecks:   []string{"W3037"},
+		SeverityOverrides: map[string]string{"I3011": "error"},
+	}
+
+	result := Merge(base, override)
+
+	if len(result.MandatoryChecks) != 2 {
+		t.Errorf("Expected 2 mandatory checks, got %v", result.MandatoryChecks)
+	}
+	if result.SeverityOverrides["W3037"] != "error" {
+		t.Errorf("Expected W3037 override from base, got %q", result.SeverityOverrides["W3037"])
+	}
+	if result.SeverityOverrides["I3011"] != "error" {
+		t.Errorf("Expected I3011 override from override, got %q", result.SeverityOverrides["I3011"])
+	}
+}
+
 func TestMerge_EmptyOverride(t *testing.T) {
 	base := &Config{
 		Templates: []string{"base/*.yaml"},
@@ -562,3 +592,89 @@ func TestMerge_SAMConfigNilOverride(t *testing.T) {
 		t.Errorf("Expected region 'us-east-1', got %s", result.SAM.TransformOptions.Region)
 	}
 }
+
+func TestFromMetadata(t *testing.T) {
+	metadata := map[string]any{
+		"cfn-lint": map[string]any{
+			"config": map[string]any{
+				"ignore_checks":        []any{"W3005", "E3012"},
+				"include_checks":       []any{"I3011"},
+				"regions":              []any{"cn-north-1"},
+				"include_experimental": true,
+				"configure_rules": map[string]any{
+					"E3010": map[string]any{"limit": 100},
+				},
+			},
+		},
+	}
+
+	cfg, err := FromMetadata(metadata)
+	if err != nil {
+		t.Fatalf("FromMetadata() error = %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("FromMetadata() returned nil config")
+	}
+
+	if len(cfg.IgnoreChecks) != 2 || cfg.IgnoreChecks[0] != "W3005" {
+		t.Errorf("IgnoreChecks = %v", cfg.IgnoreChecks)
+	}
+	if len(cfg.IncludeChecks) != 1 || cfg.IncludeChecks[0] != "I3011" {
+		t.Errorf("IncludeChecks = %v", cfg.IncludeChecks)
+	}
+	if len(cfg.Regions) != 1 || cfg.Regions[0] != "cn-north-1" {
+		t.Errorf("Regions = %v", cfg.Regions)
+	}
+	if !cfg.IncludeExperimental {
+		t.Error("IncludeExperimental should be true")
+	}
+	if cfg.ConfigureRules["E3010"]["limit"] != 100 {
+		t.Errorf("ConfigureRules = %v", cfg.ConfigureRules)
+	}
+}
+
+func TestFromMetadata_Absent(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]any
+	}{
+		{"nil metadata", nil},
+		{"no cfn-lint key", map[string]any{"Other": "value"}},
+		{"no config block", map[string]any{"cfn-lint": map[string]any{"ignore_checks": []any{"W3005"}}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := FromMetadata(tc.metadata)
+			if err != nil {
+				t.Fatalf("FromMetadata() error = %v", err)
+			}
+			if cfg != nil {
+				t.Errorf("Expected nil config, got %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestFromMetadata_Invalid(t *testing.T) {
+	metadata := map[string]any{
+		"cfn-lint": map[string]any{
+			"config": map[string]any{"ignore_checks": "W3005"},
+		},
+	}
+
+	if _, err := FromMetadata(metadata); err == nil {
+		t.Error("Expected error for ignore_checks that is not a list")
+	}
+}
+
+func TestMerge_NonZeroExitCode(t *testing.T) {
+	base := &Config{NonZeroExitCode: "warning"}
+
+	if result := Merge(base, &Config{}); result.NonZeroExitCode != "warning" {
+		t.Errorf("Expected base exit code level, got %q", result.NonZeroExitCode)
+	}
+	if result := Merge(base, &Config{NonZeroExitCode: "error"}); result.NonZeroExitCode != "error" {
+		t.Errorf("Expected override exit code level, got %q", result.NonZeroExitCode)
+	}
+}
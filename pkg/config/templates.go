@@ -0,0 +1,296 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
+)
+
+// TemplateExtensions lists the file extensions picked up when a directory
+// is walked.
+var TemplateExtensions = []string{".yaml", ".yml", ".json", ".template"}
+
+// ExpandTemplates resolves template arguments to the files to lint:
+//
+//   - a file path is used as-is, even if it does not exist, so that the
+//     linter reports it
+//   - a directory is walked recursively for files with TemplateExtensions
+//   - a glob pattern is expanded; "**" matches any number of directories
+//
+// Files found by walking a directory or expanding a "**" glob are only kept
+// if they look like CloudFormation templates (a top-level Resources or
+// AWSTemplateFormatVersion key) or Git sync deployment files (a top-level
+// template-file-path key); every match of any other glob is kept, so that
+// the linter reports files that are not templates. Hidden directories such
+// as .git are not descended into. A deployment file is followed by the template it
+// deploys, if that exists, so that the template is linted too. Files
+// matching an ignore pattern, or inside a directory matching one, are
+// dropped. Each file is returned once, in the order it was first found.
+func ExpandTemplates(patterns, ignore []string) ([]string, error) {
+	for _, pattern := range ignore {
+		if _, err := path.Match(filepath.ToSlash(pattern), ""); err != nil {
+			return nil, fmt.Errorf("invalid ignore_templates pattern %q: %w", pattern, err)
+		}
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	var add func(file templateFile)
+	// addPath adds a file that has not been read yet
+	addPath := func(file string) {
+		if !seen[filepath.Clean(file)] {
+			add(readTemplateFile(file))
+		}
+	}
+	add = func(file templateFile) {
+		if seen[filepath.Clean(file.path)] || isIgnored(file.path, ignore) {
+			return
+		}
+		seen[filepath.Clean(file.path)] = true
+		files = append(files, file.path)
+		if file.deploys != "" {
+			addPath(file.deploys)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if !hasMeta(pattern) {
+			info, err := os.Stat(pattern)
+			if err != nil || !info.IsDir() {
+				addPath(pattern)
+				continue
+			}
+		}
+
+		found, err := findTemplates(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range found {
+			add(file)
+		}
+	}
+
+	return files, nil
+}
+
+// templateFile is a file found by ExpandTemplates.
+type templateFile struct {
+	path string
+
+	// deploys is the template deployed by a Git sync deployment file, or ""
+	// if the file is not one or the template does not exist.
+	deploys string
+}
+
+// readTemplateFile reads a file to find the template it deploys, if any.
+func readTemplateFile(file string) templateFile {
+	data, _ := os.ReadFile(file)
+	return templateFile{path: file, deploys: deploymentTemplate(file, data)}
+}
+
+// findTemplates walks a directory, or the literal prefix of a glob pattern,
+// and returns the CloudFormation templates it contains, or for a glob
+// without "**" every file it matches. Each candidate file is read once.
+func findTemplates(pattern string) ([]templateFile, error) {
+	slashPattern := path.Clean(filepath.ToSlash(pattern))
+	if _, err := path.Match(slashPattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid template pattern %q: %w", pattern, err)
+	}
+
+	var segments []string
+	if slashPattern != "." {
+		segments = strings.Split(slashPattern, "/")
+	}
+
+	// The walk starts at the longest directory prefix without wildcards
+	literal := 0
+	for literal < len(segments) && !hasMeta(segments[literal]) {
+		literal++
+	}
+	root := strings.Join(segments[:literal], "/")
+	if root == "" {
+		root = "."
+		if strings.HasPrefix(slashPattern, "/") {
+			root = "/"
+		}
+	}
+	directory := literal == len(segments)
+	if directory {
+		// A directory: match every template below it
+		segments = append(segments, "**", "*")
+	}
+	recursive := false
+	for _, s := range segments {
+		recursive = recursive || s == "**"
+	}
+	// Only discovered files are checked for template content; the
+	// matches of a plain glob were named by the user
+	sniff := directory || recursive
+
+	// splitPath splits a walked path into segments aligned with the pattern
+	splitPath := func(p string) []string {
+		name := filepath.ToSlash(p)
+		if name == "." {
+			return nil
+		}
+		return strings.Split(name, "/")
+	}
+
+	var files []templateFile
+	err := filepath.WalkDir(filepath.FromSlash(root), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == filepath.FromSlash(root) && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir // a glob with no matches
+			}
+			return err
+		}
+
+		parts := splitPath(p)
+		if d.IsDir() {
+			if p == filepath.FromSlash(root) {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") || (!recursive && len(parts) >= len(segments)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() || !matchGlob(segments, parts) {
+			return nil
+		}
+		if directory && !hasTemplateExtension(p) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			// Let the linter report unreadable files
+			files = append(files, templateFile{path: p})
+			return nil
+		}
+		if !sniff || looksLikeTemplate(data) {
+			files = append(files, templateFile{path: p, deploys: deploymentTemplate(p, data)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding templates for %q: %w", pattern, err)
+	}
+	return files, nil
+}
+
+// isIgnored reports whether a file, or one of its parent directories,
+// matches an ignore pattern.
+func isIgnored(file string, ignore []string) bool {
+	if len(ignore) == 0 {
+		return false
+	}
+	parts := strings.Split(path.Clean(filepath.ToSlash(file)), "/")
+	for _, pattern := range ignore {
+		segments := strings.Split(path.Clean(filepath.ToSlash(pattern)), "/")
+		for n := len(parts); n > 0; n-- {
+			if matchGlob(segments, parts[:n]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against pattern segments. A "**" segment
+// matches zero or more path segments; other segments use path.Match.
+func matchGlob(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchGlob(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// PatternRoot returns the directory ExpandTemplates walks for a glob
+// pattern, its longest prefix without wildcards, and any other pattern
+// unchanged. Its modification time changes when files are added to or
+// removed from it, but not from its subdirectories.
+func PatternRoot(pattern string) string {
+	if !hasMeta(pattern) {
+		return pattern
+	}
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	literal := 0
+	for literal < len(segments) && !hasMeta(segments[literal]) {
+		literal++
+	}
+	root := strings.Join(segments[:literal], "/")
+	if root == "" {
+		root = "."
+		if strings.HasPrefix(filepath.ToSlash(pattern), "/") {
+			root = "/"
+		}
+	}
+	return filepath.FromSlash(root)
+}
+
+func hasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+func hasTemplateExtension(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range TemplateExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// deploymentTemplate returns the template deployed by a Git sync
+// deployment file with content data, or "" if file is not one or the
+// template does not exist.
+func deploymentTemplate(file string, data []byte) string {
+	if !deployment.IsFile(data) {
+		return ""
+	}
+	tmpl := deployment.ParseFile(file, data).TemplatePath()
+	if info, err := os.Stat(tmpl); tmpl == "" || err != nil || info.IsDir() {
+		return ""
+	}
+	return tmpl
+}
+
+var (
+	yamlTemplateKey = regexp.MustCompile(`(?m)^["']?(AWSTemplateFormatVersion|Resources|template-file-path)["']?\s*:`)
+	jsonTemplateKey = regexp.MustCompile(`"(AWSTemplateFormatVersion|Resources|template-file-path)"\s*:`)
+)
+
+// looksLikeTemplate reports whether file content has a top-level
+// Resources, AWSTemplateFormatVersion or template-file-path key. It is a
+// cheap textual check used to skip other YAML and JSON files found while
+// walking directories.
+func looksLikeTemplate(data []byte) bool {
+	if detectFormat(data) == ".json" {
+		return jsonTemplateKey.Match(data)
+	}
+	return yamlTemplateKey.Match(data)
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testTemplate = "AWSTemplateFormatVersion: '2010-09-09'\nResources: {}\n"
+
+// writeFiles creates files below dir and returns dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) string {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestExpandTemplates(t *testing.T) {
+	dir := writeFiles(t, t.TempDir(), map[string]string{
+		"app.yaml":                   testTemplate,
+		"stacks/network.yml":         "Resources:\n  Vpc:\n    Type: AWS::EC2::VPC\n",
+		"stacks/db/db.json":          `{"Resources": {}}`,
+		"stacks/db/notes.txt":        testTemplate,
+		"stacks/vendor/lib.yaml":     testTemplate,
+		"stacks/.aws-sam/build.yaml": testTemplate,
+		".github/workflows/ci.yaml":  "on: push\njobs: {}\n",
+		"k8s/deployment.yaml":        "apiVersion: apps/v1\nkind: Deployment\n",
+		"serverless/serverless.yml":  "service: api\nresources:\n  Resources:\n    Queue:\n      Type: AWS::SQS::Queue\n",
+		"package.json":               `{"name": "app"}`,
+		"custom/template.cfn":        testTemplate,
+		"custom/not-a-template.cfn":  "just text\n",
+	})
+	p := func(name string) string {
+		return filepath.Join(dir, filepath.FromSlash(name))
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		ignore   []string
+		want     []string
+	}{
+		{
+			name:     "directory walk",
+			patterns: []string{dir},
+			want:     []string{p("app.yaml"), p("stacks/db/db.json"), p("stacks/network.yml"), p("stacks/vendor/lib.yaml")},
+		},
+		{
+			name:     "single star glob",
+			patterns: []string{p("*.yaml"), p("stacks/*.yml")},
+			want:     []string{p("app.yaml"), p("stacks/network.yml")},
+		},
+		{
+			name:     "double star glob",
+			patterns: []string{p("stacks/**/*.json")},
+			want:     []string{p("stacks/db/db.json")},
+		},
+		{
+			name:     "double star matches no directories",
+			patterns: []string{p("**/app.yaml")},
+			want:     []string{p("app.yaml")},
+		},
+		{
+			name:     "glob ignores extension list and content",
+			patterns: []string{p("custom/*.cfn"), p("k8s/*.yaml")},
+			want:     []string{p("custom/not-a-template.cfn"), p("custom/template.cfn"), p("k8s/deployment.yaml")},
+		},
+		{
+			name:     "double star glob skips non-templates",
+			patterns: []string{p("**/*.yaml")},
+			want:     []string{p("app.yaml"), p("stacks/vendor/lib.yaml")},
+		},
+		{
+			name:     "ignored file and directory",
+			patterns: []string{dir},
+			ignore:   []string{p("**/vendor"), p("stacks/db/*.json")},
+			want:     []string{p("app.yaml"), p("stacks/network.yml")},
+		},
+		{
+			name:     "explicit files kept as-is",
+			patterns: []string{p("k8s/deployment.yaml"), p("missing.yaml")},
+			want:     []string{p("k8s/deployment.yaml"), p("missing.yaml")},
+		},
+		{
+			name:     "explicit files can be ignored",
+			patterns: []string{p("app.yaml")},
+			ignore:   []string{p("*.yaml")},
+			want:     nil,
+		},
+		{
+			name:     "duplicates removed",
+			patterns: []string{p("app.yaml"), p("*.yaml"), dir},
+			want:     []string{p("app.yaml"), p("stacks/db/db.json"), p("stacks/network.yml"), p("stacks/vendor/lib.yaml")},
+		},
+		{
+			name:     "glob without matches",
+			patterns: []string{p("nothing/**/*.yaml")},
+			want:     nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ExpandTemplates(tc.patterns, tc.ignore)
+			if err != nil {
+				t.Fatalf("ExpandTemplates failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExpandTemplates_RelativePatterns(t *testing.T) {
+	dir := writeFiles(t, t.TempDir(), map[string]string{
+		"templates/a.yaml":     testTemplate,
+		"templates/sub/b.yaml": testTemplate,
+	})
+
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(oldWd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	got, err := ExpandTemplates([]string{"./templates/**/*.yaml"}, nil)
+	if err != nil {
+		t.Fatalf("ExpandTemplates failed: %v", err)
+	}
+	want := []string{filepath.Join("templates", "a.yaml"), filepath.Join("templates", "sub", "b.yaml")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got, err = ExpandTemplates([]string{"."}, []string{"templates/sub"})
+	if err != nil {
+		t.Fatalf("ExpandTemplates failed: %v", err)
+	}
+	if want := []string{filepath.Join("templates", "a.yaml")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestExpandTemplates_DeploymentFiles(t *testing.T) {
+	dir := writeFiles(t, t.TempDir(), map[string]string{
+		".git/HEAD":                "ref: refs/heads/main\n",
+		"templates/app.yaml":       testTemplate,
+		"deployments/prod.yaml":    "template-file-path: templates/app.yaml\nparameters:\n  Env: prod\n",
+		"deployments/missing.yaml": "template-file-path: templates/gone.yaml\n",
+	})
+
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(oldWd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	// The template follows the deployment file that names it
+	got, err := ExpandTemplates([]string{"deployments"}, nil)
+	if err != nil {
+		t.Fatalf("ExpandTemplates failed: %v", err)
+	}
+	want := []string{filepath.Join("deployments", "missing.yaml"), filepath.Join("deployments", "prod.yaml"), filepath.Join("templates", "app.yaml")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got, err = ExpandTemplates([]string{"./templates/app.yaml", "deployments/prod.yaml"}, []string{"deployments/missing.yaml"})
+	if err != nil {
+		t.Fatalf("ExpandTemplates failed: %v", err)
+	}
+	if want := []string{"./templates/app.yaml", "deployments/prod.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got, err = ExpandTemplates([]string{"deployments/prod.yaml"}, []string{"templates"})
+	if err != nil {
+		t.Fatalf("ExpandTemplates failed: %v", err)
+	}
+	if want := []string{"deployments/prod.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestExpandTemplates_InvalidPattern(t *testing.T) {
+	if _, err := ExpandTemplates([]string{"templates/[a.yaml"}, nil); err == nil {
+		t.Error("Expected error for invalid template pattern")
+	}
+	if _, err := ExpandTemplates(nil, []string{"[a"}); err == nil {
+		t.Error("Expected error for invalid ignore pattern")
+	}
+}
+
+func TestPatternRoot(t *testing.T) {
+	tests := map[string]string{
+		"templates":              "templates",
+		"templates/a.yaml":       "templates/a.yaml",
+		"templates/*.yaml":       "templates",
+		"templates/**/app/*.yml": "templates",
+		"*.yaml":                 ".",
+		"/*.yaml":                "/",
+	}
+	for pattern, want := range tests {
+		if got := PatternRoot(pattern); got != filepath.FromSlash(want) {
+			t.Errorf("PatternRoot(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestLoad_ResolvesTemplatesAgainstConfigDir(t *testing.T) {
+	dir := writeFiles(t, t.TempDir(), map[string]string{
+		"infra/.cfnlintrc.yaml": "templates:\n  - templates/*.yaml\n  - /abs/template.yaml\nignore_templates:\n  - templates/legacy.yaml\nparameter_files:\n  - params/prod.json\n",
+	})
+
+	cfg, err := Load(filepath.Join(dir, "infra", ".cfnlintrc.yaml"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "infra", "templates", "*.yaml"), "/abs/template.yaml"}
+	if !reflect.DeepEqual(cfg.Templates, want) {
+		t.Errorf("Expected templates %v, got %v", want, cfg.Templates)
+	}
+	if want := filepath.Join(dir, "infra", "templates", "legacy.yaml"); cfg.IgnoreTemplates[0] != want {
+		t.Errorf("Expected ignore template %s, got %s", want, cfg.IgnoreTemplates[0])
+	}
+	if want := filepath.Join(dir, "infra", "params", "prod.json"); cfg.ParameterFiles[0] != want {
+		t.Errorf("Expected parameter file %s, got %s", want, cfg.ParameterFiles[0])
+	}
+}
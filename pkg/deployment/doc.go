@@ -0,0 +1,45 @@
+// Package deployment reads the files that supply parameter values when a
+// template is deployed, so that the values can be checked against the
+// template's Parameters: stack parameter files, and CloudFormation Git sync
+// deployment files.
+//
+// Parameter files may be written in JSON or YAML, in any of three formats:
+//
+//	# AWS CLI (aws cloudformation create-stack --parameters file://...)
+//	[{"ParameterKey": "Env", "ParameterValue": "prod"}]
+//
+//	# CodePipeline template configuration
+//	{"Parameters": {"Env": "prod"}, "Tags": {"Team": "web"}}
+//
+//	# Plain mapping
+//	{"Env": "prod"}
+//
+// # Usage
+//
+//	params := deployment.LoadParameters("params/prod.json")
+//	for _, e := range params.Errors {
+//	    fmt.Printf("%s:%d: %s\n", params.Filename, e.Line, e.Message)
+//	}
+//	for _, v := range params.Values {
+//	    fmt.Printf("%s = %s\n", v.Key, v.Value)
+//	}
+//
+// Git sync deployment files name the template of a stack along with its
+// parameters and tags:
+//
+//	template-file-path: templates/app.yaml
+//	parameters:
+//	  Env: prod
+//	tags:
+//	  Team: web
+//
+// ParseFile reads them into a File, whose Parameters hold the parameters
+// section in FormatGitSync and whose TemplatePath resolves
+// template-file-path the way Git sync does.
+//
+// Positions of values and errors refer to the parameter file, so that
+// findings can point at the line to change. The linter loads the files
+// named in lint.Options.ParameterFiles and passes them to rules that
+// implement rules.ParameterRule; deployment files it is asked to lint go to
+// rules that implement rules.DeploymentRule.
+package deployment
@@ -0,0 +1,223 @@
+package deployment
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Stack tag limits enforced by CloudFormation.
+const (
+	maxTags           = 50
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// File is a CloudFormation Git sync deployment file, which names the
+// template of a stack and the parameter values and tags to deploy it with:
+//
+//	template-file-path: templates/app.yaml
+//	parameters:
+//	  Env: prod
+//	tags:
+//	  Team: web
+type File struct {
+	// Filename is the path the file was read from.
+	Filename string
+
+	// TemplateFilePath is the template path as written, and TemplateNode
+	// its node. TemplateNode is nil when the key is missing or invalid.
+	TemplateFilePath string
+	TemplateNode     *yaml.Node
+
+	// Parameters holds the parameters section in FormatGitSync. When the
+	// file has no parameters section it is empty and located at the root.
+	Parameters *Parameters
+
+	// Tags lists the stack tags in file order.
+	Tags []Tag
+
+	// Errors lists the problems with the file's structure, including those
+	// in the parameters section.
+	Errors []Error
+}
+
+// Tag is a stack tag from a deployment file.
+type Tag struct {
+	Key       string
+	Value     string
+	KeyNode   *yaml.Node
+	ValueNode *yaml.Node
+	Path      []string
+}
+
+// IsFile reports whether data is a deployment file rather than a template:
+// a mapping with a top-level template-file-path key.
+func IsFile(data []byte) bool {
+	if !bytes.Contains(data, []byte("template-file-path")) {
+		return false
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return false
+	}
+	root := doc.Content[0]
+	return root.Kind == yaml.MappingNode && hasTemplateFilePath(root)
+}
+
+// LoadFile reads and parses a deployment file. A file that cannot be read
+// is returned with the read error in Errors.
+func LoadFile(path string) *File {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &File{
+			Filename:   path,
+			Parameters: &Parameters{Filename: path},
+			Errors:     []Error{{Message: fmt.Sprintf("reading deployment file: %v", err)}},
+		}
+	}
+	return ParseFile(path, data)
+}
+
+// ParseFile parses a deployment file. Problems are recorded in Errors
+// rather than returned.
+func ParseFile(filename string, data []byte) *File {
+	f := &File{Filename: filename, Parameters: &Parameters{Filename: filename}}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		f.Errors = append(f.Errors, parseError(err))
+		return f
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		f.Errors = append(f.Errors, Error{Message: "deployment file must be a mapping with template-file-path, parameters and tags", Line: 1, Column: 1})
+		return f
+	}
+
+	root := doc.Content[0]
+	f.Parameters.Format = FormatGitSync
+	f.Parameters.Node = root
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		path := []string{key.Value}
+		switch key.Value {
+		case "template-file-path":
+			if !isScalar(value) || value.ShortTag() != "!!str" || value.Value == "" {
+				f.Errors = append(f.Errors, nodeError(value, path, "template-file-path must be a non-empty string"))
+				continue
+			}
+			f.TemplateFilePath, f.TemplateNode = value.Value, value
+		case "parameters":
+			if value.Kind != yaml.MappingNode {
+				f.Errors = append(f.Errors, nodeError(value, path, "parameters must be a mapping of parameter names to values"))
+				continue
+			}
+			f.Parameters.Node, f.Parameters.Path = value, path
+			f.Parameters.parseMap(value, path)
+		case "tags":
+			if value.Kind != yaml.MappingNode {
+				f.Errors = append(f.Errors, nodeError(value, path, "tags must be a mapping of tag keys to values"))
+				continue
+			}
+			f.parseTags(value, path)
+		default:
+			f.Errors = append(f.Errors, nodeError(key, path, fmt.Sprintf("unknown key '%s' in deployment file (valid: template-file-path, parameters, tags)", key.Value)))
+		}
+	}
+
+	if !hasTemplateFilePath(root) {
+		f.Errors = append(f.Errors, nodeError(root, nil, "deployment file is missing template-file-path"))
+	}
+
+	// Parameter errors are deployment file errors
+	f.Errors = append(f.Errors, f.Parameters.Errors...)
+	f.Parameters.Errors = nil
+	return f
+}
+
+// parseTags reads the tags section, checking CloudFormation's tag limits.
+func (f *File) parseTags(mapping *yaml.Node, path []string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		tagPath := appendPath(path, key.Value)
+		switch {
+		case !isScalar(value):
+			f.Errors = append(f.Errors, nodeError(value, tagPath, fmt.Sprintf("value of tag '%s' must be a string", key.Value)))
+			continue
+		case strings.HasPrefix(strings.ToLower(key.Value), "aws:"):
+			f.Errors = append(f.Errors, nodeError(key, tagPath, fmt.Sprintf("tag key '%s' cannot start with 'aws:'", key.Value)))
+		case utf8.RuneCountInString(key.Value) > maxTagKeyLength:
+			f.Errors = append(f.Errors, nodeError(key, tagPath, fmt.Sprintf("tag key '%s' is longer than %d characters", key.Value, maxTagKeyLength)))
+		}
+		if utf8.RuneCountInString(value.Value) > maxTagValueLength {
+			f.Errors = append(f.Errors, nodeError(value, tagPath, fmt.Sprintf("value of tag '%s' is longer than %d characters", key.Value, maxTagValueLength)))
+		}
+		f.Tags = append(f.Tags, Tag{Key: key.Value, Value: value.Value, KeyNode: key, ValueNode: value, Path: tagPath})
+	}
+	if len(f.Tags) > maxTags {
+		f.Errors = append(f.Errors, nodeError(mapping, path, fmt.Sprintf("deployment file has %d tags; a stack can have at most %d", len(f.Tags), maxTags)))
+	}
+}
+
+// TemplatePath returns the path of the template the file deploys, or ""
+// if the file names none. Git sync resolves template-file-path against the
+// repository root, so the path is resolved against the root of the Git
+// repository holding the deployment file, or against the file's directory
+// outside a repository. The result is relative to the working directory
+// when it is inside it.
+func (f *File) TemplatePath() string {
+	if f.TemplateFilePath == "" {
+		return ""
+	}
+	if filepath.IsAbs(f.TemplateFilePath) {
+		return filepath.Clean(f.TemplateFilePath)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(f.Filename))
+	if err != nil {
+		return filepath.Join(filepath.Dir(f.Filename), f.TemplateFilePath)
+	}
+	if root := repositoryRoot(dir); root != "" {
+		dir = root
+	}
+	resolved := filepath.Join(dir, f.TemplateFilePath)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return resolved
+	}
+	rel, err := filepath.Rel(wd, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return resolved
+	}
+	return rel
+}
+
+// repositoryRoot returns the nearest directory at or above dir holding a
+// .git entry, or "" if there is none.
+func repositoryRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func hasTemplateFilePath(mapping *yaml.Node) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == "template-file-path" {
+			return true
+		}
+	}
+	return false
+}
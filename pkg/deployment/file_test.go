@@ -0,0 +1,189 @@
+package deployment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFile(t *testing.T) {
+	f := ParseFile("deploy.yaml", []byte(`template-file-path: templates/app.yaml
+parameters:
+  Env: prod
+  Size: 3
+tags:
+  Team: web
+`))
+	if len(f.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %+v", f.Errors)
+	}
+	if f.TemplateFilePath != "templates/app.yaml" || f.TemplateNode.Line != 1 || f.TemplateNode.Column != 21 {
+		t.Errorf("Unexpected template-file-path %q at %+v", f.TemplateFilePath, f.TemplateNode)
+	}
+	if f.Parameters.Format != FormatGitSync || f.Parameters.Filename != "deploy.yaml" {
+		t.Errorf("Unexpected parameters %+v", f.Parameters)
+	}
+	if got, want := values(f.Parameters), []string{"Env=prod", "Size=3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected values %v, got %v", want, got)
+	}
+	if want := []string{"parameters", "Env"}; !reflect.DeepEqual(f.Parameters.Values[0].ValuePath, want) {
+		t.Errorf("Expected value path %v, got %v", want, f.Parameters.Values[0].ValuePath)
+	}
+	if len(f.Tags) != 1 || f.Tags[0].Key != "Team" || f.Tags[0].Value != "web" {
+		t.Errorf("Unexpected tags %+v", f.Tags)
+	}
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	var manyTags strings.Builder
+	manyTags.WriteString("template-file-path: app.yaml\ntags:\n")
+	for i := 0; i <= maxTags; i++ {
+		fmt.Fprintf(&manyTags, "  Tag%d: x\n", i)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		message string
+		line    int
+	}{
+		{
+			name:    "invalid syntax",
+			data:    "template-file-path: app.yaml\nparameters: \"x\n",
+			message: "yaml: line 2",
+			line:    2,
+		},
+		{
+			name:    "not a mapping",
+			data:    "- template-file-path\n",
+			message: "deployment file must be a mapping",
+			line:    1,
+		},
+		{
+			name:    "missing template-file-path",
+			data:    "parameters:\n  Env: prod\n",
+			message: "deployment file is missing template-file-path",
+			line:    1,
+		},
+		{
+			name:    "empty template-file-path",
+			data:    "template-file-path: \"\"\n",
+			message: "template-file-path must be a non-empty string",
+			line:    1,
+		},
+		{
+			name:    "unknown key",
+			data:    "template-file-path: app.yaml\nparameter:\n  Env: prod\n",
+			message: "unknown key 'parameter' in deployment file",
+			line:    2,
+		},
+		{
+			name:    "parameters not a mapping",
+			data:    "template-file-path: app.yaml\nparameters:\n  - Env\n",
+			message: "parameters must be a mapping",
+			line:    3,
+		},
+		{
+			name:    "parameter value not a string",
+			data:    "template-file-path: app.yaml\nparameters:\n  Subnets: [a, b]\n",
+			message: "value of parameter 'Subnets' must be a string",
+			line:    3,
+		},
+		{
+			name:    "reserved tag key",
+			data:    "template-file-path: app.yaml\ntags:\n  aws:team: web\n",
+			message: "tag key 'aws:team' cannot start with 'aws:'",
+			line:    3,
+		},
+		{
+			name:    "tag value too long",
+			data:    "template-file-path: app.yaml\ntags:\n  Team: " + strings.Repeat("x", maxTagValueLength+1) + "\n",
+			message: "value of tag 'Team' is longer than 256 characters",
+			line:    3,
+		},
+		{
+			name:    "too many tags",
+			data:    manyTags.String(),
+			message: "deployment file has 51 tags; a stack can have at most 50",
+			line:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := ParseFile("deploy.yaml", []byte(tt.data))
+			if len(f.Errors) != 1 {
+				t.Fatalf("Expected 1 error, got %+v", f.Errors)
+			}
+			if !strings.Contains(f.Errors[0].Message, tt.message) {
+				t.Errorf("Expected error containing %q, got %q", tt.message, f.Errors[0].Message)
+			}
+			if f.Errors[0].Line != tt.line {
+				t.Errorf("Expected error on line %d, got %d", tt.line, f.Errors[0].Line)
+			}
+			if len(f.Parameters.Errors) != 0 {
+				t.Errorf("Expected parameter errors to move to the file, got %+v", f.Parameters.Errors)
+			}
+		})
+	}
+}
+
+func TestIsFile(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{"template-file-path: app.yaml\n", true},
+		{`{"template-file-path": "app.json", "parameters": {}}`, true},
+		{"AWSTemplateFormatVersion: '2010-09-09'\nResources: {}\n", false},
+		{"Resources:\n  Bucket:\n    Metadata:\n      template-file-path: x\n", false},
+		{"- template-file-path\n", false},
+		{"template-file-path: [\n", false},
+	}
+	for _, tt := range tests {
+		if got := IsFile([]byte(tt.data)); got != tt.want {
+			t.Errorf("IsFile(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestFile_TemplatePath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	// Inside a repository the path is relative to the repository root
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	f := &File{Filename: filepath.Join("deployments", "prod.yaml"), TemplateFilePath: "templates/app.yaml"}
+	if got, want := f.TemplatePath(), filepath.Join("templates", "app.yaml"); got != want {
+		t.Errorf("Expected %q inside a repository, got %q", want, got)
+	}
+
+	// Outside a repository it is relative to the deployment file
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.TemplatePath(), filepath.Join("deployments", "templates", "app.yaml"); repositoryRoot(dir) == "" && got != want {
+		t.Errorf("Expected %q outside a repository, got %q", want, got)
+	}
+
+	abs := &File{Filename: "prod.yaml", TemplateFilePath: "/srv/templates/../app.yaml"}
+	if got := abs.TemplatePath(); got != filepath.Clean("/srv/app.yaml") {
+		t.Errorf("Expected absolute path to be cleaned, got %q", got)
+	}
+	if got := (&File{Filename: "prod.yaml"}).TemplatePath(); got != "" {
+		t.Errorf("Expected no path without template-file-path, got %q", got)
+	}
+}
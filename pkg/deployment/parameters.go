@@ -0,0 +1,311 @@
+package deployment
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies the layout of a parameter file.
+type Format string
+
+const (
+	// FormatCLI is the list of parameters taken by the AWS CLI:
+	//
+	//	[{"ParameterKey": "Env", "ParameterValue": "prod"}]
+	FormatCLI Format = "cli"
+
+	// FormatTemplateConfiguration is a CodePipeline template configuration
+	// file:
+	//
+	//	{"Parameters": {"Env": "prod"}, "Tags": {...}, "StackPolicy": {...}}
+	FormatTemplateConfiguration Format = "template-configuration"
+
+	// FormatMap is a plain mapping of parameter names to values:
+	//
+	//	{"Env": "prod"}
+	FormatMap Format = "map"
+
+	// FormatGitSync is the parameters section of a Git sync deployment
+	// file; see File.
+	FormatGitSync Format = "git-sync"
+)
+
+// Parameters holds the parameter values read from a parameter file.
+type Parameters struct {
+	// Filename is the path the parameters were read from.
+	Filename string
+
+	// Format is the layout the file was recognized as. It is empty when the
+	// file could not be read as any supported format; Errors says why.
+	Format Format
+
+	// Node is the list or mapping holding the parameter values, and Path
+	// its path in the file. Node is nil when the file did not parse.
+	Node *yaml.Node
+	Path []string
+
+	// Values lists the parameter values in file order. Entries with errors
+	// are left out.
+	Values []Value
+
+	// Errors lists the syntax errors in the file.
+	Errors []Error
+}
+
+// Value is a single parameter value.
+type Value struct {
+	// Key is the parameter name.
+	Key string
+
+	// Value is the parameter value as written; numbers and booleans keep
+	// their source text.
+	Value string
+
+	// UsePreviousValue is set for CLI entries that keep the stack's current
+	// value instead of giving one. Value is then empty.
+	UsePreviousValue bool
+
+	// KeyNode and ValueNode are the nodes of the name and the value, and
+	// KeyPath and ValuePath their paths in the file. ValueNode is nil when
+	// UsePreviousValue is set.
+	KeyNode   *yaml.Node
+	ValueNode *yaml.Node
+	KeyPath   []string
+	ValuePath []string
+}
+
+// Error is a syntax error in a parameter file.
+type Error struct {
+	Message string
+
+	// Line and Column locate the error; they are zero when the file could
+	// not be read.
+	Line   int
+	Column int
+
+	// Path is the path to the offending element, if any.
+	Path []string
+}
+
+// templateConfigurationKeys are the top-level keys of a template
+// configuration file.
+var templateConfigurationKeys = map[string]bool{
+	"Parameters":  true,
+	"Tags":        true,
+	"StackPolicy": true,
+}
+
+// cliEntryKeys are the keys allowed in an entry of a CLI parameter list.
+// ResolvedValue appears in the output of describe-stacks.
+var cliEntryKeys = map[string]bool{
+	"ParameterKey":     true,
+	"ParameterValue":   true,
+	"UsePreviousValue": true,
+	"ResolvedValue":    true,
+}
+
+// yamlErrorLine extracts the line number from a YAML parser error.
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// LoadParameters reads and parses a parameter file. A file that cannot be
+// read is returned with the read error in Errors.
+func LoadParameters(path string) *Parameters {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &Parameters{
+			Filename: path,
+			Errors:   []Error{{Message: fmt.Sprintf("reading parameter file: %v", err)}},
+		}
+	}
+	return ParseParameters(path, data)
+}
+
+// ParseParameters parses a parameter file in any of the supported formats,
+// which may be written in JSON or YAML. Problems are recorded in Errors
+// rather than returned, so that one bad entry does not hide the others.
+func ParseParameters(filename string, data []byte) *Parameters {
+	p := &Parameters{Filename: filename}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		p.addError(parseError(err))
+		return p
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		p.Errors = append(p.Errors, Error{Message: "parameter file is empty", Line: 1, Column: 1})
+		return p
+	}
+
+	root := doc.Content[0]
+	switch {
+	case root.Kind == yaml.SequenceNode:
+		p.Format = FormatCLI
+		p.Node = root
+		p.parseCLI(root)
+	case root.Kind == yaml.MappingNode && isTemplateConfiguration(root):
+		p.Format = FormatTemplateConfiguration
+		p.parseTemplateConfiguration(root)
+	case root.Kind == yaml.MappingNode:
+		p.Format = FormatMap
+		p.Node = root
+		p.parseMap(root, nil)
+	default:
+		p.addError(nodeError(root, nil, "parameter file must be a list of ParameterKey/ParameterValue objects or a mapping of parameter names to values"))
+	}
+	return p
+}
+
+// parseCLI reads a list of ParameterKey/ParameterValue objects.
+func (p *Parameters) parseCLI(list *yaml.Node) {
+	seen := make(map[string]bool)
+	for i, item := range list.Content {
+		itemPath := []string{fmt.Sprintf("[%d]", i)}
+		if item.Kind != yaml.MappingNode {
+			p.addError(nodeError(item, itemPath, "parameter entry must be an object with ParameterKey and ParameterValue"))
+			continue
+		}
+
+		var v Value
+		valid := true
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			key, value := item.Content[j], item.Content[j+1]
+			path := appendPath(itemPath, key.Value)
+			switch {
+			case !cliEntryKeys[key.Value]:
+				p.addError(nodeError(key, path, fmt.Sprintf("unknown key '%s' in parameter entry (valid: ParameterKey, ParameterValue, UsePreviousValue, ResolvedValue)", key.Value)))
+				valid = false
+			case key.Value == "UsePreviousValue":
+				if value.Kind != yaml.ScalarNode || value.ShortTag() != "!!bool" {
+					p.addError(nodeError(value, path, "UsePreviousValue must be a boolean"))
+					valid = false
+					continue
+				}
+				v.UsePreviousValue = value.Value == "true"
+			case !isScalar(value):
+				p.addError(nodeError(value, path, fmt.Sprintf("%s must be a string", key.Value)))
+				valid = false
+			case key.Value == "ParameterKey":
+				v.Key, v.KeyNode, v.KeyPath = value.Value, value, path
+			case key.Value == "ParameterValue":
+				v.Value, v.ValueNode, v.ValuePath = value.Value, value, path
+			}
+		}
+
+		switch {
+		case !valid:
+			continue
+		case v.KeyNode == nil:
+			p.addError(nodeError(item, itemPath, "parameter entry is missing ParameterKey"))
+			continue
+		case v.ValueNode == nil && !v.UsePreviousValue:
+			p.addError(nodeError(item, itemPath, fmt.Sprintf("parameter '%s' needs a ParameterValue or UsePreviousValue", v.Key)))
+			continue
+		case v.ValueNode != nil && v.UsePreviousValue:
+			p.addError(nodeError(item, itemPath, fmt.Sprintf("parameter '%s' cannot have both a ParameterValue and UsePreviousValue", v.Key)))
+			continue
+		case seen[v.Key]:
+			p.addError(nodeError(v.KeyNode, v.KeyPath, fmt.Sprintf("parameter '%s' is given more than once", v.Key)))
+			continue
+		}
+		seen[v.Key] = true
+		p.Values = append(p.Values, v)
+	}
+}
+
+// parseTemplateConfiguration reads a CodePipeline template configuration.
+func (p *Parameters) parseTemplateConfiguration(root *yaml.Node) {
+	p.Node = root
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		path := []string{key.Value}
+		if !templateConfigurationKeys[key.Value] {
+			p.addError(nodeError(key, path, fmt.Sprintf("unknown key '%s' in template configuration (valid: Parameters, Tags, StackPolicy)", key.Value)))
+			continue
+		}
+		if value.Kind != yaml.MappingNode {
+			p.addError(nodeError(value, path, fmt.Sprintf("%s must be an object", key.Value)))
+			continue
+		}
+
+		switch key.Value {
+		case "Parameters":
+			p.Node, p.Path = value, path
+			p.parseMap(value, path)
+		case "Tags":
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				if tag := value.Content[j+1]; !isScalar(tag) {
+					p.addError(nodeError(tag, appendPath(path, value.Content[j].Value), fmt.Sprintf("tag '%s' must be a string", value.Content[j].Value)))
+				}
+			}
+		}
+	}
+}
+
+// parseMap reads a mapping of parameter names to values at path.
+func (p *Parameters) parseMap(mapping *yaml.Node, path []string) {
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		valuePath := appendPath(path, key.Value)
+		switch {
+		case !isScalar(value):
+			p.addError(nodeError(value, valuePath, fmt.Sprintf("value of parameter '%s' must be a string", key.Value)))
+			continue
+		case seen[key.Value]:
+			p.addError(nodeError(key, valuePath, fmt.Sprintf("parameter '%s' is given more than once", key.Value)))
+			continue
+		}
+		seen[key.Value] = true
+		p.Values = append(p.Values, Value{
+			Key:       key.Value,
+			Value:     value.Value,
+			KeyNode:   key,
+			ValueNode: value,
+			KeyPath:   valuePath,
+			ValuePath: valuePath,
+		})
+	}
+}
+
+func (p *Parameters) addError(err Error) {
+	p.Errors = append(p.Errors, err)
+}
+
+// isTemplateConfiguration reports whether a mapping is a template
+// configuration rather than a plain map of values: it has a Parameters,
+// Tags or StackPolicy key whose value is not a plain value.
+func isTemplateConfiguration(mapping *yaml.Node) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if templateConfigurationKeys[mapping.Content[i].Value] && mapping.Content[i+1].Kind == yaml.MappingNode {
+			return true
+		}
+	}
+	return false
+}
+
+// isScalar reports whether node is a plain value: a string, number or
+// boolean.
+func isScalar(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() != "!!null"
+}
+
+func nodeError(node *yaml.Node, path []string, message string) Error {
+	return Error{Message: message, Line: node.Line, Column: node.Column, Path: path}
+}
+
+// parseError converts a YAML parser error, taking the line from its message.
+func parseError(err error) Error {
+	e := Error{Message: err.Error(), Line: 1, Column: 1}
+	if m := yamlErrorLine.FindStringSubmatch(e.Message); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+	}
+	return e
+}
+
+func appendPath(path []string, elem string) []string {
+	return append(append([]string(nil), path...), elem)
+}
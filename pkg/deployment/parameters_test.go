@@ -0,0 +1,227 @@
+package deployment
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// values returns the parameters as key=value strings, with "<previous>"
+// for UsePreviousValue entries.
+func values(p *Parameters) []string {
+	var result []string
+	for _, v := range p.Values {
+		value := v.Value
+		if v.UsePreviousValue {
+			value = "<previous>"
+		}
+		result = append(result, v.Key+"="+value)
+	}
+	return result
+}
+
+func TestParseParameters_Formats(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format Format
+		values []string
+		path   []string
+	}{
+		{
+			name: "cli",
+			data: `[
+  {"ParameterKey": "Env", "ParameterValue": "prod"},
+  {"ParameterKey": "Size", "ParameterValue": 3},
+  {"ParameterKey": "Vpc", "UsePreviousValue": true}
+]`,
+			format: FormatCLI,
+			values: []string{"Env=prod", "Size=3", "Vpc=<previous>"},
+		},
+		{
+			name:   "template configuration",
+			data:   `{"Parameters": {"Env": "prod"}, "Tags": {"Team": "web"}, "StackPolicy": {"Statement": []}}`,
+			format: FormatTemplateConfiguration,
+			values: []string{"Env=prod"},
+			path:   []string{"Parameters"},
+		},
+		{
+			name:   "map",
+			data:   "Env: prod\nEnabled: true\n",
+			format: FormatMap,
+			values: []string{"Env=prod", "Enabled=true"},
+		},
+		{
+			name:   "map with a parameter named Tags",
+			data:   `{"Tags": "a,b"}`,
+			format: FormatMap,
+			values: []string{"Tags=a,b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ParseParameters("params.json", []byte(tt.data))
+			if len(p.Errors) > 0 {
+				t.Fatalf("Unexpected errors: %+v", p.Errors)
+			}
+			if p.Format != tt.format {
+				t.Errorf("Expected format %q, got %q", tt.format, p.Format)
+			}
+			if got := values(p); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("Expected values %v, got %v", tt.values, got)
+			}
+			if !reflect.DeepEqual(p.Path, tt.path) {
+				t.Errorf("Expected path %v, got %v", tt.path, p.Path)
+			}
+		})
+	}
+}
+
+func TestParseParameters_Positions(t *testing.T) {
+	p := ParseParameters("params.json", []byte(`[
+  {"ParameterKey": "Env", "ParameterValue": "prod"}
+]`))
+	if len(p.Values) != 1 {
+		t.Fatalf("Expected 1 value, got %+v", p.Values)
+	}
+	v := p.Values[0]
+	if v.KeyNode.Line != 2 || v.KeyNode.Column != 20 || v.ValueNode.Line != 2 || v.ValueNode.Column != 45 {
+		t.Errorf("Unexpected positions: key %d:%d, value %d:%d", v.KeyNode.Line, v.KeyNode.Column, v.ValueNode.Line, v.ValueNode.Column)
+	}
+	if want := []string{"[0]", "ParameterValue"}; !reflect.DeepEqual(v.ValuePath, want) {
+		t.Errorf("Expected value path %v, got %v", want, v.ValuePath)
+	}
+}
+
+func TestParseParameters_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		format  Format
+		message string
+		line    int
+		values  []string
+	}{
+		{
+			name:    "invalid syntax",
+			data:    "Env: prod\nSize: \"3\n",
+			message: "yaml: line 2",
+			line:    2,
+		},
+		{
+			name:    "empty",
+			data:    "",
+			message: "parameter file is empty",
+			line:    1,
+		},
+		{
+			name:    "scalar",
+			data:    `"prod"`,
+			message: "must be a list of ParameterKey/ParameterValue objects or a mapping",
+			line:    1,
+		},
+		{
+			name:    "cli entry without key",
+			data:    "[{\"ParameterKey\": \"Env\", \"ParameterValue\": \"prod\"},\n {\"ParameterValue\": \"x\"}]",
+			format:  FormatCLI,
+			message: "missing ParameterKey",
+			line:    2,
+			values:  []string{"Env=prod"},
+		},
+		{
+			name:    "cli entry without value",
+			data:    `[{"ParameterKey": "Env"}]`,
+			format:  FormatCLI,
+			message: "needs a ParameterValue or UsePreviousValue",
+			line:    1,
+		},
+		{
+			name:    "cli unknown key",
+			data:    `[{"ParameterKey": "Env", "Value": "prod"}]`,
+			format:  FormatCLI,
+			message: "unknown key 'Value'",
+			line:    1,
+		},
+		{
+			name:    "cli duplicate",
+			data:    "[{\"ParameterKey\": \"Env\", \"ParameterValue\": \"a\"},\n {\"ParameterKey\": \"Env\", \"ParameterValue\": \"b\"}]",
+			format:  FormatCLI,
+			message: "parameter 'Env' is given more than once",
+			line:    2,
+			values:  []string{"Env=a"},
+		},
+		{
+			name:    "cli non-boolean UsePreviousValue",
+			data:    `[{"ParameterKey": "Env", "UsePreviousValue": "yes"}]`,
+			format:  FormatCLI,
+			message: "UsePreviousValue must be a boolean",
+			line:    1,
+		},
+		{
+			name:    "template configuration unknown key",
+			data:    "{\"Parameters\": {\"Env\": \"prod\"},\n \"Tag\": {}}",
+			format:  FormatTemplateConfiguration,
+			message: "unknown key 'Tag' in template configuration",
+			line:    2,
+			values:  []string{"Env=prod"},
+		},
+		{
+			name:    "map value is an object",
+			data:    "Env: prod\nSubnets:\n  - a\n",
+			format:  FormatMap,
+			message: "value of parameter 'Subnets' must be a string",
+			line:    3,
+			values:  []string{"Env=prod"},
+		},
+		{
+			name:    "map null value",
+			data:    "Env:\n",
+			format:  FormatMap,
+			message: "value of parameter 'Env' must be a string",
+			line:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ParseParameters("params.json", []byte(tt.data))
+			if len(p.Errors) != 1 {
+				t.Fatalf("Expected 1 error, got %+v", p.Errors)
+			}
+			if !strings.Contains(p.Errors[0].Message, tt.message) {
+				t.Errorf("Expected error containing %q, got %q", tt.message, p.Errors[0].Message)
+			}
+			if p.Errors[0].Line != tt.line {
+				t.Errorf("Expected error on line %d, got %d", tt.line, p.Errors[0].Line)
+			}
+			if p.Format != tt.format {
+				t.Errorf("Expected format %q, got %q", tt.format, p.Format)
+			}
+			if got := values(p); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("Expected values %v, got %v", tt.values, got)
+			}
+		})
+	}
+}
+
+func TestLoadParameters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(path, []byte(`{"Env": "prod"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := LoadParameters(path)
+	if p.Filename != path || len(p.Values) != 1 {
+		t.Errorf("Unexpected parameters %+v", p)
+	}
+
+	missing := LoadParameters(filepath.Join(t.TempDir(), "missing.json"))
+	if len(missing.Errors) != 1 || !strings.Contains(missing.Errors[0].Message, "reading parameter file") {
+		t.Errorf("Expected a read error, got %+v", missing.Errors)
+	}
+	if missing.Format != "" || missing.Errors[0].Line != 0 {
+		t.Errorf("Expected no format and no position, got %+v", missing)
+	}
+}
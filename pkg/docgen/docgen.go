@@ -52,6 +52,20 @@ func GetRuleCategory(ruleID string) string {
 	return "Unknown"
 }
 
+// GetRuleStatusLabel returns the documentation label for a rule's status
+func GetRuleStatusLabel(r rules.Rule) string {
+	switch rules.StatusOf(r) {
+	case rules.StatusExperimental:
+		return "Experimental"
+	case rules.StatusOptIn:
+		return "Opt-in"
+	case rules.StatusDeprecated:
+		return "Deprecated"
+	default:
+		return "Implemented"
+	}
+}
+
 // GenerateRulesMarkdown generates a RULES.md file from the provided rules
 func GenerateRulesMarkdown(w io.Writer, ruleList []rules.Rule) error {
 	// Write header
@@ -127,7 +141,7 @@ func GenerateRulesMarkdown(w io.Writer, ruleList []rules.Rule) error {
 		for _, r := range rulesList {
 			// Escape pipe characters in description
 			desc := strings.ReplaceAll(r.ShortDesc(), "|", "\\|")
-			fmt.Fprintf(w, "| %s | %s | Implemented |\n", r.ID(), desc)
+			fmt.Fprintf(w, "| %s | %s | %s |\n", r.ID(), desc, GetRuleStatusLabel(r))
 		}
 		fmt.Fprintln(w)
 	}
@@ -140,6 +154,14 @@ func GenerateRulesMarkdown(w io.Writer, ruleList []rules.Rule) error {
 	fmt.Fprintln(w, "- **I (Informational)**: Suggestions and tips")
 	fmt.Fprintln(w)
 
+	fmt.Fprintln(w, "## Rule Status")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "- **Implemented**: Runs by default")
+	fmt.Fprintln(w, "- **Experimental**: Runs only with `--include-experimental` or `include_experimental: true`")
+	fmt.Fprintln(w, "- **Opt-in**: Runs only when listed in `--include-checks` or `include_checks`")
+	fmt.Fprintln(w, "- **Deprecated**: Still runs, but is scheduled for removal")
+	fmt.Fprintln(w)
+
 	fmt.Fprintln(w, "## Ignoring Rules")
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, "### CLI")
@@ -156,6 +178,22 @@ func GenerateRulesMarkdown(w io.Writer, ruleList []rules.Rule) error {
 	fmt.Fprintln(w, "  - W3002")
 	fmt.Fprintln(w, "```")
 	fmt.Fprintln(w)
+	fmt.Fprintln(w, "### Template Metadata")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Template-level configuration applies to the whole template; resource-level")
+	fmt.Fprintln(w, "`ignore_checks` only suppresses matches inside that resource.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "```yaml")
+	fmt.Fprintln(w, "Resources:")
+	fmt.Fprintln(w, "  MyBucket:")
+	fmt.Fprintln(w, "    Type: AWS::S3::Bucket")
+	fmt.Fprintln(w, "    Metadata:")
+	fmt.Fprintln(w, "      cfn-lint:")
+	fmt.Fprintln(w, "        config:")
+	fmt.Fprintln(w, "          ignore_checks:")
+	fmt.Fprintln(w, "            - W3005")
+	fmt.Fprintln(w, "```")
+	fmt.Fprintln(w)
 
 	return nil
 }
@@ -168,3 +168,38 @@ func TestRuleCategorization(t *testing.T) {
 		})
 	}
 }
+
+// statusMockRule implements rules.StatusRule for testing
+type statusMockRule struct {
+	mockRule
+	status rules.Status
+}
+
+func (r *statusMockRule) Status() rules.Status { return r.status }
+
+func TestGenerateRulesMarkdownStatus(t *testing.T) {
+	testRules := []rules.Rule{
+		&mockRule{id: "E1001", shortDesc: "Stable rule"},
+		&statusMockRule{mockRule: mockRule{id: "E1002", shortDesc: "New rule"}, status: rules.StatusExperimental},
+		&statusMockRule{mockRule: mockRule{id: "W2001", shortDesc: "Strict rule"}, status: rules.StatusOptIn},
+		&statusMockRule{mockRule: mockRule{id: "W2002", shortDesc: "Old rule"}, status: rules.StatusDeprecated},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateRulesMarkdown(&buf, testRules); err != nil {
+		t.Fatalf("GenerateRulesMarkdown() error = %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		"| E1001 | Stable rule | Implemented |",
+		"| E1002 | New rule | Experimental |",
+		"| W2001 | Strict rule | Opt-in |",
+		"| W2002 | Old rule | Deprecated |",
+		"## Rule Status",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
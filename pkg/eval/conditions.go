@@ -0,0 +1,130 @@
+package eval
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// condition evaluates a named condition to a bool or an Unknown. path
+// locates the reference, for the error when the condition is undefined.
+func (e *Evaluator) condition(name string, path []string) (any, error) {
+	if result, ok := e.conditions[name]; ok {
+		return result.value, result.err
+	}
+	cond, ok := e.tmpl.Conditions[name]
+	if !ok {
+		return nil, errorf(path, "condition '%s' is not defined", name)
+	}
+	condPath := []string{"Conditions", name}
+	if e.evaluating[name] {
+		return nil, errorf(condPath, "condition '%s' depends on itself", name)
+	}
+
+	e.evaluating[name] = true
+	value, err := e.conditionExpression(cond.Expression, condPath)
+	delete(e.evaluating, name)
+
+	e.conditions[name] = conditionResult{value: value, err: err}
+	return value, err
+}
+
+// conditionExpression evaluates a condition function, or a reference to
+// another condition, to a bool or an Unknown.
+func (e *Evaluator) conditionExpression(v any, path []string) (any, error) {
+	if m, ok := v.(map[string]any); ok && len(m) == 1 {
+		if ref, ok := m["Condition"]; ok {
+			name, ok := ref.(string)
+			if !ok {
+				return nil, errorf(AppendPath(path, "Condition"), "Condition must be a condition name")
+			}
+			return e.condition(name, AppendPath(path, "Condition"))
+		}
+	}
+
+	value, err := e.eval(v, path)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case bool, Unknown:
+		return value, nil
+	}
+	return nil, errorf(path, "condition must be Fn::Equals, Fn::And, Fn::Or, Fn::Not or a Condition reference")
+}
+
+// equals evaluates Fn::Equals: [value, value]. Scalars compare as the
+// strings CloudFormation would see.
+func (e *Evaluator) equals(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) != 2 {
+		return nil, errorf(path, "Fn::Equals must be a list of two values")
+	}
+	values := make([]any, 2)
+	for i := range args {
+		value, err := e.eval(args[i], AppendPath(path, fmt.Sprintf("[%d]", i)))
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := value.(Unknown); ok {
+			return u, nil
+		}
+		values[i] = value
+	}
+
+	a, aScalar := ScalarString(values[0])
+	b, bScalar := ScalarString(values[1])
+	if aScalar && bScalar {
+		return a == b, nil
+	}
+	if !IsKnown(values[0]) || !IsKnown(values[1]) {
+		return Unknown{Reason: "Fn::Equals compares a value that is not known"}, nil
+	}
+	return reflect.DeepEqual(values[0], values[1]), nil
+}
+
+// andOr evaluates Fn::And and Fn::Or. A false operand decides Fn::And and a
+// true one decides Fn::Or even when other operands are unknown.
+func (e *Evaluator) andOr(name string, arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) < 2 || len(args) > 10 {
+		return nil, errorf(path, "%s must be a list of 2 to 10 conditions", name)
+	}
+	decisive := name == "Fn::Or"
+	var unknown *Unknown
+	for i, item := range args {
+		value, err := e.conditionExpression(item, AppendPath(path, fmt.Sprintf("[%d]", i)))
+		if err != nil {
+			return nil, err
+		}
+		switch val := value.(type) {
+		case bool:
+			if val == decisive {
+				return decisive, nil
+			}
+		case Unknown:
+			if unknown == nil {
+				unknown = &val
+			}
+		}
+	}
+	if unknown != nil {
+		return *unknown, nil
+	}
+	return !decisive, nil
+}
+
+// not evaluates Fn::Not: [condition].
+func (e *Evaluator) not(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) != 1 {
+		return nil, errorf(path, "Fn::Not must be a list of one condition")
+	}
+	value, err := e.conditionExpression(args[0], AppendPath(path, "[0]"))
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := value.(bool); ok {
+		return !b, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,44 @@
+// Package eval resolves CloudFormation intrinsic functions to concrete
+// values, so that rules and tools can check the values a template will
+// have when it is deployed instead of skipping anything that uses a
+// function.
+//
+// An Evaluator is created for a template with the values that are only
+// known at deployment: parameter values, the region, the account and the
+// partition. It resolves Ref (to parameters and pseudo parameters),
+// Fn::Sub, Fn::Join, Fn::Select, Fn::Split, Fn::FindInMap, Fn::If,
+// Fn::Base64, Fn::Cidr, Fn::GetAZs, the AWS::LanguageExtensions functions
+// Fn::Length and Fn::ToJsonString, and the condition functions Fn::Equals,
+// Fn::And, Fn::Or and Fn::Not.
+//
+// # Usage
+//
+//	e := eval.New(tmpl, eval.Options{
+//	    Parameters: map[string]string{"Env": "prod"},
+//	    Region:     "eu-west-1",
+//	    AccountID:  "123456789012",
+//	})
+//	name, err := e.Evaluate(res.Properties["BucketName"], path)
+//	isProd, known, err := e.Condition("IsProd")
+//
+// # Unknown Values
+//
+// Values that cannot be determined before deployment, such as Fn::GetAtt,
+// Fn::ImportValue, Refs to resources and parameters without a value, come
+// back as Unknown with the reason, in place of the value. Functions given
+// an Unknown argument return it, so an Unknown spreads only as far as the
+// values it affects; IsKnown reports whether a result is fully resolved.
+// Condition functions still decide when they can: Fn::And with a false
+// operand is false even if another operand is Unknown.
+//
+// Invalid uses of a function, such as an Fn::Select index out of range,
+// are returned as *Error, located by its template path.
+//
+// # Resolving Templates
+//
+// ResolveTemplate applies the evaluation to a whole template, returning it
+// as it would be deployed: resources and outputs whose condition is false
+// are removed and every value that can be resolved is. The resolved
+// template's YAML nodes keep the positions of the source elements they
+// came from, so lint matches on it point at the source template.
+package eval
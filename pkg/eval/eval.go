@@ -0,0 +1,366 @@
+package eval
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/regions"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// Unknown stands in for a value that cannot be determined before
+// deployment, such as the result of Fn::GetAtt or a parameter without a
+// value. Functions given an unknown argument return it unchanged.
+type Unknown struct {
+	// Reason says why the value is unknown.
+	Reason string
+}
+
+func (u Unknown) String() string {
+	return "<unknown: " + u.Reason + ">"
+}
+
+// noValue is the type of NoValue.
+type noValue struct{}
+
+// NoValue is the result of Ref AWS::NoValue. Mapping entries and list
+// items that evaluate to it are removed, as CloudFormation removes them.
+var NoValue = noValue{}
+
+// Error is an invalid use of an intrinsic function, such as an Fn::Select
+// index out of range or an Fn::FindInMap key that is not in the mapping.
+type Error struct {
+	// Path locates the function in the template.
+	Path    []string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return strings.Join(e.Path, "/") + ": " + e.Message
+}
+
+// Options supplies the values that are only known at deployment. Values
+// left empty evaluate to Unknown.
+type Options struct {
+	// Parameters holds parameter values as they would be passed to
+	// CloudFormation; list parameters take comma-separated values.
+	Parameters map[string]string
+
+	// UseDefaults resolves parameters that have no value in Parameters to
+	// their Default.
+	UseDefaults bool
+
+	// Region, AccountID and StackName are the values of AWS::Region,
+	// AWS::AccountId and AWS::StackName.
+	Region    string
+	AccountID string
+	StackName string
+
+	// Partition is the value of AWS::Partition. It defaults to the
+	// partition of Region.
+	Partition string
+}
+
+// Evaluator resolves intrinsic functions in a template's values.
+type Evaluator struct {
+	tmpl *template.Template
+	opts Options
+
+	// conditions caches evaluated conditions; evaluating holds those being
+	// evaluated, to detect cycles
+	conditions map[string]conditionResult
+	evaluating map[string]bool
+}
+
+type conditionResult struct {
+	value any // bool or Unknown
+	err   error
+}
+
+// New returns an evaluator for a template.
+func New(tmpl *template.Template, opts Options) *Evaluator {
+	if opts.Partition == "" && opts.Region != "" {
+		opts.Partition = regions.Partition(opts.Region)
+	}
+	return &Evaluator{
+		tmpl:       tmpl,
+		opts:       opts,
+		conditions: make(map[string]conditionResult),
+		evaluating: make(map[string]bool),
+	}
+}
+
+// Evaluate resolves the intrinsic functions in a value decoded from the
+// template, returning a new value built from strings, numbers, booleans,
+// lists and mappings. Parts that cannot be resolved are Unknown. path is
+// the value's location in the template, used in errors.
+func (e *Evaluator) Evaluate(v any, path []string) (any, error) {
+	return e.eval(v, path)
+}
+
+// Condition evaluates a named condition. known is false when the result
+// depends on values that cannot be determined.
+func (e *Evaluator) Condition(name string) (result, known bool, err error) {
+	v, err := e.condition(name, nil)
+	if err != nil {
+		return false, false, err
+	}
+	b, ok := v.(bool)
+	return b, ok, nil
+}
+
+// IsKnown reports whether a value contains no Unknown parts.
+func IsKnown(v any) bool {
+	switch val := v.(type) {
+	case Unknown:
+		return false
+	case map[string]any:
+		for _, child := range val {
+			if !IsKnown(child) {
+				return false
+			}
+		}
+	case []any:
+		for _, child := range val {
+			if !IsKnown(child) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *Evaluator) eval(v any, path []string) (any, error) {
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) == 1 {
+			for key, arg := range val {
+				if isFunction(key) {
+					return e.function(key, arg, AppendPath(path, key))
+				}
+			}
+		}
+		result := make(map[string]any, len(val))
+		for key, child := range val {
+			resolved, err := e.eval(child, AppendPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			if resolved != NoValue {
+				result[key] = resolved
+			}
+		}
+		return result, nil
+	case []any:
+		result := make([]any, 0, len(val))
+		for i, child := range val {
+			resolved, err := e.eval(child, AppendPath(path, fmt.Sprintf("[%d]", i)))
+			if err != nil {
+				return nil, err
+			}
+			if resolved != NoValue {
+				result = append(result, resolved)
+			}
+		}
+		return result, nil
+	}
+	return v, nil
+}
+
+// function evaluates an intrinsic function; path ends with its name.
+func (e *Evaluator) function(name string, arg any, path []string) (any, error) {
+	switch name {
+	case "Ref":
+		ref, ok := arg.(string)
+		if !ok {
+			return nil, errorf(path, "Ref must be a string")
+		}
+		return e.ref(ref, path)
+	case "Fn::Sub":
+		return e.sub(arg, path)
+	case "Fn::Join":
+		return e.join(arg, path)
+	case "Fn::Select":
+		return e.selectItem(arg, path)
+	case "Fn::Split":
+		return e.split(arg, path)
+	case "Fn::FindInMap":
+		return e.findInMap(arg, path)
+	case "Fn::If":
+		return e.ifFunction(arg, path)
+	case "Fn::Base64":
+		return e.base64(arg, path)
+	case "Fn::Cidr":
+		return e.cidr(arg, path)
+	case "Fn::GetAZs":
+		return e.getAZs(arg, path)
+	case "Fn::Length":
+		return e.length(arg, path)
+	case "Fn::ToJsonString":
+		return e.toJSONString(arg, path)
+	case "Fn::Equals":
+		return e.equals(arg, path)
+	case "Fn::And", "Fn::Or":
+		return e.andOr(name, arg, path)
+	case "Fn::Not":
+		return e.not(arg, path)
+	case "Fn::GetAtt":
+		return Unknown{Reason: fmt.Sprintf("Fn::GetAtt %s is only known after deployment", getAttName(arg))}, nil
+	case "Fn::ImportValue":
+		return Unknown{Reason: "Fn::ImportValue is only known after deployment"}, nil
+	}
+	return Unknown{Reason: name + " is not supported by the evaluator"}, nil
+}
+
+// ref resolves a Ref to a pseudo parameter, parameter or resource.
+func (e *Evaluator) ref(name string, path []string) (any, error) {
+	if strings.HasPrefix(name, "AWS::") {
+		return e.pseudoParameter(name, path)
+	}
+	if param, ok := e.tmpl.Parameters[name]; ok {
+		return e.parameter(name, param), nil
+	}
+	if _, ok := e.tmpl.Resources[name]; ok {
+		return Unknown{Reason: fmt.Sprintf("Ref to resource '%s' is only known after deployment", name)}, nil
+	}
+	return nil, errorf(path, "Ref to undefined parameter or resource '%s'", name)
+}
+
+func (e *Evaluator) pseudoParameter(name string, path []string) (any, error) {
+	known := func(value, option string) any {
+		if value == "" {
+			return Unknown{Reason: fmt.Sprintf("%s is not known: no %s was given", name, option)}
+		}
+		return value
+	}
+	switch name {
+	case "AWS::NoValue":
+		return NoValue, nil
+	case "AWS::Region":
+		return known(e.opts.Region, "region"), nil
+	case "AWS::AccountId":
+		return known(e.opts.AccountID, "account"), nil
+	case "AWS::Partition":
+		return known(e.opts.Partition, "partition"), nil
+	case "AWS::StackName":
+		return known(e.opts.StackName, "stack name"), nil
+	case "AWS::URLSuffix":
+		if e.opts.Region == "" {
+			return known("", "region"), nil
+		}
+		return regions.URLSuffix(e.opts.Region), nil
+	case "AWS::StackId", "AWS::NotificationARNs":
+		return Unknown{Reason: name + " is only known after deployment"}, nil
+	}
+	return nil, errorf(path, "unknown pseudo parameter '%s'", name)
+}
+
+// parameter returns the value of a parameter: a string, or a list of
+// strings for list types.
+func (e *Evaluator) parameter(name string, param *template.Parameter) any {
+	if strings.HasPrefix(param.Type, "AWS::SSM::Parameter::Value<") {
+		return Unknown{Reason: fmt.Sprintf("parameter '%s' is read from Systems Manager at deployment", name)}
+	}
+
+	value, ok := e.opts.Parameters[name]
+	if !ok && e.opts.UseDefaults && param.Default != nil {
+		if list, isList := param.Default.([]any); isList {
+			var items []string
+			for _, item := range list {
+				s, _ := ScalarString(item)
+				items = append(items, s)
+			}
+			value, ok = strings.Join(items, ","), true
+		} else {
+			value, ok = ScalarString(param.Default)
+		}
+	}
+	if !ok {
+		return Unknown{Reason: fmt.Sprintf("parameter '%s' has no value", name)}
+	}
+
+	if param.Type == "CommaDelimitedList" || strings.HasPrefix(param.Type, "List<") {
+		items := strings.Split(value, ",")
+		list := make([]any, len(items))
+		for i, item := range items {
+			list[i] = strings.TrimSpace(item)
+		}
+		return list
+	}
+	return value
+}
+
+// isFunction reports whether a mapping key names an intrinsic function.
+func isFunction(key string) bool {
+	return key == "Ref" || strings.HasPrefix(key, "Fn::")
+}
+
+// getAttName formats the target of an Fn::GetAtt for messages.
+func getAttName(arg any) string {
+	switch val := arg.(type) {
+	case string:
+		return val
+	case []any:
+		var parts []string
+		for _, part := range val {
+			s, _ := part.(string)
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ".")
+	}
+	return ""
+}
+
+// ScalarString converts a string, number or boolean to the string
+// CloudFormation would use.
+func ScalarString(v any) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return val, true
+	case int:
+		return strconv.Itoa(val), true
+	case int64:
+		return strconv.FormatInt(val, 10), true
+	case uint64:
+		return strconv.FormatUint(val, 10), true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(val), true
+	}
+	return "", false
+}
+
+// integer converts a number, or a string holding one, to an int.
+func integer(v any) (int, bool) {
+	switch val := v.(type) {
+	case int:
+		return val, true
+	case int64:
+		return int(val), true
+	case uint64:
+		return int(val), true
+	case float64:
+		if val == float64(int(val)) {
+			return int(val), true
+		}
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(val))
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func errorf(path []string, format string, args ...any) *Error {
+	return &Error{Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+// AppendPath returns a copy of path with elems appended, so paths built
+// while walking a template do not share backing arrays.
+func AppendPath(path []string, elems ...string) []string {
+	return append(append([]string(nil), path...), elems...)
+}
@@ -0,0 +1,205 @@
+package eval
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+const testTemplate = `
+Parameters:
+  Env:
+    Type: String
+    Default: dev
+  Subnets:
+    Type: CommaDelimitedList
+    Default: "subnet-a, subnet-b"
+  Size:
+    Type: Number
+    Default: 3
+  Ami:
+    Type: AWS::SSM::Parameter::Value<AWS::EC2::Image::Id>
+    Default: /aws/service/ami
+  Vpc:
+    Type: AWS::EC2::VPC::Id
+Mappings:
+  Sizes:
+    prod:
+      Instance: m5.large
+    dev:
+      Instance: t3.micro
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsDev: !Not [!Condition IsProd]
+  InEast: !Equals [!Ref "AWS::Region", us-east-1]
+  ProdInEast: !And [!Condition IsProd, !Condition InEast]
+  ProdOrEast: !Or [!Condition IsProd, !Condition InEast]
+  Loop: !Not [!Condition Loop]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+`
+
+func parse(t *testing.T, yaml string) *template.Template {
+	t.Helper()
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	return tmpl
+}
+
+func TestEvaluate_Refs(t *testing.T) {
+	tmpl := parse(t, testTemplate)
+	e := New(tmpl, Options{
+		Parameters:  map[string]string{"Env": "prod", "Vpc": "vpc-1"},
+		UseDefaults: true,
+		Region:      "cn-north-1",
+		AccountID:   "123456789012",
+	})
+
+	tests := []struct {
+		ref  string
+		want any
+	}{
+		{"Env", "prod"},
+		{"Vpc", "vpc-1"},
+		{"Size", "3"},
+		{"Subnets", []any{"subnet-a", "subnet-b"}},
+		{"AWS::Region", "cn-north-1"},
+		{"AWS::Partition", "aws-cn"},
+		{"AWS::URLSuffix", "amazonaws.com.cn"},
+		{"AWS::AccountId", "123456789012"},
+	}
+	for _, tt := range tests {
+		got, err := e.Evaluate(map[string]any{"Ref": tt.ref}, nil)
+		if err != nil {
+			t.Errorf("Ref %s: unexpected error %v", tt.ref, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Ref %s = %#v, want %#v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluate_Unknown(t *testing.T) {
+	tmpl := parse(t, testTemplate)
+	e := New(tmpl, Options{})
+
+	tests := []struct {
+		value  any
+		reason string
+	}{
+		{map[string]any{"Ref": "Env"}, "parameter 'Env' has no value"},
+		{map[string]any{"Ref": "Ami"}, "read from Systems Manager"},
+		{map[string]any{"Ref": "Bucket"}, "Ref to resource 'Bucket'"},
+		{map[string]any{"Ref": "AWS::Region"}, "no region was given"},
+		{map[string]any{"Ref": "AWS::StackId"}, "only known after deployment"},
+		{map[string]any{"Fn::GetAtt": []any{"Bucket", "Arn"}}, "Fn::GetAtt Bucket.Arn"},
+		{map[string]any{"Fn::ImportValue": "shared-vpc"}, "Fn::ImportValue"},
+		{map[string]any{"Fn::Transform": map[string]any{}}, "not supported"},
+		{map[string]any{"Fn::Join": []any{"-", []any{"a", map[string]any{"Ref": "Bucket"}}}}, "Ref to resource 'Bucket'"},
+		{map[string]any{"Fn::If": []any{"IsProd", "a", "b"}}, "parameter 'Env' has no value"},
+	}
+	for _, tt := range tests {
+		got, err := e.Evaluate(tt.value, nil)
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", tt.value, err)
+			continue
+		}
+		u, ok := got.(Unknown)
+		if !ok || !strings.Contains(u.Reason, tt.reason) {
+			t.Errorf("%v = %#v, want Unknown containing %q", tt.value, got, tt.reason)
+		}
+	}
+}
+
+func TestEvaluate_Structure(t *testing.T) {
+	tmpl := parse(t, testTemplate)
+	e := New(tmpl, Options{Parameters: map[string]string{"Env": "prod"}})
+
+	value := map[string]any{
+		"Name":     map[string]any{"Fn::Sub": "app-${Env}"},
+		"Optional": map[string]any{"Ref": "AWS::NoValue"},
+		"Tags": []any{
+			map[string]any{"Key": "Env", "Value": map[string]any{"Ref": "Env"}},
+			map[string]any{"Ref": "AWS::NoValue"},
+		},
+		"Arn": map[string]any{"Fn::GetAtt": "Bucket.Arn"},
+	}
+	got, err := e.Evaluate(value, []string{"Resources", "Bucket", "Properties"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"Name": "app-prod",
+		"Tags": []any{map[string]any{"Key": "Env", "Value": "prod"}},
+		"Arn":  Unknown{Reason: "Fn::GetAtt Bucket.Arn is only known after deployment"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %#v, got %#v", want, got)
+	}
+	if IsKnown(got) {
+		t.Error("Expected IsKnown to find the Unknown attribute")
+	}
+	if !IsKnown(got.(map[string]any)["Tags"]) {
+		t.Error("Expected the tags to be known")
+	}
+}
+
+func TestCondition(t *testing.T) {
+	tmpl := parse(t, testTemplate)
+
+	tests := []struct {
+		name      string
+		opts      Options
+		condition string
+		result    bool
+		known     bool
+	}{
+		{"equals", Options{Parameters: map[string]string{"Env": "prod"}}, "IsProd", true, true},
+		{"not", Options{Parameters: map[string]string{"Env": "prod"}}, "IsDev", false, true},
+		{"defaults", Options{UseDefaults: true}, "IsProd", false, true},
+		{"unknown parameter", Options{}, "IsProd", false, false},
+		{"and decided by false operand", Options{Parameters: map[string]string{"Env": "dev"}}, "ProdInEast", false, true},
+		{"and with unknown operand", Options{Parameters: map[string]string{"Env": "prod"}}, "ProdInEast", false, false},
+		{"and", Options{Parameters: map[string]string{"Env": "prod"}, Region: "us-east-1"}, "ProdInEast", true, true},
+		{"or decided by true operand", Options{Parameters: map[string]string{"Env": "prod"}}, "ProdOrEast", true, true},
+		{"or with unknown operand", Options{Parameters: map[string]string{"Env": "dev"}}, "ProdOrEast", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, known, err := New(tmpl, tt.opts).Condition(tt.condition)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.result || known != tt.known {
+				t.Errorf("Condition(%s) = %v, %v; want %v, %v", tt.condition, result, known, tt.result, tt.known)
+			}
+		})
+	}
+}
+
+func TestCondition_Errors(t *testing.T) {
+	tmpl := parse(t, testTemplate)
+	e := New(tmpl, Options{})
+
+	_, _, err := e.Condition("Loop")
+	if err == nil || !strings.Contains(err.Error(), "condition 'Loop' depends on itself") {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+	_, _, err = e.Condition("Missing")
+	if err == nil || !strings.Contains(err.Error(), "condition 'Missing' is not defined") {
+		t.Errorf("Expected an undefined condition error, got %v", err)
+	}
+
+	_, err = e.Evaluate(map[string]any{"Fn::If": []any{"Missing", "a", "b"}}, []string{"Outputs", "Out", "Value"})
+	var evalErr *Error
+	if !errors.As(err, &evalErr) || !reflect.DeepEqual(evalErr.Path, []string{"Outputs", "Out", "Value", "Fn::If", "[0]"}) {
+		t.Errorf("Expected an error at the condition name, got %v", err)
+	}
+}
@@ -0,0 +1,382 @@
+package eval
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/regions"
+)
+
+// sub evaluates Fn::Sub, in its string and [string, variables] forms.
+func (e *Evaluator) sub(arg any, path []string) (any, error) {
+	text, ok := arg.(string)
+	var vars map[string]any
+	if list, isList := arg.([]any); isList && len(list) == 2 {
+		text, ok = list[0].(string)
+		vars, _ = list[1].(map[string]any)
+		ok = ok && vars != nil
+	}
+	if !ok {
+		return nil, errorf(path, "Fn::Sub must be a string or a list of a string and a mapping of variables")
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "${")
+		if start < 0 {
+			b.WriteString(text)
+			break
+		}
+		b.WriteString(text[:start])
+		text = text[start+2:]
+		if strings.HasPrefix(text, "!") {
+			// ${!Literal} is written as ${Literal}
+			b.WriteString("${")
+			text = text[1:]
+			continue
+		}
+		end := strings.Index(text, "}")
+		if end < 0 {
+			b.WriteString("${" + text)
+			break
+		}
+		name := strings.TrimSpace(text[:end])
+		text = text[end+1:]
+
+		var value any
+		var err error
+		if v, ok := vars[name]; ok {
+			value, err = e.eval(v, AppendPath(path, "[1]", name))
+		} else if strings.Contains(name, ".") {
+			value = Unknown{Reason: fmt.Sprintf("Fn::GetAtt %s is only known after deployment", name)}
+		} else {
+			value, err = e.ref(name, path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := value.(Unknown); ok {
+			return u, nil
+		}
+		s, ok := ScalarString(value)
+		if !ok {
+			return nil, errorf(path, "Fn::Sub variable '%s' must be a string", name)
+		}
+		b.WriteString(s)
+	}
+	return b.String(), nil
+}
+
+// join evaluates Fn::Join: [delimiter, list].
+func (e *Evaluator) join(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) != 2 {
+		return nil, errorf(path, "Fn::Join must be a list of a delimiter and a list of values")
+	}
+	delimiter, ok := args[0].(string)
+	if !ok {
+		return nil, errorf(AppendPath(path, "[0]"), "Fn::Join delimiter must be a string")
+	}
+	items, err := e.list(args[1], AppendPath(path, "[1]"), "Fn::Join")
+	if err != nil {
+		return nil, err
+	}
+	list, ok := items.([]any)
+	if !ok {
+		return items, nil
+	}
+
+	parts := make([]string, 0, len(list))
+	for _, item := range list {
+		if u, ok := item.(Unknown); ok {
+			return u, nil
+		}
+		s, ok := ScalarString(item)
+		if !ok {
+			return nil, errorf(AppendPath(path, "[1]"), "Fn::Join list items must be strings")
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, delimiter), nil
+}
+
+// selectItem evaluates Fn::Select: [index, list].
+func (e *Evaluator) selectItem(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) != 2 {
+		return nil, errorf(path, "Fn::Select must be a list of an index and a list of values")
+	}
+	index, err := e.eval(args[0], AppendPath(path, "[0]"))
+	if err != nil {
+		return nil, err
+	}
+	if u, ok := index.(Unknown); ok {
+		return u, nil
+	}
+	i, ok := integer(index)
+	if !ok {
+		return nil, errorf(AppendPath(path, "[0]"), "Fn::Select index must be an integer")
+	}
+	items, err := e.list(args[1], AppendPath(path, "[1]"), "Fn::Select")
+	if err != nil {
+		return nil, err
+	}
+	list, ok := items.([]any)
+	if !ok {
+		return items, nil
+	}
+	if i < 0 || i >= len(list) {
+		return nil, errorf(path, "Fn::Select index %d is out of range for a list of %d items", i, len(list))
+	}
+	return list[i], nil
+}
+
+// split evaluates Fn::Split: [delimiter, string].
+func (e *Evaluator) split(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) != 2 {
+		return nil, errorf(path, "Fn::Split must be a list of a delimiter and a string")
+	}
+	delimiter, ok := args[0].(string)
+	if !ok {
+		return nil, errorf(AppendPath(path, "[0]"), "Fn::Split delimiter must be a string")
+	}
+	source, err := e.eval(args[1], AppendPath(path, "[1]"))
+	if err != nil {
+		return nil, err
+	}
+	if u, ok := source.(Unknown); ok {
+		return u, nil
+	}
+	s, ok := ScalarString(source)
+	if !ok {
+		return nil, errorf(AppendPath(path, "[1]"), "Fn::Split source must be a string")
+	}
+	parts := strings.Split(s, delimiter)
+	list := make([]any, len(parts))
+	for i, part := range parts {
+		list[i] = part
+	}
+	return list, nil
+}
+
+// findInMap evaluates Fn::FindInMap: [mapping, top-level key, second-level
+// key], with an optional {DefaultValue: value} fourth item.
+func (e *Evaluator) findInMap(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) < 3 || len(args) > 4 {
+		return nil, errorf(path, "Fn::FindInMap must be a list of a mapping name, a top-level key and a second-level key")
+	}
+	keys := make([]string, 3)
+	for i := range keys {
+		itemPath := AppendPath(path, fmt.Sprintf("[%d]", i))
+		key, err := e.eval(args[i], itemPath)
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := key.(Unknown); ok {
+			return u, nil
+		}
+		if keys[i], ok = ScalarString(key); !ok {
+			return nil, errorf(itemPath, "Fn::FindInMap keys must be strings")
+		}
+	}
+
+	var fallback any
+	hasDefault := false
+	if len(args) == 4 {
+		options, ok := args[3].(map[string]any)
+		fallback, hasDefault = options["DefaultValue"]
+		if !ok || !hasDefault {
+			return nil, errorf(AppendPath(path, "[3]"), "Fn::FindInMap options must be a mapping with DefaultValue")
+		}
+	}
+
+	mapping, ok := e.tmpl.Mappings[keys[0]]
+	if !ok {
+		return nil, errorf(AppendPath(path, "[0]"), "mapping '%s' is not defined", keys[0])
+	}
+	value, ok := mapping.Values[keys[1]][keys[2]]
+	if !ok {
+		if hasDefault {
+			return e.eval(fallback, AppendPath(path, "[3]", "DefaultValue"))
+		}
+		return nil, errorf(path, "mapping '%s' has no value for keys '%s' and '%s'", keys[0], keys[1], keys[2])
+	}
+	return e.eval(value, []string{"Mappings", keys[0], keys[1], keys[2]})
+}
+
+// ifFunction evaluates Fn::If: [condition, value if true, value if false].
+// Only the branch that is taken is evaluated.
+func (e *Evaluator) ifFunction(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) != 3 {
+		return nil, errorf(path, "Fn::If must be a list of a condition name and two values")
+	}
+	name, ok := args[0].(string)
+	if !ok {
+		return nil, errorf(AppendPath(path, "[0]"), "Fn::If condition must be a condition name")
+	}
+	result, err := e.condition(name, AppendPath(path, "[0]"))
+	if err != nil {
+		return nil, err
+	}
+	if u, ok := result.(Unknown); ok {
+		return u, nil
+	}
+	if result.(bool) {
+		return e.eval(args[1], AppendPath(path, "[1]"))
+	}
+	return e.eval(args[2], AppendPath(path, "[2]"))
+}
+
+// base64 evaluates Fn::Base64.
+func (e *Evaluator) base64(arg any, path []string) (any, error) {
+	value, err := e.eval(arg, path)
+	if err != nil {
+		return nil, err
+	}
+	if u, ok := value.(Unknown); ok {
+		return u, nil
+	}
+	s, ok := ScalarString(value)
+	if !ok {
+		return nil, errorf(path, "Fn::Base64 value must be a string")
+	}
+	return base64.StdEncoding.EncodeToString([]byte(s)), nil
+}
+
+// cidr evaluates Fn::Cidr: [ip block, count, cidr bits], returning count
+// consecutive subnets of the block with cidr bits host bits each.
+func (e *Evaluator) cidr(arg any, path []string) (any, error) {
+	args, ok := arg.([]any)
+	if !ok || len(args) != 3 {
+		return nil, errorf(path, "Fn::Cidr must be a list of an IP block, a count and a number of subnet bits")
+	}
+	values := make([]any, 3)
+	for i := range args {
+		value, err := e.eval(args[i], AppendPath(path, fmt.Sprintf("[%d]", i)))
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := value.(Unknown); ok {
+			return u, nil
+		}
+		values[i] = value
+	}
+
+	block, _ := values[0].(string)
+	prefix, err := netip.ParsePrefix(block)
+	if err != nil {
+		return nil, errorf(AppendPath(path, "[0]"), "Fn::Cidr IP block '%v' is not a valid CIDR block", values[0])
+	}
+	count, ok := integer(values[1])
+	if !ok || count < 1 || count > 256 {
+		return nil, errorf(AppendPath(path, "[1]"), "Fn::Cidr count must be an integer from 1 to 256")
+	}
+	bits := prefix.Addr().BitLen()
+	hostBits, ok := integer(values[2])
+	if !ok || hostBits < 1 || hostBits > bits {
+		return nil, errorf(AppendPath(path, "[2]"), "Fn::Cidr subnet bits must be an integer from 1 to %d", bits)
+	}
+	subnetLength := bits - hostBits
+	if subnetLength < prefix.Bits() {
+		return nil, errorf(path, "Fn::Cidr cannot carve /%d subnets from %s", subnetLength, block)
+	}
+	if available := subnetLength - prefix.Bits(); available < 9 && count > 1<<available {
+		return nil, errorf(path, "Fn::Cidr cannot carve %d /%d subnets from %s", count, subnetLength, block)
+	}
+
+	start := new(big.Int).SetBytes(prefix.Masked().Addr().AsSlice())
+	step := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+	subnets := make([]any, count)
+	for i := range subnets {
+		raw := start.FillBytes(make([]byte, bits/8))
+		addr, _ := netip.AddrFromSlice(raw)
+		subnets[i] = netip.PrefixFrom(addr, subnetLength).String()
+		start.Add(start, step)
+	}
+	return subnets, nil
+}
+
+// getAZs evaluates Fn::GetAZs. An empty region means the stack's region.
+func (e *Evaluator) getAZs(arg any, path []string) (any, error) {
+	value, err := e.eval(arg, path)
+	if err != nil {
+		return nil, err
+	}
+	if u, ok := value.(Unknown); ok {
+		return u, nil
+	}
+	region, ok := value.(string)
+	if !ok {
+		return nil, errorf(path, "Fn::GetAZs region must be a string")
+	}
+	if region == "" {
+		if e.opts.Region == "" {
+			return Unknown{Reason: "Fn::GetAZs needs the stack's region"}, nil
+		}
+		region = e.opts.Region
+	}
+	zones := regions.AvailabilityZones(region)
+	list := make([]any, len(zones))
+	for i, zone := range zones {
+		list[i] = zone
+	}
+	return list, nil
+}
+
+// length evaluates Fn::Length, the number of items in a list. Items that
+// are not known still count.
+func (e *Evaluator) length(arg any, path []string) (any, error) {
+	value, err := e.list(arg, path, "Fn::Length")
+	if err != nil {
+		return nil, err
+	}
+	if u, ok := value.(Unknown); ok {
+		return u, nil
+	}
+	return len(value.([]any)), nil
+}
+
+// toJSONString evaluates Fn::ToJsonString, which serializes a mapping or a
+// list as compact JSON.
+func (e *Evaluator) toJSONString(arg any, path []string) (any, error) {
+	value, err := e.eval(arg, path)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case map[string]any, []any:
+	case Unknown:
+		return value, nil
+	default:
+		return nil, errorf(path, "Fn::ToJsonString needs a mapping or a list")
+	}
+	if !IsKnown(value) {
+		return Unknown{Reason: "Fn::ToJsonString value is only known after deployment"}, nil
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return nil, errorf(path, "Fn::ToJsonString: %v", err)
+	}
+	return string(text), nil
+}
+
+// list evaluates a value that must be a list, returning the list or an
+// Unknown.
+func (e *Evaluator) list(v any, path []string, function string) (any, error) {
+	value, err := e.eval(v, path)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case []any, Unknown:
+		return value, nil
+	}
+	return nil, errorf(path, "%s needs a list of values", function)
+}
@@ -0,0 +1,169 @@
+package eval
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFunctions(t *testing.T) {
+	tmpl := parse(t, testTemplate)
+	e := New(tmpl, Options{
+		Parameters: map[string]string{"Env": "prod", "Subnets": "a,b,c"},
+		Region:     "us-west-1",
+		AccountID:  "123456789012",
+		StackName:  "app",
+	})
+
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{
+			name:  "sub with pseudo parameters",
+			value: map[string]any{"Fn::Sub": "arn:${AWS::Partition}:s3:::${AWS::StackName}-${AWS::AccountId}"},
+			want:  "arn:aws:s3:::app-123456789012",
+		},
+		{
+			name:  "sub with variables and a literal",
+			value: map[string]any{"Fn::Sub": []any{"${Name}-${!Literal}", map[string]any{"Name": map[string]any{"Ref": "Env"}}}},
+			want:  "prod-${Literal}",
+		},
+		{
+			name:  "join",
+			value: map[string]any{"Fn::Join": []any{",", map[string]any{"Ref": "Subnets"}}},
+			want:  "a,b,c",
+		},
+		{
+			name:  "join with numbers",
+			value: map[string]any{"Fn::Join": []any{":", []any{"port", 443}}},
+			want:  "port:443",
+		},
+		{
+			name:  "select",
+			value: map[string]any{"Fn::Select": []any{"1", map[string]any{"Ref": "Subnets"}}},
+			want:  "b",
+		},
+		{
+			name:  "select known item of a partly unknown list",
+			value: map[string]any{"Fn::Select": []any{0, []any{"a", map[string]any{"Ref": "Bucket"}}}},
+			want:  "a",
+		},
+		{
+			name:  "split",
+			value: map[string]any{"Fn::Split": []any{"-", map[string]any{"Fn::Sub": "${Env}-app"}}},
+			want:  []any{"prod", "app"},
+		},
+		{
+			name:  "find in map",
+			value: map[string]any{"Fn::FindInMap": []any{"Sizes", map[string]any{"Ref": "Env"}, "Instance"}},
+			want:  "m5.large",
+		},
+		{
+			name:  "find in map default",
+			value: map[string]any{"Fn::FindInMap": []any{"Sizes", "test", "Instance", map[string]any{"DefaultValue": "t3.nano"}}},
+			want:  "t3.nano",
+		},
+		{
+			name:  "if",
+			value: map[string]any{"Fn::If": []any{"IsProd", "big", map[string]any{"Fn::GetAtt": "Bucket.Arn"}}},
+			want:  "big",
+		},
+		{
+			name:  "base64",
+			value: map[string]any{"Fn::Base64": map[string]any{"Fn::Sub": "echo ${Env}"}},
+			want:  "ZWNobyBwcm9k",
+		},
+		{
+			name:  "cidr",
+			value: map[string]any{"Fn::Cidr": []any{"10.0.0.0/16", 3, 8}},
+			want:  []any{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"},
+		},
+		{
+			name:  "cidr ipv6",
+			value: map[string]any{"Fn::Cidr": []any{"2001:db8::/56", "2", "64"}},
+			want:  []any{"2001:db8::/64", "2001:db8:0:1::/64"},
+		},
+		{
+			name:  "get azs of the stack region",
+			value: map[string]any{"Fn::GetAZs": ""},
+			want:  []any{"us-west-1a", "us-west-1c"},
+		},
+		{
+			name:  "select from get azs",
+			value: map[string]any{"Fn::Select": []any{0, map[string]any{"Fn::GetAZs": "eu-west-1"}}},
+			want:  "eu-west-1a",
+		},
+		{
+			name:  "length of a partly unknown list",
+			value: map[string]any{"Fn::Length": []any{"a", map[string]any{"Ref": "Bucket"}, map[string]any{"Ref": "AWS::NoValue"}}},
+			want:  2,
+		},
+		{
+			name:  "length of a list parameter",
+			value: map[string]any{"Fn::Length": map[string]any{"Ref": "Subnets"}},
+			want:  3,
+		},
+		{
+			name:  "to json string",
+			value: map[string]any{"Fn::ToJsonString": map[string]any{"env": map[string]any{"Ref": "Env"}, "ports": []any{80, 443}}},
+			want:  `{"env":"prod","ports":[80,443]}`,
+		},
+		{
+			name:  "equals with numbers",
+			value: map[string]any{"Fn::Equals": []any{"3", 3}},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.Evaluate(tt.value, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFunctions_Errors(t *testing.T) {
+	tmpl := parse(t, testTemplate)
+	e := New(tmpl, Options{Parameters: map[string]string{"Env": "test", "Subnets": "a,b"}})
+
+	tests := []struct {
+		name    string
+		value   any
+		message string
+	}{
+		{"undefined ref", map[string]any{"Ref": "Missing"}, "Ref to undefined parameter or resource 'Missing'"},
+		{"unknown pseudo parameter", map[string]any{"Ref": "AWS::Nothing"}, "unknown pseudo parameter"},
+		{"sub with a list variable", map[string]any{"Fn::Sub": "${Subnets}"}, "Fn::Sub variable 'Subnets' must be a string"},
+		{"join without a list", map[string]any{"Fn::Join": []any{",", "a"}}, "Fn::Join needs a list of values"},
+		{"select out of range", map[string]any{"Fn::Select": []any{2, []any{"a", "b"}}}, "index 2 is out of range for a list of 2 items"},
+		{"missing mapping key", map[string]any{"Fn::FindInMap": []any{"Sizes", map[string]any{"Ref": "Env"}, "Instance"}}, "mapping 'Sizes' has no value for keys 'test' and 'Instance'"},
+		{"undefined mapping", map[string]any{"Fn::FindInMap": []any{"Nope", "a", "b"}}, "mapping 'Nope' is not defined"},
+		{"invalid cidr block", map[string]any{"Fn::Cidr": []any{"10.0.0.0", 1, 8}}, "is not a valid CIDR block"},
+		{"cidr subnets too large", map[string]any{"Fn::Cidr": []any{"10.0.0.0/24", 1, 12}}, "cannot carve /20 subnets"},
+		{"too many cidr subnets", map[string]any{"Fn::Cidr": []any{"10.0.0.0/24", 5, 6}}, "cannot carve 5 /26 subnets"},
+		{"and with one condition", map[string]any{"Fn::And": []any{map[string]any{"Condition": "IsProd"}}}, "Fn::And must be a list of 2 to 10 conditions"},
+		{"not with a string", map[string]any{"Fn::Not": []any{"true"}}, "condition must be Fn::Equals"},
+		{"length of a string", map[string]any{"Fn::Length": "abc"}, "Fn::Length needs a list of values"},
+		{"to json string of a string", map[string]any{"Fn::ToJsonString": "abc"}, "Fn::ToJsonString needs a mapping or a list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.Evaluate(tt.value, []string{"Outputs", "Out", "Value"})
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+			if err != nil && !strings.HasPrefix(err.Error(), "Outputs/Out/Value/") {
+				t.Errorf("Expected the error path to start at the value, got %v", err)
+			}
+		})
+	}
+}
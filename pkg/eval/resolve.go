@@ -0,0 +1,430 @@
+package eval
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+	"gopkg.in/yaml.v3"
+)
+
+// evaluatedFunctions are the functions the evaluator implements. When one
+// of them cannot be resolved completely, ResolveTemplate still resolves its
+// arguments, so that !Join ["-", [!Ref Env, !GetAtt Queue.Arn]] becomes
+// !Join ["-", [prod, !GetAtt Queue.Arn]].
+var evaluatedFunctions = map[string]bool{
+	"Fn::Sub":          true,
+	"Fn::Join":         true,
+	"Fn::Select":       true,
+	"Fn::Split":        true,
+	"Fn::FindInMap":    true,
+	"Fn::Base64":       true,
+	"Fn::Cidr":         true,
+	"Fn::GetAZs":       true,
+	"Fn::ImportValue":  true,
+	"Fn::Length":       true,
+	"Fn::ToJsonString": true,
+}
+
+// ResolveTemplate returns the template as CloudFormation would deploy it
+// with the evaluator's options:
+//
+//   - resources and outputs whose condition is false are removed, and the
+//     Condition attribute is dropped from those whose condition is true
+//   - Fn::If with a known condition is replaced by the branch it takes, and
+//     values that are Ref AWS::NoValue are removed
+//   - every other function that can be resolved is replaced by its value;
+//     the rest are kept as written, with their arguments resolved
+//   - parameters, mappings and conditions that are no longer referenced
+//     are removed
+//
+// The nodes of the returned template keep the positions of the source
+// elements they were resolved from, so matches found by linting it point at
+// the source template. Invalid uses of functions are returned as an *Error.
+func (e *Evaluator) ResolveTemplate() (*template.Template, error) {
+	root := e.tmpl.Root
+	if root == nil || root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("template has no top-level mapping")
+	}
+	doc := root.Content[0]
+
+	resolved := *doc
+	resolved.Content = nil
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		if key.Value == "Resources" || key.Value == "Outputs" {
+			section, err := e.resolveSection(value, key.Value)
+			if err != nil {
+				return nil, err
+			}
+			value = section
+		}
+		resolved.Content = append(resolved.Content, key, value)
+	}
+	pruneDefinitions(&resolved)
+
+	resolvedRoot := *root
+	resolvedRoot.Content = []*yaml.Node{&resolved}
+	return e.tmpl.Rebuild(&resolvedRoot)
+}
+
+// resolveSection resolves the resources or outputs of a section, removing
+// those whose condition is false.
+func (e *Evaluator) resolveSection(section *yaml.Node, name string) (*yaml.Node, error) {
+	if section.Kind != yaml.MappingNode {
+		return section, nil
+	}
+	resolved := *section
+	resolved.Content = nil
+	for i := 0; i+1 < len(section.Content); i += 2 {
+		key, value := section.Content[i], section.Content[i+1]
+		entry, err := e.resolveEntry(value, []string{name, key.Value})
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			resolved.Content = append(resolved.Content, key, entry)
+		}
+	}
+	return &resolved, nil
+}
+
+// resolveEntry resolves a resource or output, returning nil when its
+// condition is false.
+func (e *Evaluator) resolveEntry(entry *yaml.Node, path []string) (*yaml.Node, error) {
+	if entry.Kind != yaml.MappingNode {
+		return entry, nil
+	}
+	resolved := *entry
+	resolved.Content = nil
+	for i := 0; i+1 < len(entry.Content); i += 2 {
+		key, value := entry.Content[i], entry.Content[i+1]
+		if key.Value == "Condition" && value.Kind == yaml.ScalarNode {
+			result, err := e.condition(value.Value, AppendPath(path, "Condition"))
+			if err != nil {
+				return nil, err
+			}
+			if b, ok := result.(bool); ok {
+				if !b {
+					return nil, nil
+				}
+				continue
+			}
+			resolved.Content = append(resolved.Content, key, value)
+			continue
+		}
+		attribute, err := e.resolveNode(value, AppendPath(path, key.Value))
+		if err != nil {
+			return nil, err
+		}
+		if attribute != nil {
+			resolved.Content = append(resolved.Content, key, attribute)
+		}
+	}
+	return &resolved, nil
+}
+
+// resolveNode returns a copy of node with its functions resolved, or nil
+// when it is Ref AWS::NoValue. Unchanged nodes are shared with the source.
+func (e *Evaluator) resolveNode(node *yaml.Node, path []string) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return e.resolveNode(node.Alias, path)
+	}
+	if name, arg := FunctionNode(node); name != "" {
+		return e.resolveFunction(node, name, arg, path)
+	}
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode {
+		return node, nil
+	}
+	return e.resolveChildren(node, path)
+}
+
+// resolveChildren resolves the items of a sequence or the values of a
+// mapping, dropping those that are Ref AWS::NoValue.
+func (e *Evaluator) resolveChildren(node *yaml.Node, path []string) (*yaml.Node, error) {
+	resolved := *node
+	resolved.Content = nil
+	if node.Kind == yaml.SequenceNode {
+		for i, item := range node.Content {
+			child, err := e.resolveNode(item, AppendPath(path, fmt.Sprintf("[%d]", i)))
+			if err != nil {
+				return nil, err
+			}
+			if child != nil {
+				resolved.Content = append(resolved.Content, child)
+			}
+		}
+		return &resolved, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		child, err := e.resolveNode(value, AppendPath(path, key.Value))
+		if err != nil {
+			return nil, err
+		}
+		if child != nil {
+			resolved.Content = append(resolved.Content, key, child)
+		}
+	}
+	return &resolved, nil
+}
+
+// resolveFunction resolves a function node. arg is the node holding the
+// function's argument: the node itself for the short form.
+func (e *Evaluator) resolveFunction(node *yaml.Node, name string, arg *yaml.Node, path []string) (*yaml.Node, error) {
+	if name == "Fn::If" && arg.Kind == yaml.SequenceNode && len(arg.Content) == 3 && arg.Content[0].Kind == yaml.ScalarNode {
+		return e.resolveIf(node, arg, AppendPath(path, name))
+	}
+
+	value, err := e.eval(template.DecodeNode(node), path)
+	if err != nil {
+		return nil, err
+	}
+	if value == NoValue {
+		return nil, nil
+	}
+	if IsKnown(value) {
+		return ValueNode(value, node)
+	}
+	if !evaluatedFunctions[name] || arg.Kind == yaml.ScalarNode {
+		return node, nil
+	}
+
+	resolvedArg, err := e.resolveChildren(arg, AppendPath(path, name))
+	if err != nil {
+		return nil, err
+	}
+	return withArgument(node, arg, resolvedArg), nil
+}
+
+// resolveIf replaces an Fn::If with the branch it takes. When the condition
+// is not known both branches are resolved and the function is kept.
+func (e *Evaluator) resolveIf(node, arg *yaml.Node, path []string) (*yaml.Node, error) {
+	result, err := e.condition(arg.Content[0].Value, AppendPath(path, "[0]"))
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := result.(bool); ok {
+		branch := 2
+		if b {
+			branch = 1
+		}
+		return e.resolveNode(arg.Content[branch], AppendPath(path, fmt.Sprintf("[%d]", branch)))
+	}
+
+	resolvedArg := *arg
+	resolvedArg.Content = []*yaml.Node{arg.Content[0]}
+	for i := 1; i <= 2; i++ {
+		branch, err := e.resolveNode(arg.Content[i], AppendPath(path, fmt.Sprintf("[%d]", i)))
+		if err != nil {
+			return nil, err
+		}
+		if branch == nil {
+			// Ref AWS::NoValue stays as written inside a kept Fn::If
+			branch = arg.Content[i]
+		}
+		resolvedArg.Content = append(resolvedArg.Content, branch)
+	}
+	return withArgument(node, arg, &resolvedArg), nil
+}
+
+// FunctionNode returns the name and argument node of an intrinsic function
+// written in short (!Sub) or long ({"Fn::Sub": ...}) form, or "" if node is
+// not a function.
+func FunctionNode(node *yaml.Node) (string, *yaml.Node) {
+	if strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!") {
+		switch tag := strings.TrimPrefix(node.Tag, "!"); tag {
+		case "Ref":
+			return "Ref", node
+		case "Condition":
+			return "", nil
+		default:
+			return "Fn::" + tag, node
+		}
+	}
+	if node.Kind == yaml.MappingNode && len(node.Content) == 2 && isFunction(node.Content[0].Value) {
+		return node.Content[0].Value, node.Content[1]
+	}
+	return "", nil
+}
+
+// withArgument returns a copy of a function node with its argument
+// replaced.
+func withArgument(node, arg, resolvedArg *yaml.Node) *yaml.Node {
+	if node == arg {
+		return resolvedArg
+	}
+	resolved := *node
+	resolved.Content = []*yaml.Node{node.Content[0], resolvedArg}
+	return &resolved
+}
+
+// ValueNode encodes an evaluated value, placing every node at the position
+// of the function it was evaluated from, so that findings in the value
+// point at that function.
+func ValueNode(value any, function *yaml.Node) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return nil, fmt.Errorf("encoding value at line %d: %w", function.Line, err)
+	}
+	setPosition(&node, function.Line, function.Column)
+	return &node, nil
+}
+
+func setPosition(node *yaml.Node, line, column int) {
+	node.Line, node.Column = line, column
+	for _, child := range node.Content {
+		setPosition(child, line, column)
+	}
+}
+
+// definitionSections are the sections pruned to the definitions that are
+// still referenced once a template is resolved.
+var definitionSections = map[string]bool{
+	"Parameters": true,
+	"Mappings":   true,
+	"Conditions": true,
+}
+
+// pruneDefinitions removes the parameters, mappings and conditions of a
+// resolved template that nothing refers to, and sections left empty.
+func pruneDefinitions(doc *yaml.Node) {
+	refs := newReferences()
+	sections := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		if definitionSections[key.Value] {
+			sections[key.Value] = value
+			continue
+		}
+		refs.collect(template.DecodeNode(value))
+	}
+
+	// Conditions that are kept may refer to other conditions, parameters
+	// and mappings
+	if conditions := sections["Conditions"]; conditions != nil && conditions.Kind == yaml.MappingNode {
+		expanded := make(map[string]bool)
+		for changed := true; changed; {
+			changed = false
+			for i := 0; i+1 < len(conditions.Content); i += 2 {
+				name := conditions.Content[i].Value
+				if refs.conditions[name] && !expanded[name] {
+					expanded[name] = true
+					refs.collect(template.DecodeNode(conditions.Content[i+1]))
+					changed = true
+				}
+			}
+		}
+	}
+
+	kept := map[string]map[string]bool{
+		"Parameters": refs.names,
+		"Mappings":   refs.mappings,
+		"Conditions": refs.conditions,
+	}
+	content := doc.Content
+	doc.Content = nil
+	for i := 0; i+1 < len(content); i += 2 {
+		key, value := content[i], content[i+1]
+		if definitionSections[key.Value] && value.Kind == yaml.MappingNode {
+			value = filterMapping(value, kept[key.Value])
+			if len(value.Content) == 0 {
+				continue
+			}
+		}
+		doc.Content = append(doc.Content, key, value)
+	}
+}
+
+// filterMapping returns a copy of a mapping with only the keys in keep.
+func filterMapping(mapping *yaml.Node, keep map[string]bool) *yaml.Node {
+	filtered := *mapping
+	filtered.Content = nil
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if keep[mapping.Content[i].Value] {
+			filtered.Content = append(filtered.Content, mapping.Content[i], mapping.Content[i+1])
+		}
+	}
+	return &filtered
+}
+
+// references collects the names a template refers to.
+type references struct {
+	// names holds the targets of Ref and of Fn::Sub variables
+	names      map[string]bool
+	mappings   map[string]bool
+	conditions map[string]bool
+}
+
+func newReferences() *references {
+	return &references{
+		names:      make(map[string]bool),
+		mappings:   make(map[string]bool),
+		conditions: make(map[string]bool),
+	}
+}
+
+func (r *references) collect(v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, child := range val {
+			switch key {
+			case "Ref":
+				if name, ok := child.(string); ok {
+					r.names[name] = true
+				}
+			case "Condition":
+				if name, ok := child.(string); ok {
+					r.conditions[name] = true
+				}
+			case "Fn::Sub":
+				text, _ := child.(string)
+				if args, ok := child.([]any); ok && len(args) > 0 {
+					text, _ = args[0].(string)
+				}
+				for _, name := range subVariables(text) {
+					r.names[name] = true
+				}
+			case "Fn::FindInMap":
+				if args, ok := child.([]any); ok && len(args) > 0 {
+					if name, ok := args[0].(string); ok {
+						r.mappings[name] = true
+					}
+				}
+			case "Fn::If":
+				if args, ok := child.([]any); ok && len(args) > 0 {
+					if name, ok := args[0].(string); ok {
+						r.conditions[name] = true
+					}
+				}
+			}
+			r.collect(child)
+		}
+	case []any:
+		for _, child := range val {
+			r.collect(child)
+		}
+	}
+}
+
+// subVariables returns the names an Fn::Sub string refers to, leaving out
+// ${!Literal} escapes and Resource.Attribute references.
+func subVariables(text string) []string {
+	var names []string
+	for {
+		start := strings.Index(text, "${")
+		if start < 0 {
+			return names
+		}
+		text = text[start+2:]
+		end := strings.Index(text, "}")
+		if end < 0 {
+			return names
+		}
+		name := strings.TrimSpace(text[:end])
+		text = text[end+1:]
+		if name != "" && !strings.HasPrefix(name, "!") && !strings.Contains(name, ".") {
+			names = append(names, name)
+		}
+	}
+}
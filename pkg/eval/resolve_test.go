@@ -0,0 +1,162 @@
+package eval
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+const resolveTemplate = `Parameters:
+  Env:
+    Type: String
+  Unused:
+    Type: String
+    Default: x
+  QueueName:
+    Type: String
+Mappings:
+  Sizes:
+    prod:
+      Instance: m5.large
+  Other:
+    a:
+      b: c
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  HasQueue: !Not [!Equals [!Ref QueueName, ""]]
+  Never: !Equals [a, b]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub "${Env}-${AWS::Region}"
+      Versioning: !If [IsProd, {Status: Enabled}, !Ref AWS::NoValue]
+      Tags:
+        - Key: size
+          Value: !FindInMap [Sizes, !Ref Env, Instance]
+        - !If [IsProd, !Ref AWS::NoValue, {Key: dev, Value: "true"}]
+  Queue:
+    Type: AWS::SQS::Queue
+    Condition: HasQueue
+    Properties:
+      QueueName: !If [HasQueue, !Ref QueueName, !Ref AWS::NoValue]
+  DevTopic:
+    Type: AWS::SNS::Topic
+    Condition: Never
+Outputs:
+  Arn:
+    Value: !Join ["-", [!Ref Env, !GetAtt Bucket.Arn]]
+  DevOnly:
+    Condition: Never
+    Value: x
+`
+
+func TestResolveTemplate(t *testing.T) {
+	tmpl := parse(t, resolveTemplate)
+	resolved, err := New(tmpl, Options{Parameters: map[string]string{"Env": "prod"}, Region: "eu-west-1"}).ResolveTemplate()
+	if err != nil {
+		t.Fatalf("ResolveTemplate() error = %v", err)
+	}
+
+	bucket := resolved.Resources["Bucket"]
+	if bucket == nil {
+		t.Fatal("Bucket was removed")
+	}
+	want := map[string]any{
+		"BucketName": "prod-eu-west-1",
+		"Versioning": map[string]any{"Status": "Enabled"},
+		"Tags":       []any{map[string]any{"Key": "size", "Value": "m5.large"}},
+	}
+	if !reflect.DeepEqual(bucket.Properties, want) {
+		t.Errorf("Bucket properties = %#v, want %#v", bucket.Properties, want)
+	}
+	if _, ok := resolved.Resources["DevTopic"]; ok {
+		t.Error("DevTopic should be removed: its condition is false")
+	}
+	if _, ok := resolved.Outputs["DevOnly"]; ok {
+		t.Error("DevOnly should be removed: its condition is false")
+	}
+
+	// QueueName has no value, so HasQueue is kept with everything it uses
+	queue := resolved.Resources["Queue"]
+	if queue == nil || queue.Condition != "HasQueue" {
+		t.Fatalf("Queue = %#v, want it kept with its condition", queue)
+	}
+	if _, ok := queue.Properties["QueueName"].(map[string]any)["Fn::If"]; !ok {
+		t.Errorf("Queue QueueName = %#v, want the Fn::If kept", queue.Properties["QueueName"])
+	}
+
+	wantOutput := map[string]any{"Fn::Join": []any{"-", []any{"prod", map[string]any{"Fn::GetAtt": "Bucket.Arn"}}}}
+	if got := resolved.Outputs["Arn"].Value; !reflect.DeepEqual(got, wantOutput) {
+		t.Errorf("Arn output = %#v, want %#v", got, wantOutput)
+	}
+
+	for section, names := range map[string][]string{
+		"Parameters": keys(resolved.Parameters),
+		"Mappings":   keys(resolved.Mappings),
+		"Conditions": keys(resolved.Conditions),
+	} {
+		want := map[string][]string{
+			"Parameters": {"QueueName"},
+			"Mappings":   nil,
+			"Conditions": {"HasQueue"},
+		}[section]
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("%s = %v, want %v", section, names, want)
+		}
+	}
+}
+
+func TestResolveTemplate_Positions(t *testing.T) {
+	tmpl := parse(t, resolveTemplate)
+	resolved, err := New(tmpl, Options{Parameters: map[string]string{"Env": "prod"}}).ResolveTemplate()
+	if err != nil {
+		t.Fatalf("ResolveTemplate() error = %v", err)
+	}
+
+	tests := []struct {
+		path []string
+		line int
+	}{
+		{[]string{"Resources", "Bucket", "Properties", "BucketName"}, 24},
+		{[]string{"Resources", "Bucket", "Properties", "Versioning", "Status"}, 25},
+		{[]string{"Resources", "Bucket", "Properties", "Tags", "[0]", "Value"}, 28},
+		{[]string{"Outputs", "Arn", "Value"}, 40},
+	}
+	for _, tt := range tests {
+		loc := resolved.ResolvePath(tt.path)
+		if loc == nil || loc.Node() == nil {
+			t.Errorf("%v: not found", tt.path)
+			continue
+		}
+		if got := loc.Node().Line; got != tt.line {
+			t.Errorf("%v: line = %d, want %d", tt.path, got, tt.line)
+		}
+	}
+	if line, _ := resolved.SourceLine(24); line != `      BucketName: !Sub "${Env}-${AWS::Region}"` {
+		t.Errorf("SourceLine(24) = %q, want the source line", line)
+	}
+}
+
+func TestResolveTemplate_Errors(t *testing.T) {
+	tmpl := parse(t, resolveTemplate)
+	_, err := New(tmpl, Options{Parameters: map[string]string{"Env": "dev"}}).ResolveTemplate()
+
+	var evalErr *Error
+	if !errors.As(err, &evalErr) {
+		t.Fatalf("ResolveTemplate() error = %v, want an *Error", err)
+	}
+	want := []string{"Resources", "Bucket", "Properties", "Tags", "[0]", "Value", "Fn::FindInMap"}
+	if !reflect.DeepEqual(evalErr.Path, want) {
+		t.Errorf("error path = %v, want %v", evalErr.Path, want)
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,168 @@
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// Diff returns a unified diff between two versions of a file, or "" when
+// they are the same.
+func Diff(filename string, before, after []byte) string {
+	if bytes.Equal(before, after) {
+		return ""
+	}
+	a, b := splitLines(before), splitLines(after)
+	ops := diffLines(a, b)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", filename, filename)
+	for _, h := range hunks(ops) {
+		aStart, aCount, bStart, bCount := 0, 0, 0, 0
+		for i, o := range ops[h.start:h.end] {
+			if i == 0 {
+				aStart, bStart = o.aLine, o.bLine
+			}
+			switch o.kind {
+			case ' ':
+				aCount++
+				bCount++
+			case '-':
+				aCount++
+			case '+':
+				bCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		for _, o := range ops[h.start:h.end] {
+			out.WriteByte(o.kind)
+			out.WriteString(o.text)
+			if !strings.HasSuffix(o.text, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+	return out.String()
+}
+
+// diffOp is a line of an edit script: ' ' keeps a line, '-' deletes it and
+// '+' inserts it. aLine and bLine are the 0-based indexes the line is at,
+// or would be at, in each version.
+type diffOp struct {
+	kind         byte
+	text         string
+	aLine, bLine int
+}
+
+// diffLines returns the shortest edit script turning a into b, using
+// Myers' algorithm.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk back from the end to recover the script
+	var reversed []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, diffOp{kind: ' ', text: a[x], aLine: x, bLine: y})
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, diffOp{kind: '+', text: b[y], aLine: x, bLine: y})
+		} else {
+			x--
+			reversed = append(reversed, diffOp{kind: '-', text: a[x], aLine: x, bLine: y})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversed = append(reversed, diffOp{kind: ' ', text: a[x], aLine: x, bLine: y})
+	}
+
+	ops := make([]diffOp, len(reversed))
+	for i, o := range reversed {
+		ops[len(ops)-1-i] = o
+	}
+	return ops
+}
+
+// hunk is a range of ops shown together.
+type hunk struct{ start, end int }
+
+// hunks groups the changes in ops with diffContext lines of context,
+// merging groups whose context would touch.
+func hunks(ops []diffOp) []hunk {
+	var result []hunk
+	for i, o := range ops {
+		if o.kind == ' ' {
+			continue
+		}
+		start, end := max(i-diffContext, 0), min(i+1+diffContext, len(ops))
+		if n := len(result); n > 0 && start <= result[n-1].end {
+			result[n-1].end = end
+			continue
+		}
+		result = append(result, hunk{start, end})
+	}
+	return result
+}
+
+// hunkRange formats one side of a hunk header. An empty range names the
+// line before it.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits text into lines, keeping their terminators.
+func splitLines(text []byte) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(text), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
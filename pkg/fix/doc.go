@@ -0,0 +1,18 @@
+// Package fix applies the fixes that rules attach to lint matches.
+//
+// Rules that implement rules.FixableRule describe fixes as text edits
+// against the original template source, so applying them keeps comments
+// and formatting everywhere else. Fixes are only computed for templates
+// that were linted as written, not for SAM templates after transformation.
+//
+// # Usage
+//
+//	source, _ := os.ReadFile("template.yaml")
+//	matches, _ := linter.LintBytes(source, "template.yaml")
+//	result := fix.Apply(source, matches)
+//	fmt.Print(fix.Diff("template.yaml", source, result.Source))
+//	fmt.Printf("fixed %d, skipped %d\n", result.Fixed, result.Skipped)
+//
+// Fixes that overlap an earlier fix are skipped; linting the fixed source
+// again reports what is left.
+package fix
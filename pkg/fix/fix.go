@@ -0,0 +1,175 @@
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+)
+
+// Result is the outcome of applying fixes to a template.
+type Result struct {
+	// Source is the fixed template source.
+	Source []byte
+
+	// Fixed is the number of matches whose fix was applied. Matches with
+	// the same fix share a single edit.
+	Fixed int
+
+	// Skipped is the number of fixes left out because they overlapped a
+	// fix applied before them or did not fit the source.
+	Skipped int
+}
+
+// edit is a text edit with byte offsets into the source.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// Apply applies the fixes attached to matches to a template's source.
+// Fixes are taken in match order; a fix whose edits overlap an earlier
+// fix's is skipped, so running the linter again may find more to fix.
+// Matches that were suppressed are not fixed. Everything outside the edits,
+// including comments and formatting, is kept.
+func Apply(source []byte, matches []lint.Match) Result {
+	lines := lineOffsets(source)
+	result := Result{Source: source}
+
+	var accepted []edit
+	applied := make(map[string]bool)
+	for _, m := range matches {
+		if m.Fix == nil || m.Suppression != nil {
+			continue
+		}
+		edits, ok := fixEdits(source, lines, m.Fix)
+		if !ok {
+			result.Skipped++
+			continue
+		}
+		key := fmt.Sprint(edits)
+		if applied[key] {
+			result.Fixed++
+			continue
+		}
+		if overlapsAny(edits, accepted) {
+			result.Skipped++
+			continue
+		}
+		applied[key] = true
+		accepted = append(accepted, edits...)
+		result.Fixed++
+	}
+
+	result.Source = applyEdits(source, accepted)
+	return result
+}
+
+// ApplyEdits applies rule edits to a template's source. It returns an error
+// if an edit is outside the source or the edits overlap.
+func ApplyEdits(source []byte, edits []rules.TextEdit) ([]byte, error) {
+	lines := lineOffsets(source)
+	var converted []edit
+	for _, e := range edits {
+		start, ok := offset(source, lines, e.StartLine, e.StartColumn)
+		end, endOK := offset(source, lines, e.EndLine, e.EndColumn)
+		if !ok || !endOK || end < start {
+			return nil, fmt.Errorf("edit %d:%d-%d:%d is outside the source", e.StartLine, e.StartColumn, e.EndLine, e.EndColumn)
+		}
+		next := edit{start: start, end: end, text: e.NewText}
+		if overlapsAny([]edit{next}, converted) {
+			return nil, fmt.Errorf("edit %d:%d-%d:%d overlaps another edit", e.StartLine, e.StartColumn, e.EndLine, e.EndColumn)
+		}
+		converted = append(converted, next)
+	}
+	return applyEdits(source, converted), nil
+}
+
+// fixEdits converts the edits of a fix to byte offsets. It reports false
+// if an edit is outside the source or the fix's edits overlap.
+func fixEdits(source []byte, lines []int, fix *lint.MatchFix) ([]edit, bool) {
+	var edits []edit
+	for _, e := range fix.Edits {
+		start, ok := offset(source, lines, e.Start.LineNumber, e.Start.ColumnNumber)
+		end, endOK := offset(source, lines, e.End.LineNumber, e.End.ColumnNumber)
+		if !ok || !endOK || end < start {
+			return nil, false
+		}
+		next := edit{start: start, end: end, text: e.NewText}
+		if overlapsAny([]edit{next}, edits) {
+			return nil, false
+		}
+		edits = append(edits, next)
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	return edits, len(edits) > 0
+}
+
+// overlapsAny reports whether any of edits overlaps one of others. Two
+// insertions at the same offset overlap since their order is ambiguous.
+func overlapsAny(edits, others []edit) bool {
+	for _, a := range edits {
+		for _, b := range others {
+			if a.start < b.end && b.start < a.end || a.start == b.start {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyEdits applies non-overlapping edits to source.
+func applyEdits(source []byte, edits []edit) []byte {
+	if len(edits) == 0 {
+		return source
+	}
+	sorted := append([]edit(nil), edits...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+
+	var out bytes.Buffer
+	pos := 0
+	for _, e := range sorted {
+		out.Write(source[pos:e.start])
+		out.WriteString(e.text)
+		pos = e.end
+	}
+	out.Write(source[pos:])
+	return out.Bytes()
+}
+
+// lineOffsets returns the byte offset each line of source starts at.
+func lineOffsets(source []byte) []int {
+	offsets := []int{0}
+	for i, b := range source {
+		if b == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// offset converts a 1-based line and character column to a byte offset.
+// Column 1 of the line after the last one is the end of the source.
+func offset(source []byte, lines []int, line, column int) (int, bool) {
+	if column < 1 || line < 1 {
+		return 0, false
+	}
+	if line > len(lines) {
+		return len(source), line == len(lines)+1 && column == 1
+	}
+	pos, end := lines[line-1], len(source)
+	if line < len(lines) {
+		end = lines[line] - 1 // the newline
+	}
+	for c := 1; c < column; c++ {
+		if pos >= end {
+			return 0, false
+		}
+		_, size := utf8.DecodeRune(source[pos:end])
+		pos += size
+	}
+	return pos, true
+}
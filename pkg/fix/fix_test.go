@@ -0,0 +1,144 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+)
+
+const source = `Resources:
+  Fn:
+    Type: AWS::Lambda::Function # runtime below
+    Properties:
+      Runtime: python3.7
+      Handler: café.handler
+`
+
+func matchEdit(startLine, startColumn, endLine, endColumn int, text string) lint.MatchEdit {
+	return lint.MatchEdit{
+		Start:   lint.MatchPosition{LineNumber: startLine, ColumnNumber: startColumn},
+		End:     lint.MatchPosition{LineNumber: endLine, ColumnNumber: endColumn},
+		NewText: text,
+	}
+}
+
+func fixMatch(edits ...lint.MatchEdit) lint.Match {
+	return lint.Match{Fix: &lint.MatchFix{Description: "fix", Edits: edits}}
+}
+
+func TestApply(t *testing.T) {
+	runtime := fixMatch(matchEdit(5, 16, 5, 25, "python3.12"))
+	suppressed := fixMatch(matchEdit(3, 11, 3, 33, "AWS::SNS::Topic"))
+	suppressed.Suppression = &lint.MatchSuppression{Line: 3}
+
+	matches := []lint.Match{
+		{Message: "no fix"},
+		runtime,
+		runtime, // the same fix from another rule
+		fixMatch(matchEdit(5, 7, 5, 20, "Runtime: py")),  // overlaps the runtime fix
+		fixMatch(matchEdit(6, 16, 6, 20, "cafe")),        // columns count characters
+		fixMatch(matchEdit(2, 1, 2, 1, "  # comment\n")), // insertion
+		fixMatch(matchEdit(9, 1, 9, 1, "x")),             // outside the source
+		suppressed,
+	}
+	result := Apply([]byte(source), matches)
+
+	want := `Resources:
+  # comment
+  Fn:
+    Type: AWS::Lambda::Function # runtime below
+    Properties:
+      Runtime: python3.12
+      Handler: cafe.handler
+`
+	if string(result.Source) != want {
+		t.Errorf("Unexpected source:\n%s", result.Source)
+	}
+	if result.Fixed != 4 || result.Skipped != 2 {
+		t.Errorf("Expected 4 fixed and 2 skipped, got %d and %d", result.Fixed, result.Skipped)
+	}
+}
+
+func TestApply_DeleteLines(t *testing.T) {
+	// Deleting the last lines of a file without a trailing newline
+	src := "a\nb\nc"
+	result := Apply([]byte(src), []lint.Match{fixMatch(matchEdit(2, 1, 4, 1, ""))})
+	if string(result.Source) != "a\n" {
+		t.Errorf("Unexpected source %q", result.Source)
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	got, err := ApplyEdits([]byte(source), []rules.TextEdit{
+		{StartLine: 5, StartColumn: 16, EndLine: 5, EndColumn: 25, NewText: "python3.12"},
+		{StartLine: 4, StartColumn: 1, EndLine: 5, EndColumn: 1},
+	})
+	if err != nil {
+		t.Fatalf("ApplyEdits failed: %v", err)
+	}
+	want := `Resources:
+  Fn:
+    Type: AWS::Lambda::Function # runtime below
+      Runtime: python3.12
+      Handler: café.handler
+`
+	if string(got) != want {
+		t.Errorf("Unexpected source:\n%s", got)
+	}
+
+	_, err = ApplyEdits([]byte(source), []rules.TextEdit{
+		{StartLine: 5, StartColumn: 7, EndLine: 5, EndColumn: 20},
+		{StartLine: 5, StartColumn: 16, EndLine: 5, EndColumn: 25},
+	})
+	if err == nil {
+		t.Error("Expected an error for overlapping edits")
+	}
+	if _, err := ApplyEdits([]byte(source), []rules.TextEdit{{StartLine: 5, StartColumn: 80, EndLine: 5, EndColumn: 81}}); err == nil {
+		t.Error("Expected an error for an edit outside the source")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	after := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"
+
+	want := `--- t.yaml
++++ t.yaml
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -9,4 +9,3 @@
+ 9
+ 10
+ 11
+-12
+`
+	if got := Diff("t.yaml", []byte(before), []byte(after)); got != want {
+		t.Errorf("Unexpected diff:\n%s", got)
+	}
+
+	if got := Diff("t.yaml", []byte(before), []byte(before)); got != "" {
+		t.Errorf("Expected no diff for equal content, got:\n%s", got)
+	}
+}
+
+func TestDiff_NoTrailingNewline(t *testing.T) {
+	want := `--- t.yaml
++++ t.yaml
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++c
+\ No newline at end of file
+`
+	if got := Diff("t.yaml", []byte("a\nb"), []byte("a\nc")); got != want {
+		t.Errorf("Unexpected diff:\n%s", got)
+	}
+}
@@ -0,0 +1,44 @@
+// Package langext expands the AWS::LanguageExtensions transform locally,
+// so that the resources it generates can be linted.
+//
+// # Fn::ForEach
+//
+// A loop in Conditions, Resources or Outputs, or in a mapping inside them,
+// is replaced by its output template once for each item of its
+// collection:
+//
+//	Fn::ForEach::Topics:
+//	  - Name
+//	  - [Orders, Invoices]
+//	  - Topic${Name}:
+//	      Type: AWS::SNS::Topic
+//	      Properties:
+//	        TopicName: !Sub "${AWS::StackName}-${Name}"
+//
+// becomes TopicOrders and TopicInvoices. In keys, ${Name} is replaced by
+// the item and &{Name} by the item without its non-alphanumeric
+// characters; in values, Ref Name and ${Name} in Fn::Sub are replaced by
+// the item. Loops may be nested, and an inner loop's collection may use
+// the outer loop's identifier.
+//
+// Collections are evaluated with pkg/eval. Parameters take their Default,
+// so a collection that depends on a parameter without one cannot be
+// expanded; Expand returns an *Error with Unresolved set for it.
+//
+// # Other Functions
+//
+// Once the loops are expanded, Fn::Length, Fn::ToJsonString and
+// Fn::FindInMap with a DefaultValue are replaced by their values where
+// those are known.
+//
+// # Usage
+//
+//	if langext.HasTransform(tmpl) {
+//	    expanded, err := langext.Expand(tmpl, eval.Options{Region: "us-east-1"})
+//	    ...
+//	}
+//
+// The expanded template's nodes keep the positions of the elements they
+// were generated from, so a finding in TopicOrders points at the loop's
+// output template in the source.
+package langext
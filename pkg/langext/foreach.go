@@ -0,0 +1,283 @@
+package langext
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/lex00/cfn-lint-go/pkg/eval"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+	"gopkg.in/yaml.v3"
+)
+
+// bindings holds the values of the identifiers of the loops around a node.
+type bindings map[string]string
+
+// with returns a copy of the bindings with an identifier bound.
+func (b bindings) with(identifier, value string) bindings {
+	next := make(bindings, len(b)+1)
+	for k, v := range b {
+		next[k] = v
+	}
+	next[identifier] = value
+	return next
+}
+
+// expander expands Fn::ForEach loops.
+type expander struct {
+	evaluator *eval.Evaluator
+}
+
+// node returns a copy of node with its loops expanded and the identifiers
+// of the loops around it replaced by their values. path is the node's
+// location in the template as written.
+func (x *expander) node(node *yaml.Node, path []string, b bindings) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return x.node(node.Alias, path, b)
+	}
+	if name, arg := eval.FunctionNode(node); name != "" && len(b) > 0 {
+		switch name {
+		case "Ref":
+			if value, ok := b[arg.Value]; ok && arg.Kind == yaml.ScalarNode {
+				return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Line: node.Line, Column: node.Column}, nil
+			}
+		case "Fn::Sub":
+			return x.sub(node, arg, path, b)
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		return x.mapping(node, path, b)
+	case yaml.SequenceNode:
+		expanded := *node
+		expanded.Content = make([]*yaml.Node, len(node.Content))
+		for i, item := range node.Content {
+			child, err := x.node(item, eval.AppendPath(path, fmt.Sprintf("[%d]", i)), b)
+			if err != nil {
+				return nil, err
+			}
+			expanded.Content[i] = child
+		}
+		return &expanded, nil
+	}
+	return node, nil
+}
+
+// mapping expands the loops of a mapping and replaces the identifiers in
+// its keys.
+func (x *expander) mapping(node *yaml.Node, path []string, b bindings) (*yaml.Node, error) {
+	expanded := *node
+	expanded.Content = nil
+	// origins holds the loop that generated each key, with its path; the
+	// loop is nil for keys written in the template
+	type origin struct {
+		loop *yaml.Node
+		path []string
+	}
+	origins := make(map[string]origin)
+	add := func(key, value *yaml.Node, from origin) error {
+		if other, ok := origins[key.Value]; ok && (from.loop != nil || other.loop != nil) {
+			if from.loop == nil {
+				from = other
+			}
+			return &Error{
+				Path:    from.path,
+				Line:    from.loop.Line,
+				Column:  from.loop.Column,
+				Message: fmt.Sprintf("%s generates key '%s', which is already defined", from.loop.Value, key.Value),
+			}
+		}
+		origins[key.Value] = from
+		expanded.Content = append(expanded.Content, key, value)
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := eval.AppendPath(path, key.Value)
+		if strings.HasPrefix(key.Value, forEachPrefix) {
+			generated, err := x.forEach(key, value, keyPath, b)
+			if err != nil {
+				return nil, err
+			}
+			for j := 0; j+1 < len(generated); j += 2 {
+				if err := add(generated[j], generated[j+1], origin{key, keyPath}); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		child, err := x.node(value, keyPath, b)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(substituteKey(key, b), child, origin{path: keyPath}); err != nil {
+			return nil, err
+		}
+	}
+	return &expanded, nil
+}
+
+// forEach expands a loop, returning the keys and values it generates:
+// the loop's output template once for each item of its collection.
+func (x *expander) forEach(key, value *yaml.Node, path []string, b bindings) ([]*yaml.Node, error) {
+	fail := func(format string, args ...any) *Error {
+		return &Error{Path: path, Line: key.Line, Column: key.Column, Message: fmt.Sprintf(format, args...)}
+	}
+	if value.Kind == yaml.AliasNode && value.Alias != nil {
+		value = value.Alias
+	}
+	if value.Kind != yaml.SequenceNode || len(value.Content) != 3 {
+		return nil, fail("%s must be a list of an identifier, a collection and an output template", key.Value)
+	}
+	identifier, output := value.Content[0], value.Content[2]
+	if identifier.Kind != yaml.ScalarNode || identifier.Value == "" {
+		return nil, fail("%s identifier must be a string", key.Value)
+	}
+	if output.Kind != yaml.MappingNode {
+		return nil, fail("%s output template must be a mapping", key.Value)
+	}
+
+	items, unknown, err := x.collection(value.Content[1], eval.AppendPath(path, "[1]"), b)
+	if err != nil {
+		return nil, fail("%s collection %v", key.Value, err)
+	}
+	if unknown != nil {
+		e := fail("%s collection cannot be resolved before deployment: %s", key.Value, unknown.Reason)
+		e.Unresolved = true
+		return nil, e
+	}
+
+	var generated []*yaml.Node
+	for _, item := range items {
+		body, err := x.mapping(output, eval.AppendPath(path, "[2]"), b.with(identifier.Value, item))
+		if err != nil {
+			return nil, err
+		}
+		generated = append(generated, body.Content...)
+	}
+	return generated, nil
+}
+
+// collection evaluates the collection of a loop to the strings it
+// iterates over. It returns the Unknown standing for the collection, or
+// for one of its items, when they are only known at deployment.
+func (x *expander) collection(node *yaml.Node, path []string, b bindings) ([]string, *eval.Unknown, error) {
+	substituted, err := x.node(node, path, b)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := x.evaluator.Evaluate(template.DecodeNode(substituted), path)
+	if err != nil {
+		var evalErr *eval.Error
+		if errors.As(err, &evalErr) {
+			return nil, nil, errors.New(evalErr.Message)
+		}
+		return nil, nil, err
+	}
+	if u, ok := value.(eval.Unknown); ok {
+		return nil, &u, nil
+	}
+	list, ok := value.([]any)
+	if !ok {
+		return nil, nil, errors.New("must be a list")
+	}
+	items := make([]string, len(list))
+	for i, item := range list {
+		if u, ok := item.(eval.Unknown); ok {
+			return nil, &u, nil
+		}
+		s, ok := eval.ScalarString(item)
+		if !ok {
+			return nil, nil, errors.New("items must be strings")
+		}
+		items[i] = s
+	}
+	return items, nil, nil
+}
+
+// sub replaces the identifiers in the string of an Fn::Sub, and in the
+// values of its variables.
+func (x *expander) sub(node, arg *yaml.Node, path []string, b bindings) (*yaml.Node, error) {
+	switch arg.Kind {
+	case yaml.ScalarNode:
+		text := *arg
+		text.Value = substitute(arg.Value, "${", b, identity)
+		if text.Value != arg.Value && !strings.Contains(text.Value, "${") {
+			// Nothing is left to substitute
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: text.Value, Line: node.Line, Column: node.Column}, nil
+		}
+		return withArgument(node, arg, &text), nil
+	case yaml.SequenceNode:
+		list := *arg
+		list.Content = make([]*yaml.Node, len(arg.Content))
+		for i, item := range arg.Content {
+			if i == 0 && item.Kind == yaml.ScalarNode {
+				text := *item
+				text.Value = substitute(item.Value, "${", b, identity)
+				list.Content[i] = &text
+				continue
+			}
+			child, err := x.node(item, eval.AppendPath(path, "Fn::Sub", fmt.Sprintf("[%d]", i)), b)
+			if err != nil {
+				return nil, err
+			}
+			list.Content[i] = child
+		}
+		return withArgument(node, arg, &list), nil
+	}
+	return node, nil
+}
+
+// substituteKey returns a key with ${Identifier} replaced by the
+// identifier's value and &{Identifier} by the value without its
+// non-alphanumeric characters.
+func substituteKey(key *yaml.Node, b bindings) *yaml.Node {
+	if len(b) == 0 {
+		return key
+	}
+	value := substitute(key.Value, "${", b, identity)
+	value = substitute(value, "&{", b, alphanumeric)
+	if value == key.Value {
+		return key
+	}
+	substituted := *key
+	substituted.Value = value
+	return &substituted
+}
+
+// substitute replaces open+Identifier+"}" in text by the identifier's
+// value, transformed by fn.
+func substitute(text, open string, b bindings, fn func(string) string) string {
+	for identifier, value := range b {
+		text = strings.ReplaceAll(text, open+identifier+"}", fn(value))
+	}
+	return text
+}
+
+func identity(s string) string { return s }
+
+// alphanumeric removes the characters of s that are not letters or
+// digits.
+func alphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// withArgument returns a copy of a function node with its argument
+// replaced.
+func withArgument(node, arg, replaced *yaml.Node) *yaml.Node {
+	if node == arg {
+		return replaced
+	}
+	copied := *node
+	copied.Content = []*yaml.Node{node.Content[0], replaced}
+	return &copied
+}
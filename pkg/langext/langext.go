@@ -0,0 +1,180 @@
+package langext
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/cfn-lint-go/pkg/eval"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+	"gopkg.in/yaml.v3"
+)
+
+// Transform is the AWS::LanguageExtensions transform identifier.
+const Transform = "AWS::LanguageExtensions"
+
+// forEachPrefix starts the key of an Fn::ForEach loop, as in
+// Fn::ForEach::Buckets.
+const forEachPrefix = "Fn::ForEach::"
+
+// expandedSections are the top-level sections whose loops are expanded and
+// whose functions are resolved.
+var expandedSections = map[string]bool{
+	"Conditions": true,
+	"Resources":  true,
+	"Outputs":    true,
+}
+
+// resolvedFunctions are the functions the transform replaces by their
+// values. Fn::FindInMap is only resolved when it has a DefaultValue.
+var resolvedFunctions = map[string]bool{
+	"Fn::Length":       true,
+	"Fn::ToJsonString": true,
+	"Fn::FindInMap":    true,
+}
+
+// HasTransform reports whether a template declares the
+// AWS::LanguageExtensions transform.
+func HasTransform(tmpl *template.Template) bool {
+	if tmpl == nil {
+		return false
+	}
+	switch t := tmpl.Transform.(type) {
+	case string:
+		return t == Transform
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s == Transform {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Error is an Fn::ForEach loop that cannot be expanded.
+type Error struct {
+	// Path locates the loop in the template as written.
+	Path []string
+
+	// Line and Column are the position of the loop's key.
+	Line   int
+	Column int
+
+	Message string
+
+	// Unresolved is set when the loop is valid but its collection depends
+	// on values only known at deployment, such as a parameter without a
+	// Default.
+	Unresolved bool
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return strings.Join(e.Path, "/") + ": " + e.Message
+}
+
+// Expand returns the template as the AWS::LanguageExtensions transform
+// would process it with opts: every Fn::ForEach loop in Conditions,
+// Resources and Outputs is replaced by the entries it generates, and
+// Fn::Length, Fn::ToJsonString and Fn::FindInMap with a DefaultValue are
+// replaced by their values where those are known. Parameters without a
+// value in opts.Parameters take their Default.
+//
+// The nodes of the returned template keep the positions of the source
+// elements they were generated from, so matches found in a generated
+// resource point at the loop's output template. Its Source is tmpl.
+// Loops that cannot be expanded are returned as an *Error.
+func Expand(tmpl *template.Template, opts eval.Options) (*template.Template, error) {
+	root := tmpl.Root
+	if root == nil || root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("template has no top-level mapping")
+	}
+	opts.UseDefaults = true
+
+	x := &expander{evaluator: eval.New(tmpl, opts)}
+	expanded, err := rewriteSections(root, func(node *yaml.Node, path []string) (*yaml.Node, error) {
+		return x.node(node, path, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Functions are resolved once the loops are expanded, so they can refer
+	// to the generated resources and to each other
+	loops, err := tmpl.Rebuild(expanded)
+	if err != nil {
+		return nil, err
+	}
+	r := &resolver{evaluator: eval.New(loops, opts)}
+	resolved, err := rewriteSections(expanded, func(node *yaml.Node, _ []string) (*yaml.Node, error) {
+		return r.node(node)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.Rebuild(resolved)
+}
+
+// rewriteSections returns a copy of a document with the expanded sections
+// rewritten by fn.
+func rewriteSections(root *yaml.Node, fn func(node *yaml.Node, path []string) (*yaml.Node, error)) (*yaml.Node, error) {
+	doc := root.Content[0]
+	rewritten := *doc
+	rewritten.Content = nil
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		if expandedSections[key.Value] {
+			section, err := fn(value, []string{key.Value})
+			if err != nil {
+				return nil, err
+			}
+			value = section
+		}
+		rewritten.Content = append(rewritten.Content, key, value)
+	}
+	rewrittenRoot := *root
+	rewrittenRoot.Content = []*yaml.Node{&rewritten}
+	return &rewrittenRoot, nil
+}
+
+// resolver replaces the functions of the transform by their values.
+type resolver struct {
+	evaluator *eval.Evaluator
+}
+
+// node returns a copy of node with the functions of the transform that
+// can be resolved replaced by their values. Unchanged nodes are shared.
+func (r *resolver) node(node *yaml.Node) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return r.node(node.Alias)
+	}
+	if name, arg := eval.FunctionNode(node); resolvedFunctions[name] {
+		if name != "Fn::FindInMap" || (arg.Kind == yaml.SequenceNode && len(arg.Content) == 4) {
+			// Invalid uses are left for the function rules to report
+			value, err := r.evaluator.Evaluate(template.DecodeNode(node), nil)
+			if err == nil && value != eval.NoValue && eval.IsKnown(value) {
+				return eval.ValueNode(value, node)
+			}
+		}
+	}
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode {
+		return node, nil
+	}
+
+	resolved := *node
+	resolved.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			resolved.Content[i] = child
+			continue
+		}
+		c, err := r.node(child)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Content[i] = c
+	}
+	return &resolved, nil
+}
@@ -0,0 +1,234 @@
+package langext
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/eval"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+const testTemplate = `
+Transform: AWS::LanguageExtensions
+Parameters:
+  Names:
+    Type: CommaDelimitedList
+    Default: "orders,invoices"
+Mappings:
+  Settings:
+    orders:
+      Retention: 7
+    us-east-1:
+      Zones: [a, b]
+Conditions:
+  Fn::ForEach::Envs:
+    - Env
+    - [dev, prod]
+    - Is${Env}: !Equals [!Ref "AWS::StackName", !Ref Env]
+Resources:
+  Fn::ForEach::Topics:
+    - Name
+    - !Ref Names
+    - Topic${Name}:
+        Type: AWS::SNS::Topic
+        Properties:
+          TopicName: !Sub "${AWS::StackName}-${Name}"
+          Retention: !FindInMap [Settings, !Ref Name, Retention, DefaultValue: 1]
+      Fn::ForEach::Zones:
+        - Zone
+        - !FindInMap [Settings, !Ref "AWS::Region", Zones]
+        - Queue&{Name}${Zone}:
+            Type: AWS::SQS::Queue
+            Properties:
+              QueueName: !Sub "${Name}-${Zone}"
+              Count: !Length [1, 2, 3]
+              Policy: !ToJsonString {topic: !Ref Name}
+              Arn: !GetAtt [!Sub "Topic${Name}", TopicArn]
+Outputs:
+  Fn::ForEach::Arns:
+    - Name
+    - !Ref Names
+    - ${Name}Arn:
+        Value: !GetAtt [!Sub "Topic${Name}", TopicArn]
+`
+
+func expand(t *testing.T, yaml string) (*template.Template, error) {
+	t.Helper()
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	return Expand(tmpl, eval.Options{Region: "us-east-1"})
+}
+
+func TestExpand(t *testing.T) {
+	tmpl, err := expand(t, testTemplate)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+
+	if got, want := slices.Sorted(maps.Keys(tmpl.Resources)), []string{"Queueinvoicesa", "Queueinvoicesb", "Queueordersa", "Queueordersb", "Topicinvoices", "Topicorders"}; !slices.Equal(got, want) {
+		t.Errorf("Resources = %v, want %v", got, want)
+	}
+	if got, want := slices.Sorted(maps.Keys(tmpl.Conditions)), []string{"Isdev", "Isprod"}; !slices.Equal(got, want) {
+		t.Errorf("Conditions = %v, want %v", got, want)
+	}
+	if got, want := slices.Sorted(maps.Keys(tmpl.Outputs)), []string{"invoicesArn", "ordersArn"}; !slices.Equal(got, want) {
+		t.Errorf("Outputs = %v, want %v", got, want)
+	}
+
+	topic := tmpl.Resources["Topicorders"].Properties
+	want := map[string]any{
+		"TopicName": map[string]any{"Fn::Sub": "${AWS::StackName}-orders"},
+		"Retention": 7,
+	}
+	if !reflect.DeepEqual(topic, want) {
+		t.Errorf("Topicorders properties = %#v, want %#v", topic, want)
+	}
+	if got := tmpl.Resources["Topicinvoices"].Properties["Retention"]; got != 1 {
+		t.Errorf("Expected the FindInMap DefaultValue, got %#v", got)
+	}
+
+	queue := tmpl.Resources["Queueinvoicesb"].Properties
+	want = map[string]any{
+		"QueueName": "invoices-b",
+		"Count":     3,
+		"Policy":    `{"topic":"invoices"}`,
+		"Arn":       map[string]any{"Fn::GetAtt": []any{"Topicinvoices", "TopicArn"}},
+	}
+	if !reflect.DeepEqual(queue, want) {
+		t.Errorf("Queueinvoicesb properties = %#v, want %#v", queue, want)
+	}
+
+	cond := tmpl.Conditions["Isprod"].Expression
+	if want := map[string]any{"Fn::Equals": []any{map[string]any{"Ref": "AWS::StackName"}, "prod"}}; !reflect.DeepEqual(cond, want) {
+		t.Errorf("Isprod = %#v, want %#v", cond, want)
+	}
+
+	if tmpl.Source == nil || tmpl.Source.Resources["Fn::ForEach::Topics"] == nil {
+		t.Error("Expected the expanded template's Source to be the template as written")
+	}
+}
+
+func TestExpand_Positions(t *testing.T) {
+	tmpl, err := expand(t, testTemplate)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+
+	// Generated resources are placed at the loop's output template
+	for _, name := range []string{"Topicorders", "Topicinvoices"} {
+		if res := tmpl.Resources[name]; res.Node.Line != 23 {
+			t.Errorf("%s is at line %d, want 23", name, res.Node.Line)
+		}
+	}
+	resolved := tmpl.ResolvePath([]string{"Resources", "Queueordersa", "Properties", "Count"})
+	if resolved == nil || resolved.Value.Line != 34 || resolved.Value.Column != 22 {
+		t.Errorf("Expected Count at 34:22, got %+v", resolved)
+	}
+}
+
+func TestExpand_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		resources  string
+		message    string
+		line       int
+		unresolved bool
+	}{
+		{
+			name: "not a list",
+			resources: `
+  Fn::ForEach::Topics: {Name: [a]}`,
+			message: "Fn::ForEach::Topics must be a list of an identifier, a collection and an output template",
+			line:    5,
+		},
+		{
+			name: "collection is not a list",
+			resources: `
+  Fn::ForEach::Topics:
+    - Name
+    - abc
+    - Topic${Name}: {Type: AWS::SNS::Topic}`,
+			message: "Fn::ForEach::Topics collection must be a list",
+			line:    5,
+		},
+		{
+			name: "undefined parameter",
+			resources: `
+  Fn::ForEach::Topics:
+    - Name
+    - !Ref Missing
+    - Topic${Name}: {Type: AWS::SNS::Topic}`,
+			message: "Ref to undefined parameter or resource 'Missing'",
+			line:    5,
+		},
+		{
+			name: "duplicate keys",
+			resources: `
+  Topica:
+    Type: AWS::SNS::Topic
+  Fn::ForEach::Topics:
+    - Name
+    - [a, b]
+    - Topic${Name}: {Type: AWS::SNS::Topic}`,
+			message: "Fn::ForEach::Topics generates key 'Topica', which is already defined",
+			line:    7,
+		},
+		{
+			name: "key without the identifier",
+			resources: `
+  Fn::ForEach::Topics: [Name, [a, b], {Topic: {Type: AWS::SNS::Topic}}]`,
+			message: "generates key 'Topic', which is already defined",
+			line:    5,
+		},
+		{
+			name: "parameter without a default",
+			resources: `
+  Fn::ForEach::Topics:
+    - Name
+    - !Ref NoDefault
+    - Topic${Name}: {Type: AWS::SNS::Topic}`,
+			message:    "collection cannot be resolved before deployment: parameter 'NoDefault' has no value",
+			line:       5,
+			unresolved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expand(t, "Parameters:\n  NoDefault:\n    Type: CommaDelimitedList\nResources:"+tt.resources+"\n")
+			var loopErr *Error
+			if !errors.As(err, &loopErr) {
+				t.Fatalf("Expected an *Error, got %v", err)
+			}
+			if !strings.Contains(loopErr.Message, tt.message) {
+				t.Errorf("Message = %q, want it to contain %q", loopErr.Message, tt.message)
+			}
+			if loopErr.Line != tt.line || loopErr.Unresolved != tt.unresolved {
+				t.Errorf("Got line %d, unresolved %v; want line %d, unresolved %v", loopErr.Line, loopErr.Unresolved, tt.line, tt.unresolved)
+			}
+		})
+	}
+}
+
+func TestHasTransform(t *testing.T) {
+	tests := []struct {
+		transform any
+		want      bool
+	}{
+		{"AWS::LanguageExtensions", true},
+		{[]any{"AWS::LanguageExtensions", "AWS::Serverless-2016-10-31"}, true},
+		{"AWS::Serverless-2016-10-31", false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := HasTransform(&template.Template{Transform: tt.transform}); got != tt.want {
+			t.Errorf("HasTransform(%v) = %v, want %v", tt.transform, got, tt.want)
+		}
+	}
+}
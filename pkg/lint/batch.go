@@ -0,0 +1,97 @@
+package lint
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// FileResult is the outcome of linting a single template file.
+type FileResult struct {
+	// Filename is the path of the linted template.
+	Filename string `json:"Filename"`
+
+	// Parsed reports whether the template could be parsed. When false,
+	// Matches holds a single E0000 parse error.
+	Parsed bool `json:"Parsed"`
+
+	// Transformed reports whether the template was transformed from SAM
+	// before linting.
+	Transformed bool `json:"Transformed"`
+
+	// Duration is the time spent parsing, transforming and linting the file.
+	Duration time.Duration `json:"Duration"`
+
+	// Cached reports whether the result came from Options.Cache.
+	Cached bool `json:"Cached"`
+
+	// Matches are the issues found in the template.
+	Matches []Match `json:"Matches"`
+
+	// Dependencies lists the other files the result was computed from:
+	// the parameter files and local nested stack and included templates,
+	// or for a deployment file the template it names.
+	Dependencies []string `json:"Dependencies,omitempty"`
+
+	// Source is the template content that was linted, or nil if the file
+	// could not be read. Formatters use it to show source context.
+	Source []byte `json:"-"`
+
+	// Err is set when linting failed for a reason that is not reported
+	// as a match.
+	Err error `json:"-"`
+}
+
+// LintFiles lints multiple template files concurrently using a bounded
+// worker pool sized by Options.Jobs. Results are returned in the same order
+// as paths, regardless of how the work was scheduled.
+func (l *Linter) LintFiles(paths []string) []FileResult {
+	return l.LintFilesContext(context.Background(), paths)
+}
+
+// LintFilesContext is LintFiles with cancellation. Files that were not
+// linted before ctx was done have Err set to the context's error.
+func (l *Linter) LintFilesContext(ctx context.Context, paths []string) []FileResult {
+	results := make([]FileResult, len(paths))
+	if len(paths) == 0 {
+		return results
+	}
+
+	jobs := l.jobs()
+	if jobs > len(paths) {
+		jobs = len(paths)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i] = FileResult{Filename: paths[i], Err: err}
+					continue
+				}
+				results[i] = l.lintFile(ctx, paths[i])
+			}
+		}()
+	}
+
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// jobs returns the effective worker pool size.
+func (l *Linter) jobs() int {
+	if l.options.Jobs > 0 {
+		return l.options.Jobs
+	}
+	return runtime.NumCPU()
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+)
+
+func TestLintFiles_Order(t *testing.T) {
+	dir := t.TempDir()
+
+	var paths, contents []string
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("template%02d.yaml", i))
+		content := "Resources:\n  B:\n    Type: AWS::S3::Bucket\n"
+		if i%5 == 0 {
+			content = "Resources: [unclosed"
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+		paths = append(paths, path)
+		contents = append(contents, content)
+	}
+
+	linter := &Linter{
+		options: Options{Jobs: 4},
+		rules:   []rules.Rule{&statusMockRule{baseMockRule{"E9001"}}},
+	}
+	results := linter.LintFiles(paths)
+
+	if len(results) != len(paths) {
+		t.Fatalf("Expected %d results, got %d", len(paths), len(results))
+	}
+
+	for i, result := range results {
+		if result.Filename != paths[i] {
+			t.Errorf("Result %d: expected %s, got %s", i, paths[i], result.Filename)
+		}
+		if result.Err != nil {
+			t.Errorf("Result %d: unexpected error %v", i, result.Err)
+		}
+		if len(result.Matches) != 1 {
+			t.Fatalf("Result %d: expected 1 match, got %d", i, len(result.Matches))
+		}
+
+		if i%5 == 0 {
+			if result.Parsed {
+				t.Errorf("Result %d: expected parse failure", i)
+			}
+			if result.Matches[0].Rule.ID != "E0000" {
+				t.Errorf("Result %d: expected E0000, got %s", i, result.Matches[0].Rule.ID)
+			}
+		} else {
+			if !result.Parsed {
+				t.Errorf("Result %d: expected template to parse", i)
+			}
+			if result.Matches[0].Location.Filename != paths[i] {
+				t.Errorf("Result %d: match filename %s", i, result.Matches[0].Location.Filename)
+			}
+		}
+		if result.Transformed {
+			t.Errorf("Result %d: plain CloudFormation should not be transformed", i)
+		}
+		if string(result.Source) != contents[i] {
+			t.Errorf("Result %d: expected the linted source to be kept", i)
+		}
+	}
+}
+
+func TestLintFiles_Empty(t *testing.T) {
+	linter := New(Options{})
+	if results := linter.LintFiles(nil); len(results) != 0 {
+		t.Errorf("Expected no results, got %d", len(results))
+	}
+}
+
+func TestLintFiles_MissingFile(t *testing.T) {
+	linter := &Linter{options: Options{Jobs: 1}}
+	results := linter.LintFiles([]string{filepath.Join(t.TempDir(), "missing.yaml")})
+
+	if results[0].Parsed {
+		t.Error("Expected missing file to be reported as not parsed")
+	}
+	if len(results[0].Matches) != 1 || results[0].Matches[0].Rule.ID != "E0000" {
+		t.Errorf("Expected a single E0000 match, got %v", results[0].Matches)
+	}
+}
@@ -0,0 +1,283 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lex00/cfn-lint-go/pkg/sam"
+	"github.com/lex00/cfn-lint-go/pkg/schema"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// ResultCache stores lint results between runs. The cache package provides
+// an on-disk implementation.
+type ResultCache interface {
+	// Get returns the data stored under key.
+	Get(key string) ([]byte, bool)
+
+	// Put stores data under key.
+	Put(key string, data []byte) error
+}
+
+// cacheFormat changes whenever the cache key or entry layout changes.
+const cacheFormat = 5
+
+// cacheEntry is the cached outcome of linting one template.
+type cacheEntry struct {
+	Parsed      bool
+	Transformed bool
+	Matches     []Match
+
+	// Dependencies maps the other files the result was computed from, such
+	// as parameter files and local nested stack and included templates, to
+	// the SHA-256 of their content.
+	// The entry is stale once any of them changes.
+	Dependencies map[string]string `json:",omitempty"`
+}
+
+// cacheKey returns the cache key for linting data as filename, or "" when
+// caching is disabled. The key covers the template content, the effective
+// options, the rule set, the build of the linter and its SAM translator,
+// and the content of the CloudFormation schema, which is downloaded at
+// runtime and can be refreshed independently of the build. Caching is
+// disabled when the schema cannot be loaded.
+func (l *Linter) cacheKey(filename string, data []byte) string {
+	if l.options.Cache == nil {
+		return ""
+	}
+	specHash, err := schema.Fingerprint()
+	if err != nil {
+		return ""
+	}
+
+	ruleIDs := make([]string, 0, len(l.rules))
+	for _, r := range l.rules {
+		ruleIDs = append(ruleIDs, r.ID())
+	}
+	sort.Strings(ruleIDs)
+
+	// Options that can change the matches; Jobs and RuleTimeout cannot
+	// (timed out rules are never cached)
+	config, err := json.Marshal(struct {
+		Regions             []string
+		IgnoreRules         []string
+		IncludeRules        []string
+		IncludeExperimental bool
+		MandatoryChecks     []string
+		SeverityOverrides   map[string]string
+		DisableSAMTransform bool
+		SAMTransformOptions *sam.TransformOptions
+		ConfigureRules      map[string]map[string]any
+		IncludeSuppressed   bool
+		ParameterFiles      []string
+		Rules               []string
+	}{
+		l.options.Regions,
+		l.options.IgnoreRules,
+		l.options.IncludeRules,
+		l.options.IncludeExperimental,
+		l.options.MandatoryChecks,
+		l.options.SeverityOverrides,
+		l.options.DisableSAMTransform,
+		l.options.SAMTransformOptions,
+		l.options.ConfigureRules,
+		l.options.IncludeSuppressed,
+		l.options.ParameterFiles,
+		ruleIDs,
+	})
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00%s\x00%s\x00%s\x00", cacheFormat, buildID(), specHash, config, filename)
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedResult returns the cached result for key, if there is a fresh one.
+func (l *Linter) cachedResult(key, filename string) (FileResult, bool) {
+	if key == "" {
+		return FileResult{}, false
+	}
+	data, ok := l.options.Cache.Get(key)
+	if !ok {
+		return FileResult{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return FileResult{}, false
+	}
+	var deps []string
+	for path, hash := range entry.Dependencies {
+		if fileHash(path) != hash {
+			return FileResult{}, false
+		}
+		deps = append(deps, path)
+	}
+	sort.Strings(deps)
+
+	for i := range entry.Matches {
+		restorePath(entry.Matches[i].Location.Path)
+	}
+	return FileResult{
+		Filename:     filename,
+		Parsed:       entry.Parsed,
+		Transformed:  entry.Transformed,
+		Matches:      entry.Matches,
+		Dependencies: deps,
+		Cached:       true,
+	}, true
+}
+
+// storeResult caches a result. Failed runs and results with rule errors
+// (E0002), which may be caused by timeouts, are not cached. Caching is best
+// effort: write errors are ignored.
+func (l *Linter) storeResult(key string, result FileResult) {
+	if key == "" || result.Err != nil {
+		return
+	}
+	for _, m := range result.Matches {
+		if m.Rule.ID == "E0002" {
+			return
+		}
+	}
+
+	var deps map[string]string
+	for _, path := range result.Dependencies {
+		if deps == nil {
+			deps = make(map[string]string)
+		}
+		deps[path] = fileHash(path)
+	}
+
+	data, err := json.Marshal(cacheEntry{
+		Parsed:       result.Parsed,
+		Transformed:  result.Transformed,
+		Matches:      result.Matches,
+		Dependencies: deps,
+	})
+	if err != nil {
+		return
+	}
+	_ = l.options.Cache.Put(key, data)
+}
+
+// restorePath turns list indexes, which JSON decodes as float64, back into
+// ints.
+func restorePath(path []any) {
+	for i, elem := range path {
+		if f, ok := elem.(float64); ok && f == float64(int(f)) {
+			path[i] = int(f)
+		}
+	}
+}
+
+// fileHash returns the hex SHA-256 of a file's content, or "" if it cannot
+// be read.
+func fileHash(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// buildID identifies the running build: module versions from the build
+// info, including the SAM translator dependency, plus the
+// executable's size and modification time so that development builds
+// sharing a version do not reuse each other's results.
+var buildID = sync.OnceValue(func() string {
+	var b strings.Builder
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(&b, "%s@%s", info.Main.Path, info.Main.Version)
+		for _, dep := range info.Deps {
+			fmt.Fprintf(&b, " %s@%s", dep.Path, dep.Version)
+			if dep.Replace != nil {
+				fmt.Fprintf(&b, "=>%s@%s", dep.Replace.Path, dep.Replace.Version)
+			}
+		}
+	}
+	if exe, err := os.Executable(); err == nil {
+		if info, err := os.Stat(exe); err == nil {
+			fmt.Fprintf(&b, " %d %d", info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String()
+})
+
+// childTemplates returns the local files a template includes or deploys as
+// nested stacks: AWS::Include locations, AWS::CloudFormation::Stack
+// TemplateURLs and AWS::Serverless::Application Locations that name an
+// existing file relative to the template. URLs are skipped.
+func childTemplates(tmpl *template.Template, filename string) []string {
+	var locations []string
+	for _, res := range tmpl.Resources {
+		switch res.Type {
+		case "AWS::CloudFormation::Stack":
+			if url, ok := res.Properties["TemplateURL"].(string); ok {
+				locations = append(locations, url)
+			}
+		case "AWS::Serverless::Application":
+			if location, ok := res.Properties["Location"].(string); ok {
+				locations = append(locations, location)
+			}
+		}
+	}
+	if tmpl.Root != nil {
+		locations = append(locations, includeLocations(template.DecodeNode(tmpl.Root))...)
+	}
+
+	seen := make(map[string]bool)
+	var children []string
+	for _, location := range locations {
+		if location == "" || strings.Contains(location, "://") || strings.HasPrefix(location, "s3:") {
+			continue
+		}
+		path := location
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(filename), path)
+		}
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() || seen[path] {
+			continue
+		}
+		seen[path] = true
+		children = append(children, path)
+	}
+	sort.Strings(children)
+	return children
+}
+
+// includeLocations returns the Location of every AWS::Include transform in
+// a value, whether in the Transform section or an Fn::Transform.
+func includeLocations(v any) []string {
+	var locations []string
+	switch val := v.(type) {
+	case map[string]any:
+		if val["Name"] == "AWS::Include" {
+			if params, ok := val["Parameters"].(map[string]any); ok {
+				if location, ok := params["Location"].(string); ok {
+					locations = append(locations, location)
+				}
+			}
+		}
+		for _, child := range val {
+			locations = append(locations, includeLocations(child)...)
+		}
+	case []any:
+		for _, item := range val {
+			locations = append(locations, includeLocations(item)...)
+		}
+	}
+	return locations
+}
@@ -0,0 +1,219 @@
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+)
+
+// memoryCache is a ResultCache backed by a map.
+type memoryCache map[string][]byte
+
+func (c memoryCache) Get(key string) ([]byte, bool) {
+	data, ok := c[key]
+	return data, ok
+}
+
+func (c memoryCache) Put(key string, data []byte) error {
+	c[key] = data
+	return nil
+}
+
+func TestLintCache(t *testing.T) {
+	source := []byte("Resources:\n  First:\n    Type: AWS::S3::Bucket\n")
+	cache := memoryCache{}
+	linter := &Linter{
+		options: Options{Cache: cache},
+		rules:   []rules.Rule{&lineMockRule{baseMockRule{"E9001"}}},
+	}
+
+	first := linter.lintSource(context.Background(), "template.yaml", source)
+	if first.Cached {
+		t.Fatal("Expected first run not to be cached")
+	}
+	if len(cache) != 1 {
+		t.Fatalf("Expected 1 cache entry, got %d", len(cache))
+	}
+
+	second := linter.lintSource(context.Background(), "template.yaml", source)
+	if !second.Cached {
+		t.Fatal("Expected second run to be cached")
+	}
+	if !reflect.DeepEqual(first.Matches, second.Matches) {
+		t.Errorf("Expected cached matches %+v, got %+v", first.Matches, second.Matches)
+	}
+	if string(second.Source) != string(source) {
+		t.Error("Expected cached result to keep the source")
+	}
+
+	changed := linter.lintSource(context.Background(), "template.yaml", append(source, "# edited\n"...))
+	if changed.Cached {
+		t.Error("Expected changed content to miss the cache")
+	}
+
+	reconfigured := &Linter{
+		options: Options{Cache: cache, IgnoreRules: []string{"W"}},
+		rules:   linter.rules,
+	}
+	if reconfigured.lintSource(context.Background(), "template.yaml", source).Cached {
+		t.Error("Expected changed options to miss the cache")
+	}
+}
+
+func TestLintCache_SkipsRuleErrors(t *testing.T) {
+	cache := memoryCache{}
+	linter := &Linter{options: Options{Cache: cache}}
+
+	linter.storeResult("key", FileResult{Matches: []Match{{Rule: MatchRule{ID: "E0002"}}}})
+	if len(cache) != 0 {
+		t.Error("Expected results with rule errors not to be cached")
+	}
+
+	linter.storeResult("key", FileResult{Matches: []Match{{Rule: MatchRule{ID: "E3001"}}}})
+	if len(cache) != 1 {
+		t.Error("Expected result to be cached")
+	}
+}
+
+func TestLintCache_Dependencies(t *testing.T) {
+	dep := filepath.Join(t.TempDir(), "child.yaml")
+	if err := os.WriteFile(dep, []byte("Resources: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := json.Marshal(cacheEntry{
+		Parsed:       true,
+		Matches:      []Match{{Rule: MatchRule{ID: "E3001"}, Location: MatchLocation{Path: []any{"Resources", 0}}}},
+		Dependencies: map[string]string{dep: fileHash(dep)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	linter := &Linter{options: Options{Cache: memoryCache{"key": entry}}}
+
+	result, ok := linter.cachedResult("key", "parent.yaml")
+	if !ok {
+		t.Fatal("Expected a cache hit")
+	}
+	if path := result.Matches[0].Location.Path; path[1] != 0 {
+		t.Errorf("Expected list index 0 restored as int, got %#v", path[1])
+	}
+
+	if err := os.WriteFile(dep, []byte("Resources:\n  Changed: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := linter.cachedResult("key", "parent.yaml"); ok {
+		t.Error("Expected a changed dependency to invalidate the entry")
+	}
+}
+
+func TestLintCache_ChildTemplates(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"network.yaml": "Resources: {}\n",
+		"snippet.yaml": "Type: AWS::SNS::Topic\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	parent := filepath.Join(dir, "parent.yaml")
+	source := []byte(`Resources:
+  Network:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: network.yaml
+  Remote:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: https://example.com/remote.yaml
+  Topic:
+    Fn::Transform:
+      Name: AWS::Include
+      Parameters:
+        Location: snippet.yaml
+`)
+	if err := os.WriteFile(parent, source, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := &Linter{options: Options{Cache: memoryCache{}}}
+	result := linter.lintFile(context.Background(), parent)
+	want := []string{filepath.Join(dir, "network.yaml"), filepath.Join(dir, "snippet.yaml")}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Expected dependencies %v, got %v", want, result.Dependencies)
+	}
+
+	if !linter.lintFile(context.Background(), parent).Cached {
+		t.Fatal("Expected the second run to be cached")
+	}
+	if err := os.WriteFile(want[0], []byte("Resources:\n  Changed: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if linter.lintFile(context.Background(), parent).Cached {
+		t.Error("Expected a changed nested stack template to invalidate the entry")
+	}
+}
+
+func TestLintCache_ParameterFiles(t *testing.T) {
+	params := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(params, []byte(`{"Env": "prod"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	source := []byte("Resources:\n  First:\n    Type: AWS::S3::Bucket\n")
+	linter := &Linter{
+		options: Options{Cache: memoryCache{}, ParameterFiles: []string{params}},
+		rules:   []rules.Rule{&parameterMockRule{baseMockRule{"E9030"}}},
+	}
+
+	linter.lintSource(context.Background(), "template.yaml", source)
+	if !linter.lintSource(context.Background(), "template.yaml", source).Cached {
+		t.Fatal("Expected unchanged parameter files to hit the cache")
+	}
+
+	if err := os.WriteFile(params, []byte(`{"Env": "dev", "Size": "1"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result := linter.lintSource(context.Background(), "template.yaml", source)
+	if result.Cached {
+		t.Fatal("Expected a changed parameter file to miss the cache")
+	}
+	if len(result.Matches) != 2 {
+		t.Errorf("Expected matches for the new values, got %+v", result.Matches)
+	}
+}
+
+func TestLintCache_DeploymentTemplate(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(tmpl, []byte("Resources:\n  First:\n    Type: AWS::S3::Bucket\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	source := []byte("template-file-path: app.yaml\n")
+	deploy := filepath.Join(dir, "deploy.yaml")
+	linter := &Linter{
+		options: Options{Cache: memoryCache{}},
+		rules:   []rules.Rule{&deploymentMockRule{baseMockRule{"E9031"}}},
+	}
+
+	linter.lintSource(context.Background(), deploy, source)
+	cached := linter.lintSource(context.Background(), deploy, source)
+	if !cached.Cached {
+		t.Fatal("Expected an unchanged template to hit the cache")
+	}
+	if !reflect.DeepEqual(cached.Dependencies, []string{tmpl}) {
+		t.Errorf("Expected the cached result to depend on %s, got %v", tmpl, cached.Dependencies)
+	}
+
+	if err := os.WriteFile(tmpl, []byte("Resources: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if linter.lintSource(context.Background(), deploy, source).Cached {
+		t.Fatal("Expected a changed template to miss the cache")
+	}
+}
@@ -17,6 +17,11 @@
 //	    fmt.Printf("[%s] %s\n", m.Rule.ID, m.Message)
 //	}
 //
+// Templates that are not on disk can be linted from memory or a reader:
+//
+//	matches, err := linter.LintBytes(data, "generated.yaml")
+//	matches, err = linter.LintReader(os.Stdin, "-")
+//
 // # Configuration
 //
 // The linter can be configured with Options:
@@ -27,6 +32,10 @@
 //	    IncludeExperimental: true,
 //	})
 //
+// Set Options.Cache (see the cache package) to reuse results for templates
+// that have not changed since they were last linted with the same options
+// and build.
+//
 // # Match Results
 //
 // Each Match contains:
@@ -0,0 +1,70 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Exit code bits for the levels of reported matches, compatible with Python
+// cfn-lint. A run with errors and warnings exits with 2|4 = 6.
+const (
+	ExitCodeError         = 2
+	ExitCodeWarning       = 4
+	ExitCodeInformational = 8
+)
+
+// Fail levels select the lowest match level that contributes to the exit
+// code.
+const (
+	FailOnInformational = "informational"
+	FailOnWarning       = "warning"
+	FailOnError         = "error"
+	FailOnNone          = "none"
+)
+
+// ExitCode returns the exit code for a set of matches: the bitmask of the
+// levels of unsuppressed matches at or above failLevel. An empty failLevel
+// counts every level, like FailOnInformational.
+func ExitCode(matches []Match, failLevel string) (int, error) {
+	mask, err := failMask(failLevel)
+	if err != nil {
+		return 0, err
+	}
+
+	code := 0
+	for _, m := range matches {
+		if m.Suppression != nil {
+			continue
+		}
+		switch m.Level {
+		case "Error":
+			code |= ExitCodeError
+		case "Warning":
+			code |= ExitCodeWarning
+		case "Informational":
+			code |= ExitCodeInformational
+		}
+	}
+	return code & mask, nil
+}
+
+// ValidateFailLevel reports an error for an unknown fail level.
+func ValidateFailLevel(failLevel string) error {
+	_, err := failMask(failLevel)
+	return err
+}
+
+// failMask returns the exit code bits counted at a fail level.
+func failMask(failLevel string) (int, error) {
+	switch strings.ToLower(failLevel) {
+	case "", FailOnInformational, "info":
+		return ExitCodeError | ExitCodeWarning | ExitCodeInformational, nil
+	case FailOnWarning:
+		return ExitCodeError | ExitCodeWarning, nil
+	case FailOnError:
+		return ExitCodeError, nil
+	case FailOnNone:
+		return 0, nil
+	}
+	return 0, fmt.Errorf("invalid fail level %q (valid: informational, warning, error, none)", failLevel)
+}
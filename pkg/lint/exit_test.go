@@ -0,0 +1,55 @@
+package lint
+
+import "testing"
+
+func TestExitCode(t *testing.T) {
+	levels := func(levels ...string) []Match {
+		var matches []Match
+		for _, level := range levels {
+			matches = append(matches, Match{Level: level})
+		}
+		return matches
+	}
+	suppressed := Match{Level: "Error", Suppression: &MatchSuppression{}}
+
+	tests := []struct {
+		name      string
+		matches   []Match
+		failLevel string
+		want      int
+	}{
+		{"no matches", nil, "", 0},
+		{"errors", levels("Error", "Error"), "", 2},
+		{"warnings", levels("Warning"), "", 4},
+		{"informational", levels("Informational"), "", 8},
+		{"all levels", levels("Informational", "Warning", "Error"), "", 14},
+		{"suppressed ignored", []Match{suppressed}, "", 0},
+		{"fail on informational", levels("Informational", "Warning"), "informational", 12},
+		{"fail on warning", levels("Informational", "Warning", "Error"), "warning", 6},
+		{"fail on warning only info", levels("Informational"), "warning", 0},
+		{"fail on error", levels("Warning", "Error"), "error", 2},
+		{"fail on error case-insensitive", levels("Warning", "Error"), "ERROR", 2},
+		{"fail on none", levels("Warning", "Error"), "none", 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ExitCode(tc.matches, tc.failLevel)
+			if err != nil {
+				t.Fatalf("ExitCode failed: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected exit code %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExitCode_InvalidFailLevel(t *testing.T) {
+	if _, err := ExitCode(nil, "critical"); err == nil {
+		t.Error("Expected error for invalid fail level")
+	}
+	if err := ValidateFailLevel("warning"); err != nil {
+		t.Errorf("Expected warning to be valid, got %v", err)
+	}
+}
@@ -2,6 +2,20 @@
 package lint
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lex00/cfn-lint-go/pkg/config"
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
+	"github.com/lex00/cfn-lint-go/pkg/eval"
+	"github.com/lex00/cfn-lint-go/pkg/langext"
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/sam"
 	"github.com/lex00/cfn-lint-go/pkg/template"
@@ -11,25 +25,77 @@ import (
 type Linter struct {
 	options Options
 	rules   []rules.Rule
+
+	// deployment is set while linting a Git sync deployment file
+	deployment *deploymentLint
+}
+
+// deploymentLint is the state of linting a Git sync deployment file.
+type deploymentLint struct {
+	file *deployment.File
+
+	// loaded reports whether the template named by the file was loaded;
+	// parameter rules only run when it was
+	loaded bool
 }
 
 // Options configures the linter.
 type Options struct {
-	// Regions to validate against. Empty means all regions.
+	// Regions to validate against. Empty means us-east-1, and the special
+	// value "ALL_REGIONS" expands to every known region.
 	Regions []string
 
 	// IgnoreRules is a list of rule IDs to skip.
 	IgnoreRules []string
 
+	// IncludeRules is a list of rule IDs to enable even if they are
+	// experimental or opt-in.
+	IncludeRules []string
+
 	// IncludeExperimental enables experimental rules.
 	IncludeExperimental bool
 
+	// MandatoryChecks is a list of rule IDs that always run. They cannot be
+	// ignored by options, template or resource Metadata, or inline comments.
+	MandatoryChecks []string
+
+	// SeverityOverrides maps rule IDs to the level their matches are
+	// reported at: "error", "warning" or "informational".
+	SeverityOverrides map[string]string
+
 	// DisableSAMTransform disables automatic SAM transformation.
 	// When true, SAM templates are linted as-is without transformation.
 	DisableSAMTransform bool
 
 	// SAMTransformOptions configures SAM transformation behavior.
 	SAMTransformOptions *sam.TransformOptions
+
+	// ConfigureRules holds rule options by rule ID, as in the
+	// configure_rules configuration section.
+	ConfigureRules map[string]map[string]any
+
+	// RuleTimeout limits how long a single rule may run on a template.
+	// A rule that exceeds it is reported as E0002. Zero means no limit.
+	RuleTimeout time.Duration
+
+	// IncludeSuppressed keeps matches suppressed by inline comment
+	// directives in the results, with Suppression set, instead of dropping them.
+	IncludeSuppressed bool
+
+	// Jobs is the maximum number of files LintFiles lints concurrently.
+	// Zero means one worker per CPU.
+	Jobs int
+
+	// ParameterFiles lists stack parameter files whose values are checked
+	// against each template by rules that implement rules.ParameterRule.
+	// The files are read on every lint.
+	ParameterFiles []string
+
+	// Cache, when set, stores the results of linting file and in-memory
+	// sources and returns them when the same content is linted again with
+	// the same options and build. Parsed templates passed to Lint are not
+	// cached.
+	Cache ResultCache
 }
 
 // Match represents a linting issue found in a template (Python cfn-lint compatible format).
@@ -38,6 +104,40 @@ type Match struct {
 	Location MatchLocation `json:"Location"`
 	Level    string        `json:"Level"` // "Error", "Warning", "Informational"
 	Message  string        `json:"Message"`
+
+	// Regions is set for matches from region-aware rules and lists the
+	// target regions the issue applies to.
+	Regions []string `json:"Regions,omitempty"`
+
+	// Suppression is set when the match was suppressed by an inline
+	// comment directive. Suppressed matches are only reported when
+	// Options.IncludeSuppressed is set.
+	Suppression *MatchSuppression `json:"Suppression,omitempty"`
+
+	// Fix is set when the rule can fix the match mechanically. See
+	// package fix for applying it.
+	Fix *MatchFix `json:"Fix,omitempty"`
+}
+
+// MatchFix is a change to the template source that resolves a match.
+type MatchFix struct {
+	Description string      `json:"Description"`
+	Edits       []MatchEdit `json:"Edits"`
+}
+
+// MatchEdit replaces the source from Start up to, but not including, End
+// with NewText. Positions are 1-based and columns count characters; an
+// edit that deletes whole lines ends at column 1 of the following line.
+type MatchEdit struct {
+	Start   MatchPosition `json:"Start"`
+	End     MatchPosition `json:"End"`
+	NewText string        `json:"NewText"`
+}
+
+// MatchSuppression describes an inline suppression of a match.
+type MatchSuppression struct {
+	Justification string `json:"Justification,omitempty"`
+	Line          int    `json:"Line"` // line of the suppressing comment
 }
 
 // MatchRule contains rule metadata.
@@ -72,41 +172,337 @@ func New(opts Options) *Linter {
 
 // LintFile lints a CloudFormation template file.
 func (l *Linter) LintFile(path string) ([]Match, error) {
-	tmpl, err := template.ParseFile(path)
+	return l.LintFileContext(context.Background(), path)
+}
+
+// LintFileContext lints a CloudFormation template file. Linting stops with
+// the context's error when ctx is cancelled or its deadline passes.
+func (l *Linter) LintFileContext(ctx context.Context, path string) ([]Match, error) {
+	result := l.lintFile(ctx, path)
+	return result.Matches, result.Err
+}
+
+// LintBytes lints a CloudFormation template held in memory. The filename
+// is only used to report matches and does not have to exist.
+func (l *Linter) LintBytes(data []byte, filename string) ([]Match, error) {
+	return l.LintBytesContext(context.Background(), data, filename)
+}
+
+// LintBytesContext is LintBytes with cancellation.
+func (l *Linter) LintBytesContext(ctx context.Context, data []byte, filename string) ([]Match, error) {
+	result := l.LintBytesResult(ctx, data, filename)
+	return result.Matches, result.Err
+}
+
+// LintBytesResult lints a template held in memory and reports it as
+// LintFiles reports a file, with parse and transform status and duration.
+func (l *Linter) LintBytesResult(ctx context.Context, data []byte, filename string) FileResult {
+	return l.lintSource(ctx, filename, data)
+}
+
+// LintReader lints a CloudFormation template read from r, such as
+// standard input. The filename is only used to report matches.
+func (l *Linter) LintReader(r io.Reader, filename string) ([]Match, error) {
+	return l.LintReaderContext(context.Background(), r, filename)
+}
+
+// LintReaderContext is LintReader with cancellation.
+func (l *Linter) LintReaderContext(ctx context.Context, r io.Reader, filename string) ([]Match, error) {
+	result := l.LintReaderResult(ctx, r, filename)
+	return result.Matches, result.Err
+}
+
+// LintReaderResult lints a template read from r and reports it as
+// LintFiles reports a file. Read errors are returned in Err.
+func (l *Linter) LintReaderResult(ctx context.Context, r io.Reader, filename string) FileResult {
+	start := time.Now()
+	data, err := io.ReadAll(r)
 	if err != nil {
-		return []Match{{
-			Rule: MatchRule{
-				ID:               "E0000",
-				Description:      "Checks that the template can be parsed",
-				ShortDescription: "Template parse error",
-				Source:           "https://github.com/lex00/cfn-lint-go",
-			},
-			Location: MatchLocation{
-				Start:    MatchPosition{LineNumber: 1, ColumnNumber: 1},
-				End:      MatchPosition{LineNumber: 1, ColumnNumber: 1},
-				Path:     []any{},
-				Filename: path,
-			},
-			Level:   "Error",
-			Message: err.Error(),
-		}}, nil
+		return FileResult{Filename: filename, Duration: time.Since(start), Err: fmt.Errorf("reading template: %w", err)}
+	}
+	result := l.LintBytesResult(ctx, data, filename)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// lintFile lints a template file and records how it was processed.
+func (l *Linter) lintFile(ctx context.Context, path string) FileResult {
+	start := time.Now()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("reading file: %w", err)
+		return FileResult{
+			Filename: path,
+			Matches:  l.applySeverity(path, []Match{parseErrorMatch(path, err)}),
+			Duration: time.Since(start),
+		}
+	}
+
+	result := l.lintSource(ctx, path, data)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// lintSource lints template content and records how it was processed.
+func (l *Linter) lintSource(ctx context.Context, filename string, data []byte) FileResult {
+	start := time.Now()
+
+	key := l.cacheKey(filename, data)
+	if result, ok := l.cachedResult(key, filename); ok {
+		result.Source = data
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result := FileResult{Filename: filename, Source: data}
+	if deployment.IsFile(data) {
+		result.Parsed = true
+		result.Matches, result.Dependencies, result.Err = l.lintDeployment(ctx, filename, data)
+	} else if tmpl, err := template.Parse(data); err != nil {
+		result.Matches = l.applySeverity(filename, []Match{parseErrorMatch(filename, err)})
+	} else {
+		tmpl.Filename = filename
+		result.Parsed = true
+		result.Matches, result.Transformed, result.Err = l.lint(ctx, tmpl, filename)
+		result.Dependencies = append(append([]string(nil), l.options.ParameterFiles...), childTemplates(tmpl, filename)...)
+	}
+
+	l.storeResult(key, result)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// lintDeployment lints a Git sync deployment file. Deployment rules check
+// the file itself, and parameter rules check its parameters against the
+// template it names; other rules do not run, since the template is linted
+// on its own. It also returns the template's path, which the result
+// depends on.
+func (l *Linter) lintDeployment(ctx context.Context, filename string, data []byte) ([]Match, []string, error) {
+	file := deployment.ParseFile(filename, data)
+
+	tmpl := &template.Template{}
+	var deps []string
+	loaded := false
+	if path := file.TemplatePath(); path != "" {
+		deps = []string{path}
+		var message string
+		if source, err := os.ReadFile(path); err != nil {
+			message = fmt.Sprintf("template-file-path '%s' cannot be read: %v", file.TemplateFilePath, err)
+		} else if parsed, err := template.Parse(source); err != nil {
+			message = fmt.Sprintf("template-file-path '%s' is not a valid template: %v", file.TemplateFilePath, err)
+		} else {
+			parsed.Filename = path
+			tmpl, loaded = parsed, true
+		}
+		if message != "" {
+			file.Errors = append(file.Errors, deployment.Error{
+				Message: message,
+				Line:    file.TemplateNode.Line,
+				Column:  file.TemplateNode.Column,
+				Path:    []string{"template-file-path"},
+			})
+		}
+	}
+
+	// Configuration in the template's Metadata applies to its deployments
+	if loaded {
+		l = l.withTemplateConfig(tmpl)
+	}
+	l = &Linter{options: l.options, rules: l.rules, deployment: &deploymentLint{file: file, loaded: loaded}}
+
+	matches, err := l.lintCloudFormation(ctx, tmpl, filename, nil)
+	if err != nil {
+		return nil, deps, err
 	}
+	return l.applySeverity(filename, matches), deps, nil
+}
 
-	return l.Lint(tmpl, path)
+// parseErrorMatch reports a template that could not be parsed.
+func parseErrorMatch(filename string, err error) Match {
+	return Match{
+		Rule: MatchRule{
+			ID:               "E0000",
+			Description:      "Checks that the template can be parsed",
+			ShortDescription: "Template parse error",
+			Source:           "https://github.com/lex00/cfn-lint-go",
+		},
+		Location: MatchLocation{
+			Start:    MatchPosition{LineNumber: 1, ColumnNumber: 1},
+			End:      MatchPosition{LineNumber: 1, ColumnNumber: 1},
+			Path:     []any{},
+			Filename: filename,
+		},
+		Level:   "Error",
+		Message: err.Error(),
+	}
 }
 
 // Lint lints a parsed CloudFormation template.
 func (l *Linter) Lint(tmpl *template.Template, filename string) ([]Match, error) {
+	return l.LintContext(context.Background(), tmpl, filename)
+}
+
+// LintContext lints a parsed CloudFormation template. Linting stops with
+// the context's error when ctx is cancelled or its deadline passes: before
+// the next rule, or during a rule when Options.RuleTimeout is set.
+func (l *Linter) LintContext(ctx context.Context, tmpl *template.Template, filename string) ([]Match, error) {
+	matches, _, err := l.lint(ctx, tmpl, filename)
+	return matches, err
+}
+
+// lint lints a parsed template and reports whether it was SAM-transformed.
+func (l *Linter) lint(ctx context.Context, tmpl *template.Template, filename string) ([]Match, bool, error) {
+	// Apply configuration embedded in the template's Metadata
+	l = l.withTemplateConfig(tmpl)
+
+	// The language extensions are expanded before the SAM transform, as
+	// CloudFormation does
+	source := tmpl
+	tmpl, transformErrs := l.expandLanguageExtensions(tmpl, filename)
+
 	// Check if SAM transformation is needed
+	var (
+		matches     []Match
+		transformed bool
+		err         error
+	)
 	if sam.IsSAMTemplate(tmpl) && !l.options.DisableSAMTransform {
-		return l.lintSAM(tmpl, filename)
+		matches, transformed, err = l.lintSAM(ctx, tmpl, filename)
+	} else {
+		matches, err = l.lintCloudFormation(ctx, tmpl, filename, nil)
+	}
+	if err != nil {
+		return nil, transformed, err
+	}
+	matches = append(transformErrs, matches...)
+
+	// Inline directives refer to lines of the original template
+	matches = l.applySuppressions(source, filename, matches)
+	return l.applySeverity(filename, matches), transformed, nil
+}
+
+// applySeverity sets the effective level of each match, applying
+// SeverityOverrides. Invalid override levels are reported as E0003.
+func (l *Linter) applySeverity(filename string, matches []Match) []Match {
+	if len(l.options.SeverityOverrides) == 0 {
+		return matches
+	}
+
+	var errs []Match
+	levels := make(map[string]string)
+	for id, severity := range l.options.SeverityOverrides {
+		level, ok := levelFromSeverity(severity)
+		if !ok {
+			errs = append(errs, configErrorMatch(filename, fmt.Sprintf("invalid severity %q for rule %s (valid: error, warning, informational)", severity, id)))
+			continue
+		}
+		levels[id] = level
+	}
+
+	for i := range matches {
+		if level, ok := levels[matches[i].Rule.ID]; ok {
+			matches[i].Level = level
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Message < errs[j].Message })
+	if l.isIgnored("E0003") {
+		errs = nil
+	}
+	return append(matches, errs...)
+}
+
+// levelFromSeverity converts a configured severity to a match level.
+func levelFromSeverity(severity string) (string, bool) {
+	switch strings.ToLower(severity) {
+	case "error":
+		return "Error", true
+	case "warning":
+		return "Warning", true
+	case "informational", "info":
+		return "Informational", true
+	}
+	return "", false
+}
+
+// applySuppressions drops matches covered by inline comment directives, or
+// marks them as suppressed when suppressed matches are reported. Matches in
+// other files, such as parameter files, are never suppressed.
+func (l *Linter) applySuppressions(tmpl *template.Template, filename string, matches []Match) []Match {
+	if len(tmpl.Suppressions) == 0 {
+		return matches
 	}
 
-	return l.lintCloudFormation(tmpl, filename, nil)
+	filtered := matches[:0]
+	for _, m := range matches {
+		var s *template.Suppression
+		if m.Location.Filename == filename {
+			s = tmpl.SuppressionFor(m.Rule.ID, m.Location.Start.LineNumber)
+		}
+		if s != nil && !l.isMandatory(m.Rule.ID) {
+			if !l.options.IncludeSuppressed {
+				continue
+			}
+			m.Suppression = &MatchSuppression{Justification: s.Reason, Line: s.Line}
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
+// expandLanguageExtensions expands the AWS::LanguageExtensions transform
+// of a template that declares it. The template is expanded once, for the
+// first target region, and that expansion is linted for every region: a
+// loop whose collection depends on AWS::Region, such as a Fn::FindInMap
+// keyed on it, generates the entries of the first region only. A loop
+// that cannot be expanded is reported as E0001 and the template is linted
+// as written; so is a loop whose collection is only known at deployment,
+// without a match.
+func (l *Linter) expandLanguageExtensions(tmpl *template.Template, filename string) (*template.Template, []Match) {
+	if !langext.HasTransform(tmpl) {
+		return tmpl, nil
+	}
+	expanded, err := langext.Expand(tmpl, eval.Options{Region: regions.Expand(l.options.Regions)[0]})
+	if err == nil {
+		return expanded, nil
+	}
+
+	var loopErr *langext.Error
+	if !errors.As(err, &loopErr) {
+		loopErr = &langext.Error{Message: err.Error()}
+	}
+	if loopErr.Unresolved || l.isIgnored("E0001") {
+		return tmpl, nil
+	}
+	line, column := loopErr.Line, loopErr.Column
+	if line == 0 {
+		line, column = 1, 1
+	}
+	path := make([]any, len(loopErr.Path))
+	for i, p := range loopErr.Path {
+		path[i] = p
+	}
+	return tmpl, []Match{{
+		Rule: MatchRule{
+			ID:               "E0001",
+			Description:      "Checks for errors during template transformation (e.g., AWS::Include, AWS::Serverless, AWS::LanguageExtensions).",
+			ShortDescription: "Template transformation error",
+			Source:           "https://github.com/aws-cloudformation/cfn-lint/blob/main/docs/rules.md#E0001",
+		},
+		Location: MatchLocation{
+			Start:    MatchPosition{LineNumber: line, ColumnNumber: column},
+			End:      MatchPosition{LineNumber: line, ColumnNumber: column},
+			Path:     path,
+			Filename: filename,
+		},
+		Level:   "Error",
+		Message: "Error transforming template: " + loopErr.Message,
+	}}
 }
 
 // lintSAM handles SAM template linting with transformation.
-func (l *Linter) lintSAM(tmpl *template.Template, filename string) ([]Match, error) {
+func (l *Linter) lintSAM(ctx context.Context, tmpl *template.Template, filename string) ([]Match, bool, error) {
 	// Transform SAM to CloudFormation
 	result, err := sam.Transform(tmpl, l.options.SAMTransformOptions)
 	if err != nil {
@@ -126,75 +522,477 @@ func (l *Linter) lintSAM(tmpl *template.Template, filename string) ([]Match, err
 			},
 			Level:   "Error",
 			Message: err.Error(),
-		}}, nil
+		}}, false, nil
 	}
 
 	// Lint the transformed template
-	return l.lintCloudFormation(result.Template, filename, result.SourceMap)
+	matches, err := l.lintCloudFormation(ctx, result.Template, filename, result.SourceMap)
+	return matches, true, err
+}
+
+// mergeRegions reports the matches a regional rule found in several
+// regions once, listing every region it was found in.
+func mergeRegions(matches []Match) []Match {
+	index := make(map[string]int, len(matches))
+	out := make([]Match, 0, len(matches))
+	for _, m := range matches {
+		key := fmt.Sprintf("%s\x00%v\x00%d:%d\x00%s", m.Rule.ID, m.Location.Path, m.Location.Start.LineNumber, m.Location.Start.ColumnNumber, m.Message)
+		if i, ok := index[key]; ok {
+			out[i].Regions = append(out[i].Regions, m.Regions...)
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, m)
+	}
+	return out
 }
 
 // lintCloudFormation lints a CloudFormation template with optional source mapping.
-func (l *Linter) lintCloudFormation(tmpl *template.Template, filename string, sourceMap *sam.SourceMap) ([]Match, error) {
-	var matches []Match
+// A rule that panics or exceeds Options.RuleTimeout is reported as E0002 and
+// does not stop the other rules.
+func (l *Linter) lintCloudFormation(ctx context.Context, tmpl *template.Template, filename string, sourceMap *sam.SourceMap) ([]Match, error) {
+	contexts, matches := l.ruleContexts(filename)
+	targetRegions := regions.Expand(l.options.Regions)
+	parameterFiles := l.parameterFiles()
+
+	run := func(rule rules.Rule, region string, match func() []rules.Match) error {
+		rms, err := l.runRule(ctx, func() []rules.Match {
+			rms := match()
+			// Fixes edit the source, so they are only computed for templates
+			// that were linted as written
+			if fixer, ok := rule.(rules.FixableRule); ok && sourceMap == nil && tmpl.Source == nil {
+				for i := range rms {
+					if rms[i].Fix == nil {
+						rms[i].Fix = fixer.Fix(tmpl, rms[i])
+					}
+				}
+			}
+			return rms
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !l.isIgnored("E0002") {
+				matches = append(matches, ruleErrorMatch(filename, rule.ID(), err))
+			}
+			return nil
+		}
+		for _, rm := range rms {
+			if sourceMap == nil && rm.Filename == "" {
+				rm = resolveLocation(tmpl, rm)
+			}
+			matches = append(matches, newMatch(rule, rm, filename, sourceMap, region))
+		}
+		return nil
+	}
 
 	for _, rule := range l.rules {
-		if l.isIgnored(rule.ID()) {
+		if !l.isEnabled(rule) || !l.runsOnFile(rule) {
 			continue
 		}
 
-		ruleMatches := rule.Match(tmpl)
-		for _, rm := range ruleMatches {
-			// Convert []string path to []any for JSON compatibility
-			path := make([]any, len(rm.Path))
-			for i, p := range rm.Path {
-				path[i] = p
-			}
-
-			// Get line/column, potentially mapping back to SAM source
-			line, column := rm.Line, rm.Column
-			if sourceMap != nil && len(rm.Path) >= 2 {
-				// Try to get resource name from path
-				if rm.Path[0] == "Resources" {
-					resourceName := rm.Path[1]
-					mappedLoc := sourceMap.MapError(resourceName, "", rm.Line)
-					if mappedLoc.Line > 0 {
-						line = mappedLoc.Line
-						column = mappedLoc.Column
-					}
+		// Configurable rules run with their validated options; rules whose
+		// configuration is invalid were reported as E0003 and are skipped
+		var ruleCtx *rules.Context
+		if _, ok := rule.(rules.ConfigurableRule); ok {
+			var valid bool
+			if ruleCtx, valid = contexts[rule.ID()]; !valid {
+				continue
+			}
+		}
+
+		deploymentRule, isDeployment := rule.(rules.DeploymentRule)
+		parameterRule, isParameter := rule.(rules.ParameterRule)
+		var err error
+		if isDeployment && l.deployment != nil {
+			err = run(rule, "", func() []rules.Match { return deploymentRule.MatchDeployment(tmpl, l.deployment.file) })
+		}
+		if isParameter {
+			// Parameter file rules run once per parameter file
+			for _, params := range parameterFiles {
+				if err != nil {
+					break
+				}
+				err = run(rule, "", func() []rules.Match { return parameterRule.MatchParameters(tmpl, params) })
+			}
+		}
+		if !isDeployment && !isParameter {
+			// Region-aware rules run once per target region
+			ruleRegions := []string{""}
+			if _, ok := rule.(rules.RegionalRule); ok {
+				ruleRegions = targetRegions
+			}
+			start := len(matches)
+			for _, region := range ruleRegions {
+				if err != nil {
+					break
 				}
+				err = run(rule, region, func() []rules.Match { return matchRule(rule, ruleCtx, tmpl, region) })
 			}
+			if len(ruleRegions) > 1 {
+				matches = append(matches[:start], mergeRegions(matches[start:])...)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			matches = append(matches, Match{
-				Rule: MatchRule{
-					ID:               rule.ID(),
-					Description:      rule.Description(),
-					ShortDescription: rule.ShortDesc(),
-					Source:           rule.Source(),
-				},
-				Location: MatchLocation{
-					Start:    MatchPosition{LineNumber: line, ColumnNumber: column},
-					End:      MatchPosition{LineNumber: line, ColumnNumber: column},
-					Path:     path,
-					Filename: filename,
-				},
-				Level:   levelFromRuleID(rule.ID()),
-				Message: rm.Message,
-			})
+	return l.filterResourceIgnores(tmpl, matches), nil
+}
+
+// matchRule checks a template with a rule for a region, or "" for rules
+// that are not region-aware. Configurable rules get the region in their
+// context.
+func matchRule(rule rules.Rule, ctx *rules.Context, tmpl *template.Template, region string) []rules.Match {
+	if r, ok := rule.(rules.ConfigurableRule); ok {
+		return r.MatchWithContext(ctx.WithRegion(region), tmpl)
+	}
+	if r, ok := rule.(rules.RegionalRule); ok {
+		return r.MatchRegion(tmpl, region)
+	}
+	return rule.Match(tmpl)
+}
+
+// parameterFiles loads the files in Options.ParameterFiles. Files that
+// cannot be read or parsed are returned with their errors, which E0200
+// reports. For a deployment file it returns the file's parameters, as long
+// as its template was loaded.
+func (l *Linter) parameterFiles() []*deployment.Parameters {
+	if l.deployment != nil {
+		if !l.deployment.loaded {
+			return nil
+		}
+		return []*deployment.Parameters{l.deployment.file.Parameters}
+	}
+	params := make([]*deployment.Parameters, len(l.options.ParameterFiles))
+	for i, path := range l.options.ParameterFiles {
+		params[i] = deployment.LoadParameters(path)
+	}
+	return params
+}
+
+// resolveLocation fills in the position of a rule match from its path when
+// the rule left it empty, or when the rule reported the position of an
+// enclosing element (such as the resource) rather than the element itself.
+// It also sets the end of the match from the element's span when the rule
+// did not report one.
+func resolveLocation(tmpl *template.Template, rm rules.Match) rules.Match {
+	if len(rm.Path) == 0 || rm.EndLine > 0 {
+		return rm
+	}
+	resolved := tmpl.ResolvePath(rm.Path)
+	if resolved == nil || resolved.Depth == 0 {
+		return rm
+	}
+	node := resolved.Node()
+
+	useElement := rm.Line == 0 || (rm.Line == node.Line && rm.Column == node.Column)
+	if !useElement && resolved.Exact(len(rm.Path)) && rm.Line != node.Line {
+		// A position on an ancestor of an exactly resolved element is vague
+		for depth := 1; depth < len(rm.Path); depth++ {
+			ancestor := tmpl.ResolvePath(rm.Path[:depth])
+			if ancestor != nil && (ancestor.Value.Line == rm.Line || (ancestor.Key != nil && ancestor.Key.Line == rm.Line)) {
+				useElement = true
+				break
+			}
 		}
 	}
 
-	return matches, nil
+	var span template.Span
+	switch {
+	case useElement:
+		span = tmpl.ElementSpan(resolved)
+	case rm.Line == resolved.Value.Line && rm.Column == resolved.Value.Column:
+		// The rule pointed at the value rather than the key
+		span = tmpl.NodeSpan(resolved.Value)
+	default:
+		return rm
+	}
+
+	rm.Line, rm.Column = span.StartLine, span.StartColumn
+	rm.EndLine, rm.EndColumn = span.EndLine, span.EndColumn
+	return rm
 }
 
-func (l *Linter) isIgnored(ruleID string) bool {
-	for _, ignored := range l.options.IgnoreRules {
-		if ignored == ruleID {
+// ruleContexts validates ConfigureRules against the options each rule
+// declares. It returns the contexts of configurable rules with valid
+// configuration, and an E0003 match for every configuration error.
+func (l *Linter) ruleContexts(filename string) (map[string]*rules.Context, []Match) {
+	contexts := make(map[string]*rules.Context)
+	var errs []Match
+
+	known := make(map[string]bool)
+	for _, rule := range l.rules {
+		known[rule.ID()] = true
+		configured := l.options.ConfigureRules[rule.ID()]
+
+		configurable, ok := rule.(rules.ConfigurableRule)
+		if !ok {
+			if len(configured) > 0 {
+				errs = append(errs, configErrorMatch(filename, fmt.Sprintf("rule %s has no configurable options", rule.ID())))
+			}
+			continue
+		}
+
+		ctx, err := rules.NewContext(configurable, configured)
+		if err != nil {
+			errs = append(errs, configErrorMatch(filename, err.Error()))
+			continue
+		}
+		contexts[rule.ID()] = ctx
+	}
+
+	var unknown []string
+	for id := range l.options.ConfigureRules {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		errs = append(errs, configErrorMatch(filename, fmt.Sprintf("configure_rules references unknown rule %s", id)))
+	}
+
+	if l.isIgnored("E0003") {
+		errs = nil
+	}
+	return contexts, errs
+}
+
+// configErrorMatch reports an invalid cfn-lint configuration.
+func configErrorMatch(filename, message string) Match {
+	return Match{
+		Rule: MatchRule{
+			ID:               "E0003",
+			Description:      "Checks for configuration errors in the CloudFormation template structure.",
+			ShortDescription: "Configuration error",
+			Source:           "https://github.com/lex00/cfn-lint-go",
+		},
+		Location: MatchLocation{
+			Start:    MatchPosition{LineNumber: 1, ColumnNumber: 1},
+			End:      MatchPosition{LineNumber: 1, ColumnNumber: 1},
+			Path:     []any{},
+			Filename: filename,
+		},
+		Level:   "Error",
+		Message: message,
+	}
+}
+
+// withTemplateConfig returns a linter whose options include the cfn-lint
+// configuration from the template-level Metadata. Ignore and include lists
+// are added to the linter's own, template regions apply only when no regions
+// were configured, include_experimental can only enable experimental rules,
+// and configure_rules options override the configured values.
+func (l *Linter) withTemplateConfig(tmpl *template.Template) *Linter {
+	cfg, err := config.FromMetadata(tmpl.Metadata)
+	if err != nil || cfg == nil {
+		// Malformed metadata configuration is reported by W4005
+		return l
+	}
+
+	opts := l.options
+	opts.IgnoreRules = append(append([]string{}, opts.IgnoreRules...), excludeIDs(cfg.IgnoreChecks, cfg.IncludeChecks)...)
+	opts.IncludeRules = append(append([]string{}, opts.IncludeRules...), cfg.IncludeChecks...)
+	if len(opts.Regions) == 0 {
+		opts.Regions = cfg.Regions
+	}
+	opts.IncludeExperimental = opts.IncludeExperimental || cfg.IncludeExperimental
+	if len(cfg.ConfigureRules) > 0 {
+		// Template options override configured ones, option by option
+		merged := make(map[string]map[string]any)
+		for id, values := range opts.ConfigureRules {
+			merged[id] = values
+		}
+		for id, values := range cfg.ConfigureRules {
+			ruleValues := make(map[string]any)
+			for name, v := range merged[id] {
+				ruleValues[name] = v
+			}
+			for name, v := range values {
+				ruleValues[name] = v
+			}
+			merged[id] = ruleValues
+		}
+		opts.ConfigureRules = merged
+	}
+
+	return &Linter{options: opts, rules: l.rules}
+}
+
+// filterResourceIgnores drops matches inside resources whose Metadata
+// ignores the matching rule via cfn-lint config ignore_checks.
+// Mandatory checks are never dropped.
+func (l *Linter) filterResourceIgnores(tmpl *template.Template, matches []Match) []Match {
+	resourceIgnores := make(map[string][]string)
+	for name, res := range tmpl.Resources {
+		cfg, err := config.FromMetadata(res.Metadata)
+		if err != nil || cfg == nil || len(cfg.IgnoreChecks) == 0 {
+			continue
+		}
+		resourceIgnores[name] = cfg.IgnoreChecks
+	}
+	if len(resourceIgnores) == 0 {
+		return matches
+	}
+
+	filtered := matches[:0]
+	for _, m := range matches {
+		if len(m.Location.Path) >= 2 && m.Location.Path[0] == "Resources" && !l.isMandatory(m.Rule.ID) {
+			if name, ok := m.Location.Path[1].(string); ok && containsID(resourceIgnores[name], m.Rule.ID) {
+				continue
+			}
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
+// excludeIDs returns the IDs in list that are not in exclude.
+func excludeIDs(list, exclude []string) []string {
+	var result []string
+	for _, id := range list {
+		if !containsID(exclude, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func containsID(list []string, id string) bool {
+	for _, item := range list {
+		if item == id {
 			return true
 		}
 	}
 	return false
 }
 
+// newMatch converts a rule match into a public Match.
+func newMatch(rule rules.Rule, rm rules.Match, filename string, sourceMap *sam.SourceMap, region string) Match {
+	// Convert []string path to []any for JSON compatibility
+	path := make([]any, len(rm.Path))
+	for i, p := range rm.Path {
+		path[i] = p
+	}
+
+	// Matches in other files are reported as found
+	if rm.Filename != "" {
+		filename, sourceMap = rm.Filename, nil
+	}
+
+	// Get line/column, potentially mapping back to SAM source
+	line, column := rm.Line, rm.Column
+	endLine, endColumn := rm.EndLine, rm.EndColumn
+	if endLine == 0 {
+		endLine, endColumn = line, column
+	}
+	if sourceMap != nil && len(rm.Path) >= 2 {
+		// Try to get resource name from path
+		if rm.Path[0] == "Resources" {
+			resourceName := rm.Path[1]
+			mappedLoc := sourceMap.MapError(resourceName, "", rm.Line)
+			if mappedLoc.Line > 0 {
+				line = mappedLoc.Line
+				column = mappedLoc.Column
+				endLine, endColumn = line, column
+			}
+		}
+	}
+
+	return Match{
+		Rule: MatchRule{
+			ID:               rule.ID(),
+			Description:      rule.Description(),
+			ShortDescription: rule.ShortDesc(),
+			Source:           rule.Source(),
+		},
+		Location: MatchLocation{
+			Start:    MatchPosition{LineNumber: line, ColumnNumber: column},
+			End:      MatchPosition{LineNumber: endLine, ColumnNumber: endColumn},
+			Path:     path,
+			Filename: filename,
+		},
+		Level:   levelFromRuleID(rule.ID()),
+		Message: rm.Message,
+		Regions: matchRegions(region),
+		Fix:     newMatchFix(rm.Fix, sourceMap),
+	}
+}
+
+// matchRegions returns the Regions of a match found for a region, or nil
+// for rules that are not region-aware.
+func matchRegions(region string) []string {
+	if region == "" {
+		return nil
+	}
+	return []string{region}
+}
+
+// newMatchFix converts a rule fix into a public MatchFix. Fixes of
+// transformed templates are dropped since their positions do not refer to
+// the source.
+func newMatchFix(fix *rules.Fix, sourceMap *sam.SourceMap) *MatchFix {
+	if fix == nil || len(fix.Edits) == 0 || sourceMap != nil {
+		return nil
+	}
+	edits := make([]MatchEdit, len(fix.Edits))
+	for i, e := range fix.Edits {
+		edits[i] = MatchEdit{
+			Start:   MatchPosition{LineNumber: e.StartLine, ColumnNumber: e.StartColumn},
+			End:     MatchPosition{LineNumber: e.EndLine, ColumnNumber: e.EndColumn},
+			NewText: e.NewText,
+		}
+	}
+	return &MatchFix{Description: fix.Description, Edits: edits}
+}
+
+// isEnabled reports whether a rule should run, taking mandatory checks,
+// ignores and the rule's lifecycle status into account.
+func (l *Linter) isEnabled(rule rules.Rule) bool {
+	if l.isMandatory(rule.ID()) {
+		return true
+	}
+	if l.isIgnored(rule.ID()) {
+		return false
+	}
+
+	switch rules.StatusOf(rule) {
+	case rules.StatusExperimental:
+		return l.options.IncludeExperimental || l.isIncluded(rule.ID())
+	case rules.StatusOptIn:
+		return l.isIncluded(rule.ID())
+	}
+	return true
+}
+
+// runsOnFile reports whether a rule runs on the file being linted: only
+// deployment and parameter rules run on deployment files.
+func (l *Linter) runsOnFile(rule rules.Rule) bool {
+	if l.deployment == nil {
+		return true
+	}
+	switch rule.(type) {
+	case rules.DeploymentRule, rules.ParameterRule:
+		return true
+	}
+	return false
+}
+
+func (l *Linter) isIncluded(ruleID string) bool {
+	return containsID(l.options.IncludeRules, ruleID)
+}
+
+func (l *Linter) isIgnored(ruleID string) bool {
+	return containsID(l.options.IgnoreRules, ruleID) && !l.isMandatory(ruleID)
+}
+
+func (l *Linter) isMandatory(ruleID string) bool {
+	return containsID(l.options.MandatoryChecks, ruleID)
+}
+
 func levelFromRuleID(id string) string {
 	if len(id) == 0 {
 		return "Error"
@@ -1,11 +1,14 @@
 package lint_test
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/lex00/cfn-lint-go/internal/testutil"
 	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/regions"
 
 	// Import rule packages to register them
 	_ "github.com/lex00/cfn-lint-go/internal/rules/conditions"
@@ -210,3 +213,103 @@ func TestLevelDistribution(t *testing.T) {
 		}
 	}
 }
+
+// TestLintFilesMatchesSequential ensures concurrent batch linting produces the
+// same results, in the same order, as linting each template on its own.
+func TestLintFilesMatchesSequential(t *testing.T) {
+	templates := testutil.ListTemplates(t, testutil.TemplatesDir())
+	if len(templates) == 0 {
+		t.Skip("No templates found")
+	}
+
+	linter := lint.New(lint.Options{Jobs: 4})
+	results := linter.LintFiles(templates)
+
+	for i, tmplPath := range templates {
+		want := testutil.LintFile(t, tmplPath, lint.Options{})
+		got := results[i]
+		if got.Filename != tmplPath {
+			t.Errorf("Result %d: expected %s, got %s", i, tmplPath, got.Filename)
+			continue
+		}
+		if len(got.Matches) != len(want) {
+			t.Errorf("%s: expected %d matches, got %d", filepath.Base(tmplPath), len(want), len(got.Matches))
+		}
+	}
+}
+
+// TestMetadataIgnoreChecks ensures cfn-lint config in template and resource
+// Metadata suppresses matches.
+func TestMetadataIgnoreChecks(t *testing.T) {
+	base := `
+AWSTemplateFormatVersion: '2010-09-09'
+%s
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+  Policy:
+    Type: AWS::S3::BucketPolicy
+    DependsOn: Bucket
+%s
+    Properties:
+      Bucket: !Ref Bucket
+      PolicyDocument:
+        Statement: []
+`
+	tests := []struct {
+		name        string
+		templateCfg string
+		resourceCfg string
+		wantW3005   bool
+	}{
+		{"no config", "", "", true},
+		{"template ignore", "Metadata:\n  cfn-lint:\n    config:\n      ignore_checks: [W3005]", "", false},
+		{"resource ignore", "", "    Metadata:\n      cfn-lint:\n        config:\n          ignore_checks: [W3005]", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content := fmt.Sprintf(base, tc.templateCfg, tc.resourceCfg)
+			tmpl := testutil.LoadTemplateBytes(t, []byte(content))
+			matches := testutil.LintTemplate(t, tmpl, "test.yaml", lint.Options{})
+
+			found := len(testutil.FilterByRuleID(matches, "W3005")) > 0
+			if found != tc.wantW3005 {
+				t.Errorf("W3005 reported = %v, want %v", found, tc.wantW3005)
+			}
+		})
+	}
+}
+
+// TestAllRegionsHardcodedAZ ensures a finding that is the same in many
+// regions is reported once, listing the regions it applies to.
+func TestAllRegionsHardcodedAZ(t *testing.T) {
+	tmpl := testutil.LoadTemplateBytes(t, []byte(`
+AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  Subnet:
+    Type: AWS::EC2::Subnet
+    Properties:
+      VpcId: vpc-12345
+      CidrBlock: 10.0.0.0/24
+      AvailabilityZone: us-east-1a
+`))
+	matches := testutil.LintTemplate(t, tmpl, "test.yaml", lint.Options{Regions: []string{regions.AllRegions}})
+
+	w3010 := testutil.FilterByRuleID(matches, "W3010")
+	if len(w3010) != 2 {
+		t.Fatalf("Expected a portability warning and a region mismatch, got %d: %v", len(w3010), w3010)
+	}
+	for _, m := range w3010 {
+		switch {
+		case strings.Contains(m.Message, "not in the target region"):
+			if len(m.Regions) != len(regions.All())-1 {
+				t.Errorf("Expected the mismatch in every region but us-east-1, got %v", m.Regions)
+			}
+		default:
+			if strings.Join(m.Regions, ",") != "us-east-1" {
+				t.Errorf("Expected the portability warning in us-east-1 only, got %v", m.Regions)
+			}
+		}
+	}
+}
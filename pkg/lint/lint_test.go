@@ -1,8 +1,17 @@
 package lint
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -47,6 +56,69 @@ Resources:
 	}
 }
 
+func TestLintBytes(t *testing.T) {
+	source := "Resources:\n  First:\n    Type: AWS::S3::Bucket\n"
+	linter := &Linter{rules: []rules.Rule{&lineMockRule{baseMockRule{"E9001"}}}}
+
+	tests := []struct {
+		name string
+		lint func() ([]Match, error)
+	}{
+		{"bytes", func() ([]Match, error) { return linter.LintBytes([]byte(source), "generated.yaml") }},
+		{"reader", func() ([]Match, error) { return linter.LintReader(strings.NewReader(source), "generated.yaml") }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := tc.lint()
+			if err != nil {
+				t.Fatalf("Lint failed: %v", err)
+			}
+			if len(matches) == 0 {
+				t.Fatal("Expected matches")
+			}
+			for _, m := range matches {
+				if m.Location.Filename != "generated.yaml" {
+					t.Errorf("Expected filename generated.yaml, got %s", m.Location.Filename)
+				}
+			}
+		})
+	}
+}
+
+func TestLintBytesResult(t *testing.T) {
+	source := "Resources:\n  First:\n    Type: AWS::S3::Bucket\n"
+	linter := &Linter{rules: []rules.Rule{&lineMockRule{baseMockRule{"E9001"}}}}
+
+	for name, result := range map[string]FileResult{
+		"bytes":  linter.LintBytesResult(context.Background(), []byte(source), "-"),
+		"reader": linter.LintReaderResult(context.Background(), strings.NewReader(source), "-"),
+	} {
+		if result.Err != nil {
+			t.Fatalf("%s: Lint failed: %v", name, result.Err)
+		}
+		if result.Filename != "-" || !result.Parsed || result.Duration <= 0 || len(result.Matches) == 0 || string(result.Source) != source {
+			t.Errorf("%s: Expected a full result, got %+v", name, result)
+		}
+	}
+
+	result := linter.LintBytesResult(context.Background(), []byte("Resources: [unclosed"), "-")
+	if result.Parsed || len(result.Matches) != 1 {
+		t.Errorf("Expected an unparsed result with one match, got %+v", result)
+	}
+}
+
+func TestLintBytes_ParseError(t *testing.T) {
+	linter := &Linter{}
+	matches, err := linter.LintBytes([]byte("Resources: [unclosed"), "-")
+	if err != nil {
+		t.Fatalf("LintBytes failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Rule.ID != "E0000" || matches[0].Location.Filename != "-" {
+		t.Errorf("Expected a single E0000 match for -, got %v", matches)
+	}
+}
+
 func TestLintWithIgnoredRules(t *testing.T) {
 	linter := New(Options{
 		IgnoreRules: []string{"E1001"},
@@ -114,3 +186,843 @@ func TestMatch_JSON(t *testing.T) {
 		t.Errorf("Expected filename test.yaml, got %s", m.Location.Filename)
 	}
 }
+
+// baseMockRule implements the descriptive methods of rules.Rule for the
+// mock rules below, which add the methods under test.
+type baseMockRule struct {
+	id string
+}
+
+func (r *baseMockRule) ID() string          { return r.id }
+func (r *baseMockRule) ShortDesc() string   { return "Mock rule" }
+func (r *baseMockRule) Description() string { return "A mock rule" }
+func (r *baseMockRule) Source() string      { return "" }
+func (r *baseMockRule) Tags() []string      { return nil }
+
+// regionalMockRule reports one match per region outside the aws partition.
+type regionalMockRule struct {
+	baseMockRule
+}
+
+func (r *regionalMockRule) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchRegion(tmpl, "us-east-1")
+}
+func (r *regionalMockRule) MatchRegion(tmpl *template.Template, region string) []rules.Match {
+	if region == "us-east-1" {
+		return nil
+	}
+	return []rules.Match{{Message: "not in us-east-1", Path: []string{"Resources"}}}
+}
+
+func TestLintRegions(t *testing.T) {
+	tmpl, err := template.Parse([]byte("Resources:\n  B:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{
+		options: Options{Regions: []string{"us-east-1", "cn-north-1", "us-gov-west-1"}},
+		rules:   []rules.Rule{&regionalMockRule{baseMockRule{"E9999"}}},
+	}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	// The match is the same in both non-default regions, so it is reported
+	// once, listing both of them
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(matches))
+	}
+	if got := strings.Join(matches[0].Regions, ","); got != "cn-north-1,us-gov-west-1" {
+		t.Errorf("Expected the match for cn-north-1 and us-gov-west-1, got %s", got)
+	}
+}
+
+func TestLintRegions_Default(t *testing.T) {
+	tmpl, err := template.Parse([]byte("Resources:\n  B:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{rules: []rules.Rule{&regionalMockRule{baseMockRule{"E9999"}}}}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("Expected default region us-east-1 to produce no matches, got %d", len(matches))
+	}
+}
+
+// statusMockRule always matches and declares a lifecycle status.
+type statusMockRule struct {
+	baseMockRule
+	status rules.Status
+}
+
+func (r *statusMockRule) Status() rules.Status { return r.status }
+func (r *statusMockRule) Match(tmpl *template.Template) []rules.Match {
+	return []rules.Match{{Message: r.id, Path: []string{"Resources"}}}
+}
+
+func TestLintRuleStatus(t *testing.T) {
+	tmpl, err := template.Parse([]byte("Resources:\n  B:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	ruleSet := []rules.Rule{
+		&statusMockRule{baseMockRule{"E9001"}, rules.StatusStable},
+		&statusMockRule{baseMockRule{"E9002"}, rules.StatusExperimental},
+		&statusMockRule{baseMockRule{"E9003"}, rules.StatusOptIn},
+		&statusMockRule{baseMockRule{"E9004"}, rules.StatusDeprecated},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"defaults", Options{}, []string{"E9001", "E9004"}},
+		{"include experimental", Options{IncludeExperimental: true}, []string{"E9001", "E9002", "E9004"}},
+		{"include opt-in by ID", Options{IncludeRules: []string{"E9003"}}, []string{"E9001", "E9003", "E9004"}},
+		{"include experimental by ID", Options{IncludeRules: []string{"E9002"}}, []string{"E9001", "E9002", "E9004"}},
+		{"ignore wins", Options{IncludeExperimental: true, IgnoreRules: []string{"E9002"}}, []string{"E9001", "E9004"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			linter := &Linter{options: tc.opts, rules: ruleSet}
+			matches, err := linter.Lint(tmpl, "test.yaml")
+			if err != nil {
+				t.Fatalf("Lint failed: %v", err)
+			}
+
+			var got []string
+			for _, m := range matches {
+				got = append(got, m.Rule.ID)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Expected rules %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("Expected rules %v, got %v", tc.want, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+// resourceMockRule reports one match per resource.
+type resourceMockRule struct {
+	baseMockRule
+}
+
+func (r *resourceMockRule) Match(tmpl *template.Template) []rules.Match {
+	var matches []rules.Match
+	for _, name := range []string{"First", "Second"} {
+		if tmpl.HasResource(name) {
+			matches = append(matches, rules.Match{Message: name, Path: []string{"Resources", name, "Properties"}})
+		}
+	}
+	return matches
+}
+
+func TestLintMetadataConfig(t *testing.T) {
+	yaml := `
+Metadata:
+  cfn-lint:
+    config:
+      ignore_checks:
+        - W9001
+      include_checks:
+        - E9003
+      regions:
+        - cn-north-1
+Resources:
+  First:
+    Type: AWS::S3::Bucket
+    Metadata:
+      cfn-lint:
+        config:
+          ignore_checks:
+            - E9002
+  Second:
+    Type: AWS::S3::Bucket
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{rules: []rules.Rule{
+		&resourceMockRule{baseMockRule{"W9001"}},
+		&resourceMockRule{baseMockRule{"E9002"}},
+		&statusMockRule{baseMockRule{"E9003"}, rules.StatusOptIn},
+		&regionalMockRule{baseMockRule{"E9999"}},
+	}}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	got := make(map[string]int)
+	for _, m := range matches {
+		got[m.Rule.ID+":"+m.Message]++
+	}
+
+	want := map[string]int{
+		"E9002:Second":           1, // First ignores E9002 in its own Metadata
+		"E9003:E9003":            1, // opt-in enabled by template include_checks
+		"E9999:not in us-east-1": 1, // template regions apply when none configured
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected matches %v, got %v", want, got)
+	}
+	for key, count := range want {
+		if got[key] != count {
+			t.Errorf("Expected %d of %s, got %d", count, key, got[key])
+		}
+	}
+}
+
+func TestLintMetadataConfig_RegionsFromOptionsWin(t *testing.T) {
+	yaml := `
+Metadata:
+  cfn-lint:
+    config:
+      regions: [cn-north-1]
+Resources:
+  First:
+    Type: AWS::S3::Bucket
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{
+		options: Options{Regions: []string{"us-east-1"}},
+		rules:   []rules.Rule{&regionalMockRule{baseMockRule{"E9999"}}},
+	}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("Expected configured regions to take precedence, got %d matches", len(matches))
+	}
+}
+
+// lineMockRule reports one match per resource at the resource's line.
+type lineMockRule struct {
+	baseMockRule
+}
+
+func (r *lineMockRule) Match(tmpl *template.Template) []rules.Match {
+	var matches []rules.Match
+	for _, name := range []string{"First", "Second"} {
+		if res, ok := tmpl.Resources[name]; ok {
+			matches = append(matches, rules.Match{Message: name, Line: res.Node.Line, Column: res.Node.Column})
+		}
+	}
+	return matches
+}
+
+func TestLintInlineSuppressions(t *testing.T) {
+	yaml := `
+Resources:
+  First:
+    Type: AWS::S3::Bucket # cfn-lint-disable-line W9001 -- kept for compatibility
+  Second:
+    Type: AWS::S3::Bucket
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	ruleSet := []rules.Rule{&lineMockRule{baseMockRule{"W9001"}}, &lineMockRule{baseMockRule{"E9002"}}}
+
+	linter := &Linter{rules: ruleSet}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.Rule.ID+":"+m.Message)
+	}
+	want := []string{"W9001:Second", "E9002:First", "E9002:Second"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected matches %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected matches %v, got %v", want, got)
+			break
+		}
+	}
+
+	linter = &Linter{options: Options{IncludeSuppressed: true}, rules: ruleSet}
+	matches, err = linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(matches) != 4 {
+		t.Fatalf("Expected 4 matches with suppressed included, got %d", len(matches))
+	}
+	s := matches[0].Suppression
+	if s == nil {
+		t.Fatalf("Expected first match to be suppressed, got %+v", matches[0])
+	}
+	if s.Justification != "kept for compatibility" || s.Line != 4 {
+		t.Errorf("Unexpected suppression %+v", s)
+	}
+	for _, m := range matches[1:] {
+		if m.Suppression != nil {
+			t.Errorf("Expected %s:%s not to be suppressed", m.Rule.ID, m.Message)
+		}
+	}
+}
+
+// configurableMockRule reports the configured limit.
+type configurableMockRule struct {
+	baseMockRule
+}
+
+func (r *configurableMockRule) Options() []rules.Option {
+	return []rules.Option{{Name: "limit", Type: rules.OptionInteger, Default: 5}}
+}
+func (r *configurableMockRule) Match(tmpl *template.Template) []rules.Match {
+	return r.MatchWithContext(rules.DefaultContext(r), tmpl)
+}
+func (r *configurableMockRule) MatchWithContext(ctx *rules.Context, tmpl *template.Template) []rules.Match {
+	return []rules.Match{{Message: fmt.Sprintf("limit %d", ctx.Int("limit"))}}
+}
+
+func TestLintConfigureRules(t *testing.T) {
+	yaml := `
+Metadata:
+  cfn-lint:
+    config:
+      configure_rules:
+        E9010:
+          limit: 7
+Resources:
+  First:
+    Type: AWS::S3::Bucket
+`
+	plain, err := template.Parse([]byte("Resources:\n  First:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	withMetadata, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		tmpl      *template.Template
+		configure map[string]map[string]any
+		want      []string
+	}{
+		{"defaults", plain, nil, []string{"E9010:limit 5"}},
+		{"configured", plain, map[string]map[string]any{"E9010": {"limit": 3}}, []string{"E9010:limit 3"}},
+		{"template metadata overrides", withMetadata, map[string]map[string]any{"E9010": {"limit": 3}}, []string{"E9010:limit 7"}},
+		{"invalid value", plain, map[string]map[string]any{"E9010": {"limit": "lots"}},
+			[]string{`E0003:rule E9010 option "limit": expected an integer, got string "lots"`}},
+		{"rule without options", plain, map[string]map[string]any{"W9001": {"strict": true}},
+			[]string{"E0003:rule W9001 has no configurable options", "E9010:limit 5"}},
+		{"unknown rule", plain, map[string]map[string]any{"E1234": {"limit": 1}},
+			[]string{"E0003:configure_rules references unknown rule E1234", "E9010:limit 5"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			linter := &Linter{
+				options: Options{ConfigureRules: tc.configure},
+				rules:   []rules.Rule{&configurableMockRule{baseMockRule{"E9010"}}, &resourceMockRule{baseMockRule{"W9001"}}},
+			}
+			matches, err := linter.Lint(tc.tmpl, "test.yaml")
+			if err != nil {
+				t.Fatalf("Lint failed: %v", err)
+			}
+
+			var got []string
+			for _, m := range matches {
+				if m.Rule.ID != "W9001" {
+					got = append(got, m.Rule.ID+":"+m.Message)
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+				t.Errorf("Expected matches %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// regionalConfigurableMockRule is both configurable and region-aware.
+type regionalConfigurableMockRule struct {
+	configurableMockRule
+}
+
+func (r *regionalConfigurableMockRule) MatchRegion(tmpl *template.Template, region string) []rules.Match {
+	return []rules.Match{{Message: "without options in " + region}}
+}
+
+func (r *regionalConfigurableMockRule) MatchWithContext(ctx *rules.Context, tmpl *template.Template) []rules.Match {
+	return []rules.Match{{Message: fmt.Sprintf("limit %d in %s", ctx.Int("limit"), ctx.Region())}}
+}
+
+func TestLintConfigurableRegionalRule(t *testing.T) {
+	tmpl, err := template.Parse([]byte("Resources:\n  First:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{
+		options: Options{
+			Regions:        []string{"us-east-1", "eu-west-1"},
+			ConfigureRules: map[string]map[string]any{"E9010": {"limit": 3}},
+		},
+		rules: []rules.Rule{&regionalConfigurableMockRule{configurableMockRule{baseMockRule{"E9010"}}}},
+	}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	var got []string
+	for _, m := range matches {
+		got = append(got, strings.Join(m.Regions, ",")+":"+m.Message)
+	}
+	want := []string{"us-east-1:limit 3 in us-east-1", "eu-west-1:limit 3 in eu-west-1"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected matches %v, got %v", want, got)
+	}
+}
+
+func TestLintSeverityOverrides(t *testing.T) {
+	tmpl, err := template.Parse([]byte("Resources:\n  First:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{
+		options: Options{SeverityOverrides: map[string]string{
+			"W9001": "error",
+			"I9002": "Warning",
+			"E9003": "critical",
+		}},
+		rules: []rules.Rule{&resourceMockRule{baseMockRule{"W9001"}}, &resourceMockRule{baseMockRule{"I9002"}}},
+	}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, m := range matches {
+		got[m.Rule.ID] = m.Level
+	}
+	want := map[string]string{"W9001": "Error", "I9002": "Warning", "E0003": "Error"}
+	for id, level := range want {
+		if got[id] != level {
+			t.Errorf("Expected %s at level %s, got %q", id, level, got[id])
+		}
+	}
+}
+
+func TestLintMandatoryChecks(t *testing.T) {
+	yaml := `
+Metadata:
+  cfn-lint:
+    config:
+      ignore_checks: [W9001, E9002]
+Resources:
+  First:
+    Type: AWS::S3::Bucket # cfn-lint-disable-line
+    Metadata:
+      cfn-lint:
+        config:
+          ignore_checks: [W9003]
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{
+		options: Options{
+			IgnoreRules:     []string{"E9004"},
+			MandatoryChecks: []string{"W9001", "W9003", "E9004", "E9005", "E9006"},
+		},
+		rules: []rules.Rule{
+			&resourceMockRule{baseMockRule{"W9001"}},                  // ignored by template Metadata
+			&resourceMockRule{baseMockRule{"E9002"}},                  // ignored by template Metadata, not mandatory
+			&resourceMockRule{baseMockRule{"W9003"}},                  // ignored by resource Metadata
+			&resourceMockRule{baseMockRule{"E9004"}},                  // ignored by options
+			&lineMockRule{baseMockRule{"E9005"}},                      // suppressed inline
+			&statusMockRule{baseMockRule{"E9006"}, rules.StatusOptIn}, // opt-in
+			&statusMockRule{baseMockRule{"E9007"}, rules.StatusOptIn}, // opt-in, not mandatory
+		},
+	}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, m := range matches {
+		got[m.Rule.ID] = true
+	}
+	for _, id := range []string{"W9001", "W9003", "E9004", "E9005", "E9006"} {
+		if !got[id] {
+			t.Errorf("Expected mandatory check %s to run, got %v", id, got)
+		}
+	}
+	for _, id := range []string{"E9002", "E9007"} {
+		if got[id] {
+			t.Errorf("Expected %s not to run", id)
+		}
+	}
+}
+
+func TestResolveLocation(t *testing.T) {
+	yaml := `Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: data
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	propPath := []string{"Resources", "MyBucket", "Properties", "BucketName"}
+
+	tests := []struct {
+		name               string
+		match              rules.Match
+		line, column       int
+		endLine, endColumn int
+	}{
+		{"missing position", rules.Match{Path: propPath}, 5, 7, 5, 23},
+		{"resource position", rules.Match{Path: propPath, Line: 3, Column: 5}, 5, 7, 5, 23},
+		{"resource key position", rules.Match{Path: propPath, Line: 2, Column: 3}, 5, 7, 5, 23},
+		{"precise position kept", rules.Match{Path: propPath, Line: 5, Column: 19}, 5, 19, 5, 23},
+		{"template start is vague", rules.Match{Path: propPath, Line: 1, Column: 1}, 5, 7, 5, 23},
+		{"non-ancestor position kept", rules.Match{Path: []string{"Resources", "MyBucket", "Type"}, Line: 5, Column: 7}, 5, 7, 0, 0},
+		{"reported span kept", rules.Match{Path: propPath, Line: 5, Column: 19, EndLine: 5, EndColumn: 21}, 5, 19, 5, 21},
+		{"partial path", rules.Match{Path: []string{"Resources", "MyBucket", "Properties", "Missing"}}, 4, 5, 4, 15},
+		{"no path", rules.Match{}, 0, 0, 0, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resolveLocation(tmpl, tc.match)
+			if got.Line != tc.line || got.Column != tc.column {
+				t.Errorf("Expected %d:%d, got %d:%d", tc.line, tc.column, got.Line, got.Column)
+			}
+			if got.EndLine != tc.endLine || got.EndColumn != tc.endColumn {
+				t.Errorf("Expected end %d:%d, got %d:%d", tc.endLine, tc.endColumn, got.EndLine, got.EndColumn)
+			}
+		})
+	}
+}
+
+// fixMockRule reports each resource's Type and fixes it to AWS::SNS::Topic.
+type fixMockRule struct {
+	baseMockRule
+}
+
+func (r *fixMockRule) Match(tmpl *template.Template) []rules.Match {
+	return []rules.Match{{Message: "type", Path: []string{"Resources", "First", "Type"}}}
+}
+func (r *fixMockRule) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+	node := tmpl.ResolvePath(m.Path).Value
+	return &rules.Fix{
+		Description: "Use a topic",
+		Edits:       []rules.TextEdit{rules.ReplaceScalar(tmpl, node, "AWS::SNS::Topic")},
+	}
+}
+
+func TestLintFixes(t *testing.T) {
+	yaml := `Resources:
+  First:
+    Type: "AWS::S3::Bucket"
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{rules: []rules.Rule{&fixMockRule{baseMockRule{"W9020"}}}}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Fix == nil {
+		t.Fatalf("Expected one match with a fix, got %+v", matches)
+	}
+
+	fix := matches[0].Fix
+	want := MatchEdit{
+		Start:   MatchPosition{LineNumber: 3, ColumnNumber: 11},
+		End:     MatchPosition{LineNumber: 3, ColumnNumber: 28},
+		NewText: `"AWS::SNS::Topic"`,
+	}
+	if fix.Description != "Use a topic" || len(fix.Edits) != 1 || fix.Edits[0] != want {
+		t.Errorf("Unexpected fix %+v", fix)
+	}
+}
+
+type parameterMockRule struct {
+	baseMockRule
+}
+
+func (r *parameterMockRule) Match(tmpl *template.Template) []rules.Match {
+	return []rules.Match{{Message: "template", Line: 2, Column: 1}}
+}
+func (r *parameterMockRule) MatchParameters(tmpl *template.Template, params *deployment.Parameters) []rules.Match {
+	var matches []rules.Match
+	for _, v := range params.Values {
+		matches = append(matches, rules.Match{
+			Message:  v.Key,
+			Line:     v.KeyNode.Line,
+			Column:   v.KeyNode.Column,
+			Path:     v.KeyPath,
+			Filename: params.Filename,
+		})
+	}
+	return matches
+}
+
+func TestLintParameterFiles(t *testing.T) {
+	dir := t.TempDir()
+	prod := filepath.Join(dir, "prod.json")
+	dev := filepath.Join(dir, "dev.json")
+	if err := os.WriteFile(prod, []byte("{\n  \"Env\": \"prod\"\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dev, []byte("{\"Env\": \"dev\", \"Size\": \"1\"}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The suppression is on line 2 of the template, as is the prod value
+	yaml := `Resources:
+  Topic: # cfn-lint-disable-line E9030
+    Type: AWS::SNS::Topic
+`
+	tmpl, err := template.Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	linter := &Linter{options: Options{ParameterFiles: []string{prod, dev}}, rules: []rules.Rule{&parameterMockRule{baseMockRule{"E9030"}}}}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	var got []string
+	for _, m := range matches {
+		got = append(got, fmt.Sprintf("%s:%d:%d:%s", filepath.Base(m.Location.Filename), m.Location.Start.LineNumber, m.Location.Start.ColumnNumber, m.Message))
+	}
+	want := []string{"prod.json:2:3:Env", "dev.json:1:2:Env", "dev.json:1:16:Size"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected matches %v, got %v", want, got)
+	}
+}
+
+type deploymentMockRule struct {
+	baseMockRule
+}
+
+func (r *deploymentMockRule) Match(tmpl *template.Template) []rules.Match {
+	return nil
+}
+func (r *deploymentMockRule) MatchDeployment(tmpl *template.Template, file *deployment.File) []rules.Match {
+	var matches []rules.Match
+	for _, e := range file.Errors {
+		matches = append(matches, rules.Match{Message: e.Message, Line: e.Line, Column: e.Column, Path: e.Path, Filename: file.Filename})
+	}
+	for _, tag := range file.Tags {
+		matches = append(matches, rules.Match{Message: tag.Key, Line: tag.KeyNode.Line, Column: tag.KeyNode.Column, Path: tag.Path, Filename: file.Filename})
+	}
+	return matches
+}
+
+func TestLintDeploymentFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("Resources:\n  Topic:\n    Type: AWS::SNS::Topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deploy := filepath.Join(dir, "deploy.yaml")
+	if err := os.WriteFile(deploy, []byte("template-file-path: app.yaml\nparameters:\n  Env: prod\ntags:\n  Team: web\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	broken := filepath.Join(dir, "broken.yaml")
+	if err := os.WriteFile(broken, []byte("template-file-path: missing.yaml\nparameters:\n  Env: prod\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := &Linter{rules: []rules.Rule{&parameterMockRule{baseMockRule{"E9030"}}, &deploymentMockRule{baseMockRule{"E9031"}}}}
+	format := func(matches []Match) []string {
+		var got []string
+		for _, m := range matches {
+			got = append(got, fmt.Sprintf("%s:%d:%d:%s:%s", filepath.Base(m.Location.Filename), m.Location.Start.LineNumber, m.Location.Start.ColumnNumber, m.Rule.ID, m.Message))
+		}
+		return got
+	}
+
+	// Template rules do not run; parameter rules see the deployment's values
+	result := linter.lintFile(context.Background(), deploy)
+	if result.Err != nil {
+		t.Fatalf("Lint failed: %v", result.Err)
+	}
+	want := []string{"deploy.yaml:3:3:E9030:Env", "deploy.yaml:5:3:E9031:Team"}
+	if got := format(result.Matches); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected matches %v, got %v", want, got)
+	}
+	if want := []string{filepath.Join(dir, "app.yaml")}; !reflect.DeepEqual(result.Dependencies, want) {
+		t.Errorf("Expected dependencies %v, got %v", want, result.Dependencies)
+	}
+
+	// A missing template is reported at template-file-path, and the
+	// parameters are not checked against it
+	result = linter.lintFile(context.Background(), broken)
+	if len(result.Matches) != 1 || result.Matches[0].Rule.ID != "E9031" || result.Matches[0].Location.Start.LineNumber != 1 || result.Matches[0].Location.Start.ColumnNumber != 21 {
+		t.Fatalf("Expected a match at template-file-path, got %v", format(result.Matches))
+	}
+	if !strings.Contains(result.Matches[0].Message, "template-file-path 'missing.yaml' cannot be read") {
+		t.Errorf("Unexpected message %q", result.Matches[0].Message)
+	}
+}
+
+// resourceTypeMockRule reports the type of every resource.
+type resourceTypeMockRule struct {
+	baseMockRule
+}
+
+func (r *resourceTypeMockRule) Match(tmpl *template.Template) []rules.Match {
+	var matches []rules.Match
+	for name := range tmpl.Resources {
+		matches = append(matches, rules.Match{Message: name, Path: []string{"Resources", name, "Type"}})
+	}
+	return matches
+}
+
+func TestLintLanguageExtensions(t *testing.T) {
+	yaml := `Transform: AWS::LanguageExtensions
+Parameters:
+  Names:
+    Type: CommaDelimitedList
+    Default: a,b
+  Other:
+    Type: CommaDelimitedList
+Resources:
+  Fn::ForEach::Topics:
+    - Name
+    - !Ref Names
+    - Topic${Name}:
+        Type: AWS::SNS::Topic
+  Subscription:
+    Type: AWS::SNS::Subscription
+    Properties:
+      Protocol: email
+      Endpoint: ops@example.com
+      TopicArn: !Ref Topica
+`
+	lint := func(t *testing.T, linter *Linter, yaml string) []string {
+		t.Helper()
+		tmpl, err := template.Parse([]byte(yaml))
+		if err != nil {
+			t.Fatalf("Failed to parse template: %v", err)
+		}
+		matches, err := linter.Lint(tmpl, "test.yaml")
+		if err != nil {
+			t.Fatalf("Lint failed: %v", err)
+		}
+		var got []string
+		for _, m := range matches {
+			got = append(got, fmt.Sprintf("%s:%d:%d:%s", m.Rule.ID, m.Location.Start.LineNumber, m.Location.Start.ColumnNumber, m.Message))
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	// Generated resources are linted at the loop's output template
+	mock := &Linter{rules: []rules.Rule{&resourceTypeMockRule{baseMockRule{"E9040"}}}}
+	got := lint(t, mock, yaml)
+	want := []string{"E9040:13:9:Topica", "E9040:13:9:Topicb", "E9040:15:5:Subscription"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected matches %v, got %v", want, got)
+	}
+
+	// References to generated resources and to the loop's collection are
+	// not reported
+	for _, m := range lint(t, New(Options{}), yaml) {
+		if strings.HasPrefix(m, "E1001") || strings.Contains(m, "'Names'") || strings.HasPrefix(m, "E3001") {
+			t.Errorf("Unexpected match %s", m)
+		}
+	}
+
+	// A loop that cannot be expanded is reported, and the template is
+	// linted as written
+	got = lint(t, mock, strings.Replace(yaml, "!Ref Names", "!Ref Missing", 1))
+	want = []string{"E0001:9:3:Error transforming template: Fn::ForEach::Topics collection Ref to undefined parameter or resource 'Missing'", "E9040:15:5:Subscription", "E9040:9:3:Fn::ForEach::Topics"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected matches %v, got %v", want, got)
+	}
+
+	// A collection only known at deployment is not an error
+	got = lint(t, mock, strings.Replace(yaml, "!Ref Names", "!Ref Other", 1))
+	if len(got) != 2 || strings.HasPrefix(got[0], "E0001") {
+		t.Errorf("Expected the template to be linted as written, got %v", got)
+	}
+}
+
+func TestLintLanguageExtensions_FirstRegion(t *testing.T) {
+	tmpl, err := template.Parse([]byte(`Transform: AWS::LanguageExtensions
+Mappings:
+  Zones:
+    eu-west-1:
+      Names: [a, b]
+    us-east-1:
+      Names: [c]
+Resources:
+  Fn::ForEach::Queues:
+    - Zone
+    - !FindInMap [Zones, !Ref "AWS::Region", Names]
+    - Queue${Zone}:
+        Type: AWS::SQS::Queue
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	// The template is expanded for the first target region only, and that
+	// expansion is linted for both regions
+	linter := &Linter{
+		options: Options{Regions: []string{"eu-west-1", "us-east-1"}},
+		rules:   []rules.Rule{&resourceTypeMockRule{baseMockRule{"E9040"}}},
+	}
+	matches, err := linter.Lint(tmpl, "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.Message)
+	}
+	sort.Strings(got)
+	if want := []string{"Queuea", "Queueb"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the eu-west-1 queues %v, got %v", want, got)
+	}
+}
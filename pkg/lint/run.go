@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+)
+
+// ruleError describes a rule that failed instead of returning matches.
+type ruleError struct {
+	message string
+	stack   []byte
+}
+
+func (e *ruleError) Error() string {
+	if len(e.stack) == 0 {
+		return e.message
+	}
+	return e.message + "\n" + string(e.stack)
+}
+
+// runRule calls match, recovering panics and enforcing Options.RuleTimeout
+// and ctx. A rule that times out is abandoned: its goroutine keeps running
+// until the rule returns, but its result is discarded. Without a timeout,
+// the rule runs in the calling goroutine and cancellation is only noticed
+// between rules.
+func (l *Linter) runRule(ctx context.Context, match func() []rules.Match) ([]rules.Match, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if l.options.RuleTimeout <= 0 {
+		return safeMatch(match)
+	}
+
+	type result struct {
+		matches []rules.Match
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		rms, err := safeMatch(match)
+		done <- result{rms, err}
+	}()
+
+	timer := time.NewTimer(l.options.RuleTimeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		return r.matches, r.err
+	case <-timer.C:
+		return nil, &ruleError{message: fmt.Sprintf("timed out after %s", l.options.RuleTimeout)}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// safeMatch calls match and converts a panic into a ruleError.
+func safeMatch(match func() []rules.Match) (matches []rules.Match, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			matches = nil
+			err = &ruleError{message: fmt.Sprintf("panic: %v", r), stack: debug.Stack()}
+		}
+	}()
+	return match(), nil
+}
+
+// ruleErrorMatch reports a rule that panicked or timed out.
+func ruleErrorMatch(filename, ruleID string, err error) Match {
+	return Match{
+		Rule: MatchRule{
+			ID:               "E0002",
+			Description:      "Checks for errors during rule execution. This indicates a bug in cfn-lint-go.",
+			ShortDescription: "Rule processing error",
+			Source:           "https://github.com/aws-cloudformation/cfn-lint/blob/main/docs/rules.md#E0002",
+		},
+		Location: MatchLocation{
+			Start:    MatchPosition{LineNumber: 1, ColumnNumber: 1},
+			End:      MatchPosition{LineNumber: 1, ColumnNumber: 1},
+			Path:     []any{},
+			Filename: filename,
+		},
+		Level:   "Error",
+		Message: fmt.Sprintf("Unknown exception while processing rule %s: %s", ruleID, err),
+	}
+}
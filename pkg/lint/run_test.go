@@ -0,0 +1,155 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// panicMockRule panics on every template.
+type panicMockRule struct {
+	baseMockRule
+}
+
+func (r *panicMockRule) Match(tmpl *template.Template) []rules.Match {
+	var props map[string]any
+	_ = props["missing"].(string) // bad type assertion
+	return nil
+}
+
+// blockingMockRule blocks until release is closed.
+type blockingMockRule struct {
+	baseMockRule
+	release chan struct{}
+}
+
+func (r *blockingMockRule) Match(tmpl *template.Template) []rules.Match {
+	<-r.release
+	return []rules.Match{{Message: "finished"}}
+}
+
+func parseSimple(t *testing.T) *template.Template {
+	t.Helper()
+	tmpl, err := template.Parse([]byte("Resources:\n  First:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	return tmpl
+}
+
+func TestLintRulePanic(t *testing.T) {
+	linter := &Linter{rules: []rules.Rule{&panicMockRule{baseMockRule{"E9020"}}, &resourceMockRule{baseMockRule{"W9001"}}}}
+	matches, err := linter.Lint(parseSimple(t), "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	if len(matches) != 2 {
+		t.Fatalf("Expected E0002 and W9001 matches, got %v", matches)
+	}
+	m := matches[0]
+	if m.Rule.ID != "E0002" || m.Level != "Error" {
+		t.Errorf("Expected E0002 error, got %s %s", m.Rule.ID, m.Level)
+	}
+	if !strings.Contains(m.Message, "E9020") || !strings.Contains(m.Message, "goroutine") {
+		t.Errorf("Expected rule ID and stack in message, got %q", m.Message)
+	}
+	if matches[1].Rule.ID != "W9001" {
+		t.Errorf("Expected other rules to keep running, got %s", matches[1].Rule.ID)
+	}
+}
+
+func TestLintRuleTimeout(t *testing.T) {
+	rule := &blockingMockRule{baseMockRule: baseMockRule{"E9021"}, release: make(chan struct{})}
+	defer close(rule.release)
+
+	linter := &Linter{
+		options: Options{RuleTimeout: 20 * time.Millisecond},
+		rules:   []rules.Rule{rule, &resourceMockRule{baseMockRule{"W9001"}}},
+	}
+	matches, err := linter.Lint(parseSimple(t), "test.yaml")
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	if len(matches) != 2 {
+		t.Fatalf("Expected E0002 and W9001 matches, got %v", matches)
+	}
+	if matches[0].Rule.ID != "E0002" || !strings.Contains(matches[0].Message, "E9021") || !strings.Contains(matches[0].Message, "timed out") {
+		t.Errorf("Expected E0002 timeout for E9021, got %s %q", matches[0].Rule.ID, matches[0].Message)
+	}
+}
+
+func TestLintContextCancelled(t *testing.T) {
+	// Without a rule timeout the running rule finishes, and linting stops
+	// before the next one
+	rule := &blockingMockRule{baseMockRule: baseMockRule{"E9021"}, release: make(chan struct{})}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		close(rule.release)
+	}()
+
+	linter := &Linter{rules: []rules.Rule{rule, &resourceMockRule{baseMockRule{"W9001"}}}}
+	_, err := linter.LintContext(ctx, parseSimple(t), "test.yaml")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestLintContextCancelledWithTimeout(t *testing.T) {
+	// With a rule timeout the running rule is abandoned
+	rule := &blockingMockRule{baseMockRule: baseMockRule{"E9021"}, release: make(chan struct{})}
+	defer close(rule.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	linter := &Linter{options: Options{RuleTimeout: time.Minute}, rules: []rules.Rule{rule}}
+	_, err := linter.LintContext(ctx, parseSimple(t), "test.yaml")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRunRuleInline(t *testing.T) {
+	// A cancellable context alone does not move rules to a goroutine, so a
+	// rule that finishes is never raced by the context
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	linter := &Linter{}
+	matches, err := linter.runRule(ctx, func() []rules.Match {
+		cancel()
+		return []rules.Match{{Message: "finished"}}
+	})
+	if err != nil || len(matches) != 1 {
+		t.Errorf("Expected the rule's match, got %v, %v", matches, err)
+	}
+}
+
+func TestLintFilesContextCancelled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.yaml")
+	if err := os.WriteFile(path, []byte("Resources:\n  B:\n    Type: AWS::S3::Bucket\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	linter := &Linter{options: Options{Jobs: 1}, rules: []rules.Rule{&resourceMockRule{baseMockRule{"W9001"}}}}
+	results := linter.LintFilesContext(ctx, []string{path, path})
+	for i, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Result %d: expected context.Canceled, got %v", i, result.Err)
+		}
+	}
+}
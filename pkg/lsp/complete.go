@@ -0,0 +1,137 @@
+package lsp
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/lex00/cfn-lint-go/pkg/schema"
+)
+
+var (
+	// "!GetAtt Resource.Attr" and "!GetAtt Resource"
+	getAttDotted = regexp.MustCompile(`!GetAtt\s+["']?([\w-]+)\.([\w.]*)$`)
+	getAttName   = regexp.MustCompile(`!GetAtt\s+["']?([\w-]*)$`)
+
+	// "Fn::GetAtt: [Resource, Attr]" and "!GetAtt [Resource, Attr]"
+	getAttListAttr = regexp.MustCompile(`(?:Fn::GetAtt["']?\s*:|!GetAtt)\s*\[\s*["']?([\w-]+)["']?\s*,\s*["']?([\w.]*)$`)
+	getAttListName = regexp.MustCompile(`(?:Fn::GetAtt["']?\s*:|!GetAtt)\s*\[\s*["']?([\w-]*)$`)
+)
+
+// completeAt returns completion items for a position: GetAtt resources and
+// attributes, resource types after "Type:" and property names inside a
+// resource's Properties.
+func completeAt(text string, pos Position) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	lines := splitLines(text)
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return items
+	}
+	c := cursorAt(lines, pos)
+	end := len(c.before)
+
+	for _, re := range []*regexp.Regexp{getAttDotted, getAttListAttr} {
+		if m := re.FindStringSubmatch(c.before); m != nil {
+			return attributeItems(lines, m[1], lineRange(lines, pos.Line, end-len(m[2]), end))
+		}
+	}
+	for _, re := range []*regexp.Regexp{getAttName, getAttListName} {
+		if m := re.FindStringSubmatch(c.before); m != nil {
+			return resourceItems(lines, lineRange(lines, pos.Line, end-len(m[1]), end))
+		}
+	}
+
+	switch {
+	case c.inValue && c.key == "Type" && isResource(c.path):
+		return resourceTypeItems(lineRange(lines, pos.Line, min(c.valueStart, end), end))
+	case !c.inValue && isProperties(c.path):
+		suffix := ": "
+		if c.key != "" {
+			suffix = "" // the key already has its colon
+		}
+		return propertyItems(resourceType(lines, c.path[1]), lineRange(lines, pos.Line, min(c.keyStart, end), end), suffix)
+	}
+	return items
+}
+
+// attributeItems completes the GetAtt attributes of a resource.
+func attributeItems(lines []string, resource string, r Range) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	rt, err := schema.GetResourceType(resourceType(lines, resource))
+	if err != nil || rt == nil {
+		return items
+	}
+	for _, name := range sortedKeys(rt.Attributes) {
+		items = append(items, CompletionItem{
+			Label:    name,
+			Kind:     CompletionField,
+			Detail:   attributeTypeName(rt.Attributes[name]),
+			TextEdit: &TextEdit{Range: r, NewText: name},
+		})
+	}
+	return items
+}
+
+// resourceItems completes the logical IDs of the template's resources.
+func resourceItems(lines []string, r Range) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	for _, name := range resourceNames(lines) {
+		items = append(items, CompletionItem{
+			Label:    name,
+			Kind:     CompletionVariable,
+			Detail:   resourceType(lines, name),
+			TextEdit: &TextEdit{Range: r, NewText: name},
+		})
+	}
+	return items
+}
+
+// resourceTypeItems completes resource type names.
+func resourceTypeItems(r Range) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	names, err := schema.ResourceTypeNames()
+	if err != nil {
+		return items
+	}
+	for _, name := range names {
+		items = append(items, CompletionItem{
+			Label:    name,
+			Kind:     CompletionClass,
+			TextEdit: &TextEdit{Range: r, NewText: name},
+		})
+	}
+	return items
+}
+
+// propertyItems completes the property names of a resource type.
+func propertyItems(typeName string, r Range, suffix string) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	rt, err := schema.GetResourceType(typeName)
+	if err != nil || rt == nil {
+		return items
+	}
+	for _, name := range sortedKeys(rt.Properties) {
+		prop := rt.Properties[name]
+		detail := propertyTypeName(prop)
+		if prop.Required {
+			detail += " (required)"
+		}
+		doc := markdown(propertyDoc(name, typeName, prop))
+		items = append(items, CompletionItem{
+			Label:         name,
+			Kind:          CompletionProperty,
+			Detail:        detail,
+			Documentation: &doc,
+			TextEdit:      &TextEdit{Range: r, NewText: name + suffix},
+		})
+	}
+	return items
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
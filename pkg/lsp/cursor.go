@@ -0,0 +1,189 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// cursor describes where in a YAML template a position is. It is worked out
+// from indentation rather than by parsing, so it also works while the
+// template is being edited and does not parse.
+type cursor struct {
+	// path lists the keys of the mappings enclosing the cursor line, e.g.
+	// ["Resources", "MyBucket", "Properties"].
+	path []string
+
+	// key is the mapping key on the cursor line, if any, and keyStart the
+	// byte offset the line's key starts, or would start, at.
+	key      string
+	keyStart int
+
+	// inValue is set when the cursor is after the key's colon; valueStart
+	// is the byte offset the value starts at.
+	inValue    bool
+	valueStart int
+
+	// before is the text of the line before the cursor.
+	before string
+}
+
+// keyLine matches a line holding a mapping key, after any list markers.
+var keyLine = regexp.MustCompile(`^(?:"([^"]*)"|'([^']*)'|([^\s"'#][^#]*?))\s*:(?:\s+|$)`)
+
+// splitLines splits text into lines without line terminators.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// parseKey returns the indentation of a line's content, skipping list
+// markers ("- "), and the mapping key it holds, if any.
+func parseKey(line string) (indent int, key string, rest string, ok bool) {
+	indent = len(line) - len(strings.TrimLeft(line, " "))
+	content := line[indent:]
+	for strings.HasPrefix(content, "- ") || content == "-" {
+		trimmed := strings.TrimLeft(content[1:], " ")
+		indent += len(content) - len(trimmed)
+		content = trimmed
+	}
+	m := keyLine.FindStringSubmatch(content)
+	if m == nil {
+		return indent, "", content, false
+	}
+	key = m[1] + m[2] + m[3]
+	return indent, key, content[len(m[0]):], true
+}
+
+// cursorAt returns the cursor context of a position, whose character is
+// counted in UTF-16 code units.
+func cursorAt(lines []string, pos Position) cursor {
+	var c cursor
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return c
+	}
+	line := lines[pos.Line]
+	char := byteOffset(line, pos.Character)
+	c.before = line[:char]
+
+	indent, key, rest, ok := parseKey(line)
+	if strings.TrimSpace(line) == "" {
+		// A blank line: the cursor column is where the next key goes
+		indent = char
+	}
+	c.keyStart = indent
+	if ok {
+		c.key = key
+		c.valueStart = len(line) - len(rest)
+		colonEnd := len(strings.TrimRight(line[:c.valueStart], " \t"))
+		c.inValue = char >= colonEnd
+	}
+
+	// Walk up to each enclosing key with less indentation
+	for l := pos.Line - 1; l >= 0 && indent > 0; l-- {
+		text := lines[l]
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		parentIndent, parentKey, _, ok := parseKey(text)
+		lineIndent := len(text) - len(strings.TrimLeft(text, " "))
+		if lineIndent >= indent {
+			continue
+		}
+		if !ok {
+			// A list item or scalar at a lower level: not a mapping
+			indent = lineIndent
+			continue
+		}
+		if parentIndent >= indent {
+			// "- key:" whose key is a sibling of the cursor line
+			indent = lineIndent
+			continue
+		}
+		c.path = append([]string{parentKey}, c.path...)
+		indent = lineIndent
+	}
+	return c
+}
+
+// resourceType returns the Type of a resource, found by scanning the
+// template's Resources section.
+func resourceType(lines []string, name string) string {
+	inResources, inResource := false, false
+	resourceIndent, childIndent := -1, -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		indent, key, rest, ok := parseKey(line)
+		if indent == 0 {
+			inResources, inResource = ok && key == "Resources", false
+			continue
+		}
+		if !inResources || !ok {
+			continue
+		}
+		if resourceIndent < 0 {
+			resourceIndent = indent
+		}
+		switch {
+		case indent <= resourceIndent:
+			inResource, childIndent = key == name, -1
+		case inResource:
+			if childIndent < 0 {
+				childIndent = indent
+			}
+			if indent == childIndent && key == "Type" {
+				return unquote(strings.TrimSpace(stripComment(rest)))
+			}
+		}
+	}
+	return ""
+}
+
+// resourceNames returns the logical IDs of the resources in a template.
+func resourceNames(lines []string) []string {
+	var names []string
+	inResources := false
+	resourceIndent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		indent, key, _, ok := parseKey(line)
+		if indent == 0 {
+			inResources = ok && key == "Resources"
+			continue
+		}
+		if !inResources || !ok {
+			continue
+		}
+		if resourceIndent < 0 {
+			resourceIndent = indent
+		}
+		if indent == resourceIndent {
+			names = append(names, key)
+		}
+	}
+	return names
+}
+
+func stripComment(s string) string {
+	if i := strings.Index(s, " #"); i >= 0 {
+		return s[:i]
+	}
+	if strings.HasPrefix(s, "#") {
+		return ""
+	}
+	return s
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
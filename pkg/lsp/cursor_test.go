@@ -0,0 +1,159 @@
+package lsp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const cursorTemplate = `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: data
+      Tags:
+        - Key: Team
+          Value: data
+      
+  "Topic":
+    Type: 'AWS::SNS::Topic' # notifications
+Outputs:
+  Arn:
+    Value: !GetAtt Bucket.
+`
+
+func TestCursorAt(t *testing.T) {
+	lines := splitLines(cursorTemplate)
+
+	tests := []struct {
+		name    string
+		pos     Position
+		path    []string
+		key     string
+		inValue bool
+	}{
+		{"resource type value", Position{Line: 2, Character: 12}, []string{"Resources", "Bucket"}, "Type", true},
+		{"property key", Position{Line: 4, Character: 8}, []string{"Resources", "Bucket", "Properties"}, "BucketName", false},
+		{"list item key", Position{Line: 7, Character: 12}, []string{"Resources", "Bucket", "Properties", "Tags"}, "Value", false},
+		{"blank line", Position{Line: 8, Character: 6}, []string{"Resources", "Bucket", "Properties"}, "", false},
+		{"top level", Position{Line: 11, Character: 2}, nil, "Outputs", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := cursorAt(lines, tc.pos)
+			if !reflect.DeepEqual(c.path, tc.path) || c.key != tc.key || c.inValue != tc.inValue {
+				t.Errorf("Expected path %v key %q inValue %v, got path %v key %q inValue %v",
+					tc.path, tc.key, tc.inValue, c.path, c.key, c.inValue)
+			}
+		})
+	}
+}
+
+func TestResourceType(t *testing.T) {
+	lines := splitLines(cursorTemplate)
+	if got := resourceType(lines, "Bucket"); got != "AWS::S3::Bucket" {
+		t.Errorf("Expected AWS::S3::Bucket, got %q", got)
+	}
+	if got := resourceType(lines, "Topic"); got != "AWS::SNS::Topic" {
+		t.Errorf("Expected quoted type without comment, got %q", got)
+	}
+	if got := resourceNames(lines); !reflect.DeepEqual(got, []string{"Bucket", "Topic"}) {
+		t.Errorf("Expected resource names, got %v", got)
+	}
+}
+
+func labels(items []CompletionItem) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Label)
+	}
+	return out
+}
+
+func TestCompleteAt(t *testing.T) {
+	t.Run("properties", func(t *testing.T) {
+		items := completeAt(cursorTemplate, Position{Line: 8, Character: 6})
+		if !contains(labels(items), "BucketName") {
+			t.Fatalf("Expected BucketName, got %v", labels(items))
+		}
+		if items[0].TextEdit == nil || !strings.HasSuffix(items[0].TextEdit.NewText, ": ") {
+			t.Errorf("Expected a key edit, got %+v", items[0].TextEdit)
+		}
+	})
+
+	t.Run("resource types", func(t *testing.T) {
+		items := completeAt(cursorTemplate, Position{Line: 2, Character: 14})
+		if !contains(labels(items), "AWS::SNS::Topic") {
+			t.Fatalf("Expected resource types, got %v", labels(items))
+		}
+		if edit := items[0].TextEdit; edit.Range.Start.Character != 10 || edit.Range.End.Character != 14 {
+			t.Errorf("Expected the typed value to be replaced, got %+v", edit.Range)
+		}
+	})
+
+	t.Run("GetAtt attributes", func(t *testing.T) {
+		items := completeAt(cursorTemplate, Position{Line: 13, Character: 26})
+		if !contains(labels(items), "Arn") {
+			t.Errorf("Expected bucket attributes, got %v", labels(items))
+		}
+	})
+
+	t.Run("GetAtt resources", func(t *testing.T) {
+		text := strings.Replace(cursorTemplate, "!GetAtt Bucket.", "!GetAtt [To", 1)
+		items := completeAt(text, Position{Line: 13, Character: 22})
+		if !reflect.DeepEqual(labels(items), []string{"Bucket", "Topic"}) {
+			t.Errorf("Expected resource names, got %v", labels(items))
+		}
+	})
+
+	t.Run("nothing", func(t *testing.T) {
+		if items := completeAt(cursorTemplate, Position{Line: 0, Character: 3}); len(items) != 0 {
+			t.Errorf("Expected no items, got %v", labels(items))
+		}
+	})
+}
+
+func TestHoverAt(t *testing.T) {
+	hover := hoverAt(cursorTemplate, Position{Line: 4, Character: 8})
+	if hover == nil || !strings.Contains(hover.Contents.Value, "**BucketName** `String`") {
+		t.Fatalf("Expected property documentation, got %+v", hover)
+	}
+
+	hover = hoverAt(cursorTemplate, Position{Line: 2, Character: 14})
+	if hover == nil || !strings.HasPrefix(hover.Contents.Value, "**AWS::S3::Bucket**") {
+		t.Fatalf("Expected resource type documentation, got %+v", hover)
+	}
+	if hover.Range.Start.Character != 10 || hover.Range.End.Character != 25 {
+		t.Errorf("Expected hover range over the type, got %+v", hover.Range)
+	}
+
+	if hover := hoverAt(cursorTemplate, Position{Line: 12, Character: 3}); hover != nil {
+		t.Errorf("Expected no hover on an output, got %+v", hover)
+	}
+}
+
+func TestPositionEncoding(t *testing.T) {
+	line := "a\u00e9\U0001F600b"
+	if got := byteOffset(line, 4); got != 7 {
+		t.Errorf("Expected byte offset 7, got %d", got)
+	}
+	if got := byteOffset(line, 10); got != len(line) {
+		t.Errorf("Expected offsets past the end to clamp, got %d", got)
+	}
+	if got := utf16Offset(line, 7); got != 4 {
+		t.Errorf("Expected UTF-16 offset 4, got %d", got)
+	}
+	if got := charOffset(line, 3); got != 4 {
+		t.Errorf("Expected UTF-16 offset 4 for character 3, got %d", got)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+// Package lsp implements a Language Server Protocol server for
+// CloudFormation templates.
+//
+// The server speaks JSON-RPC with Content-Length framing, as editors expect
+// from a language server started on stdio. It keeps open documents in
+// memory with full text synchronization and lints each new version in the
+// background, publishing the matches as diagnostics. Match levels map to
+// LSP severities: Error to Error, Warning to Warning and Informational to
+// Information. Matches located in other files, such as parameter file
+// matches from E0200 and E2900, are not published.
+//
+// For YAML templates the server also answers hover and completion requests
+// from the CloudFormation resource schema loaded by the schema package:
+//
+//   - hovering a resource Type shows its documentation and required
+//     properties; hovering a property name shows its type, whether it is
+//     required and its documentation
+//   - completion offers resource types after "Type:", property names inside
+//     a resource's Properties, and resources and attributes in !GetAtt and
+//     Fn::GetAtt
+//
+// The cursor context is worked out from indentation, so hover and
+// completion keep working while a template does not parse.
+//
+// # Usage
+//
+//	server := lsp.NewServer(lint.New(lint.Options{}), version)
+//	if err := server.Serve(ctx, os.Stdin, os.Stdout); err != nil {
+//	    log.Fatal(err)
+//	}
+package lsp
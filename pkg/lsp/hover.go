@@ -0,0 +1,125 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lex00/cloudformation-schema-go/spec"
+
+	"github.com/lex00/cfn-lint-go/pkg/schema"
+)
+
+// hoverAt returns schema documentation for the resource type or resource
+// property at a position, or nil if there is none.
+func hoverAt(text string, pos Position) *Hover {
+	lines := splitLines(text)
+	c := cursorAt(lines, pos)
+	if c.key == "" {
+		return nil
+	}
+	line := lines[pos.Line]
+
+	switch {
+	case c.inValue && c.key == "Type" && isResource(c.path):
+		value := strings.TrimRight(stripComment(line[c.valueStart:]), " \t")
+		typeName := unquote(value)
+		rt, err := schema.GetResourceType(typeName)
+		if err != nil || rt == nil {
+			return nil
+		}
+		r := lineRange(lines, pos.Line, c.valueStart, c.valueStart+len(value))
+		return &Hover{Contents: markdown(resourceTypeDoc(typeName, rt)), Range: &r}
+
+	case !c.inValue && isProperties(c.path):
+		typeName := resourceType(lines, c.path[1])
+		prop, err := schema.GetProperty(typeName, c.key)
+		if err != nil || prop == nil {
+			return nil
+		}
+		r := lineRange(lines, pos.Line, c.keyStart, c.keyStart+len(c.key))
+		return &Hover{Contents: markdown(propertyDoc(c.key, typeName, prop)), Range: &r}
+	}
+	return nil
+}
+
+// isResource reports whether path is the path of a resource definition.
+func isResource(path []string) bool {
+	return len(path) == 2 && path[0] == "Resources"
+}
+
+// isProperties reports whether path is the path of a resource's
+// Properties.
+func isProperties(path []string) bool {
+	return len(path) == 3 && path[0] == "Resources" && path[2] == "Properties"
+}
+
+// resourceTypeDoc describes a resource type in markdown.
+func resourceTypeDoc(name string, rt *spec.ResourceType) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**", name)
+	if doc := documentation(rt.Documentation); doc != "" {
+		fmt.Fprintf(&b, "\n\n%s", doc)
+	}
+
+	required := rt.GetRequiredProperties()
+	sort.Strings(required)
+	if len(required) > 0 {
+		fmt.Fprintf(&b, "\n\nRequired properties: `%s`", strings.Join(required, "`, `"))
+	}
+	return b.String()
+}
+
+// propertyDoc describes a resource property in markdown.
+func propertyDoc(name, resourceType string, prop *spec.Property) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** `%s`", name, propertyTypeName(prop))
+	if prop.Required {
+		b.WriteString(" (required)")
+	}
+	fmt.Fprintf(&b, "\n\n%s property", resourceType)
+	if prop.UpdateType != "" {
+		fmt.Fprintf(&b, ", update requires: %s", prop.UpdateType)
+	}
+	if doc := documentation(prop.Documentation); doc != "" {
+		fmt.Fprintf(&b, "\n\n%s", doc)
+	}
+	return b.String()
+}
+
+// documentation renders a schema documentation field: the specification
+// holds documentation URLs, which become links.
+func documentation(doc string) string {
+	if strings.HasPrefix(doc, "http://") || strings.HasPrefix(doc, "https://") {
+		return fmt.Sprintf("[Documentation](%s)", doc)
+	}
+	return doc
+}
+
+// propertyTypeName describes the type of a property, e.g. "String" or
+// "List of Tag".
+func propertyTypeName(prop *spec.Property) string {
+	return typeName(prop.PrimitiveType, prop.Type, prop.PrimitiveItemType, prop.ItemType)
+}
+
+// attributeTypeName describes the type of a GetAtt attribute.
+func attributeTypeName(attr *spec.Attribute) string {
+	return typeName(attr.PrimitiveType, attr.Type, attr.PrimitiveItemType, attr.ItemType)
+}
+
+func typeName(primitive, typ, primitiveItem, item string) string {
+	if primitive != "" {
+		return primitive
+	}
+	if primitiveItem != "" {
+		item = primitiveItem
+	}
+	if (typ == "List" || typ == "Map") && item != "" {
+		return typ + " of " + item
+	}
+	return typ
+}
+
+func markdown(value string) MarkupContent {
+	return MarkupContent{Kind: "markdown", Value: value}
+}
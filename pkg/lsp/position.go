@@ -0,0 +1,58 @@
+package lsp
+
+import "unicode/utf16"
+
+// LSP positions count characters in UTF-16 code units, the default
+// position encoding. The cursor works in byte offsets into a line and lint
+// matches count columns in characters, so positions are converted at the
+// protocol boundary.
+
+// byteOffset returns the byte offset in line of a UTF-16 character offset.
+// Offsets past the end of the line are clamped to its length.
+func byteOffset(line string, units int) int {
+	n := 0
+	for i, r := range line {
+		if n >= units {
+			return i
+		}
+		n += utf16.RuneLen(r)
+	}
+	return len(line)
+}
+
+// utf16Offset returns the UTF-16 character offset of byte offset i in
+// line.
+func utf16Offset(line string, i int) int {
+	n := 0
+	for j, r := range line {
+		if j >= i {
+			break
+		}
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// charOffset returns the UTF-16 character offset of a zero-based offset
+// counted in characters. Offsets past the end of the line count one unit
+// per character.
+func charOffset(line string, chars int) int {
+	n := 0
+	for _, r := range line {
+		if chars <= 0 {
+			return n
+		}
+		n += utf16.RuneLen(r)
+		chars--
+	}
+	return n + max(chars, 0)
+}
+
+// lineRange returns the range between two byte offsets in a line.
+func lineRange(lines []string, line, start, end int) Range {
+	text := lines[line]
+	return Range{
+		Start: Position{Line: line, Character: utf16Offset(text, start)},
+		End:   Position{Line: line, Character: utf16Offset(text, end)},
+	}
+}
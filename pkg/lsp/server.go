@@ -0,0 +1,357 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+)
+
+// diagnosticSource is the source reported with every diagnostic.
+const diagnosticSource = "cfn-lint"
+
+// Server is a Language Server Protocol server for CloudFormation templates.
+// It lints open documents from their in-memory content and answers hover
+// and completion requests from the CloudFormation resource schema.
+type Server struct {
+	linter  *lint.Linter
+	version string
+
+	mu   sync.Mutex // guards docs and writes to out
+	docs map[string]*document
+	out  io.Writer
+	wg   sync.WaitGroup
+}
+
+// document is an open text document.
+type document struct {
+	version int
+	text    string
+	cancel  context.CancelFunc // stops the running lint, if any
+}
+
+// NewServer returns a server that lints documents with linter. version is
+// reported to the client as the server version.
+func NewServer(linter *lint.Linter, version string) *Server {
+	return &Server{
+		linter:  linter,
+		version: version,
+		docs:    make(map[string]*document),
+	}
+}
+
+// Serve reads requests from in and writes responses and notifications to
+// out until the client sends exit, in is closed or ctx is canceled.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.wg.Wait()
+	}()
+	s.out = out
+
+	messages := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		r := bufio.NewReader(in)
+		for {
+			data, err := readMessage(r)
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case messages <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var data []byte
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case data = <-messages:
+		}
+
+		var req request
+		if err := json.Unmarshal(data, &req); err != nil {
+			s.respond(json.RawMessage("null"), nil, &rpcError{Code: codeParseError, Message: err.Error()})
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(ctx, req)
+		if req.ID == nil {
+			continue // notifications get no response
+		}
+		s.respond(req.ID, result, err)
+	}
+}
+
+// handle dispatches a request or notification.
+func (s *Server) handle(ctx context.Context, req request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync: 1,
+				HoverProvider:    true,
+				CompletionProvider: &CompletionOptions{
+					TriggerCharacters: []string{".", ":", " "},
+				},
+			},
+			ServerInfo: ServerInfo{Name: "cfn-lint", Version: s.version},
+		}, nil
+
+	case "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		s.update(ctx, params.TextDocument.URI, params.TextDocument.Version, params.TextDocument.Text)
+		return nil, nil
+
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.update(ctx, params.TextDocument.URI, params.TextDocument.Version, params.ContentChanges[n-1].Text)
+		}
+		return nil, nil
+
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		s.close(params.TextDocument.URI)
+		return nil, nil
+
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		text, ok := s.text(params.TextDocument.URI)
+		if !ok {
+			return nil, nil
+		}
+		if hover := hoverAt(text, params.Position); hover != nil {
+			return hover, nil
+		}
+		return nil, nil
+
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		text, _ := s.text(params.TextDocument.URI)
+		return CompletionList{Items: completeAt(text, params.Position)}, nil
+	}
+
+	if strings.HasPrefix(req.Method, "$/") || req.ID == nil {
+		// Optional notifications, such as $/cancelRequest or initialized
+		return nil, nil
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+}
+
+func unmarshalParams(req request, v any) error {
+	if err := json.Unmarshal(req.Params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid %s params: %v", req.Method, err)}
+	}
+	return nil
+}
+
+// update stores new document content and lints it in the background,
+// canceling the lint of the previous version.
+func (s *Server) update(ctx context.Context, uri string, version int, text string) {
+	lintCtx, cancel := context.WithCancel(ctx)
+
+	s.mu.Lock()
+	if doc, ok := s.docs[uri]; ok {
+		doc.cancel()
+	}
+	s.docs[uri] = &document{version: version, text: text, cancel: cancel}
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		path := uriToPath(uri)
+		matches, _ := s.linter.LintBytesContext(lintCtx, []byte(text), path)
+		if lintCtx.Err() != nil {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// A newer version may have been stored while linting
+		if doc, ok := s.docs[uri]; !ok || doc.version != version || doc.text != text {
+			return
+		}
+		s.write(notification{
+			JSONRPC: "2.0",
+			Method:  "textDocument/publishDiagnostics",
+			Params:  PublishDiagnosticsParams{URI: uri, Version: version, Diagnostics: Diagnostics(text, documentMatches(matches, path))},
+		})
+	}()
+}
+
+// close forgets a document and clears its diagnostics.
+func (s *Server) close(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if doc, ok := s.docs[uri]; ok {
+		doc.cancel()
+		delete(s.docs, uri)
+	}
+	s.write(notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  PublishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}},
+	})
+}
+
+// text returns the content of an open document.
+func (s *Server) text(uri string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, ok := s.docs[uri]
+	if !ok {
+		return "", false
+	}
+	return doc.text, true
+}
+
+func (s *Server) respond(id json.RawMessage, result any, err error) {
+	resp := response{JSONRPC: "2.0", ID: id}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInvalidRequest, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+	} else {
+		data, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			resp.Error = &rpcError{Code: codeInvalidRequest, Message: marshalErr.Error()}
+		} else {
+			resp.Result = data
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.write(resp)
+}
+
+// write sends a message. The caller must hold s.mu.
+func (s *Server) write(msg any) {
+	// Write errors mean the client is gone; Serve ends when input closes
+	_ = writeMessage(s.out, msg)
+}
+
+// Diagnostics converts the lint matches of a document's text to LSP
+// diagnostics. Lines and columns become zero-based and columns are counted
+// in UTF-16 code units; a match without an end position covers one
+// character.
+func Diagnostics(text string, matches []lint.Match) []Diagnostic {
+	lines := splitLines(text)
+	diagnostics := make([]Diagnostic, 0, len(matches))
+	for _, m := range matches {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    matchRange(lines, m.Location),
+			Severity: Severity(m.Level),
+			Code:     m.Rule.ID,
+			Source:   diagnosticSource,
+			Message:  m.Message,
+		})
+	}
+	return diagnostics
+}
+
+// documentMatches returns the matches located in the document at path.
+// Matches in other files, such as E0200 and E2900 matches in parameter
+// files, would point at the wrong lines of the document.
+func documentMatches(matches []lint.Match, path string) []lint.Match {
+	var out []lint.Match
+	for _, m := range matches {
+		if m.Location.Filename == path {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Severity maps a lint match level to an LSP diagnostic severity.
+func Severity(level string) DiagnosticSeverity {
+	switch level {
+	case "Error":
+		return SeverityError
+	case "Warning":
+		return SeverityWarning
+	case "Informational":
+		return SeverityInformation
+	}
+	return SeverityHint
+}
+
+func matchRange(lines []string, loc lint.MatchLocation) Range {
+	start := position(lines, max(loc.Start.LineNumber-1, 0), max(loc.Start.ColumnNumber-1, 0))
+	end := position(lines, loc.End.LineNumber-1, loc.End.ColumnNumber-1)
+	if end.Line < start.Line || (end.Line == start.Line && end.Character <= start.Character) {
+		end = Position{Line: start.Line, Character: start.Character + 1}
+	}
+	return Range{Start: start, End: end}
+}
+
+// position returns the LSP position of a zero-based line and character
+// column. Lines outside the text keep the column unchanged.
+func position(lines []string, line, column int) Position {
+	if line < 0 || line >= len(lines) {
+		return Position{Line: line, Character: column}
+	}
+	return Position{Line: line, Character: charOffset(lines[line], column)}
+}
+
+// uriToPath returns the file path of a file: URI, which is used as the
+// file name in matches and for template-relative settings. Other URIs are
+// returned unchanged.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	path := u.Path
+	// file:///C:/dir on Windows
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
@@ -0,0 +1,230 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lex00/cfn-lint-go/pkg/lint"
+	"github.com/lex00/cfn-lint-go/pkg/rules"
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// badNameRule reports resources named "Bad".
+type badNameRule struct{}
+
+func (r *badNameRule) ID() string          { return "E9901" }
+func (r *badNameRule) ShortDesc() string   { return "Bad name" }
+func (r *badNameRule) Description() string { return "Reports resources named Bad" }
+func (r *badNameRule) Source() string      { return "" }
+func (r *badNameRule) Tags() []string      { return nil }
+func (r *badNameRule) Match(tmpl *template.Template) []rules.Match {
+	res, ok := tmpl.Resources["Bad"]
+	if !ok {
+		return nil
+	}
+	return []rules.Match{{
+		Message: "Resource is named Bad",
+		Line:    res.Node.Line,
+		Column:  res.Node.Column,
+		Path:    []string{"Resources", "Bad"},
+	}}
+}
+
+func init() {
+	rules.Register(&badNameRule{})
+}
+
+// client drives a server over in-memory pipes.
+type client struct {
+	t    *testing.T
+	in   *io.PipeWriter
+	out  *bufio.Reader
+	done chan error
+}
+
+func startServer(t *testing.T) *client {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &client{t: t, in: inW, out: bufio.NewReader(outR), done: make(chan error, 1)}
+
+	server := NewServer(lint.New(lint.Options{}), "test")
+	go func() {
+		c.done <- server.Serve(context.Background(), inR, outW)
+		outW.Close()
+	}()
+	t.Cleanup(func() { inW.Close() })
+	return c
+}
+
+func (c *client) send(id int, method string, params any) {
+	c.t.Helper()
+	msg := map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+	if id != 0 {
+		msg["id"] = id
+	}
+	if err := writeMessage(c.in, msg); err != nil {
+		c.t.Fatalf("sending %s: %v", method, err)
+	}
+}
+
+// receive reads the next message into v.
+func (c *client) receive(v any) {
+	c.t.Helper()
+	data, err := readMessage(c.out)
+	if err != nil {
+		c.t.Fatalf("reading message: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		c.t.Fatalf("decoding %s: %v", data, err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	c := startServer(t)
+
+	c.send(1, "initialize", map[string]any{})
+	var initResp struct {
+		ID     int              `json:"id"`
+		Result InitializeResult `json:"result"`
+	}
+	c.receive(&initResp)
+	if initResp.ID != 1 || !initResp.Result.Capabilities.HoverProvider || initResp.Result.Capabilities.TextDocumentSync != 1 {
+		t.Errorf("Unexpected initialize result: %+v", initResp)
+	}
+	c.send(0, "initialized", map[string]any{})
+
+	uri := "file:///project/template.yaml"
+	c.send(0, "textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{
+		URI: uri, LanguageID: "yaml", Version: 1,
+		Text: "Resources:\n  Bad:\n    Type: AWS::SNS::Topic\n",
+	}})
+	var published struct {
+		Method string                   `json:"method"`
+		Params PublishDiagnosticsParams `json:"params"`
+	}
+	c.receive(&published)
+	if published.Method != "textDocument/publishDiagnostics" || published.Params.URI != uri || published.Params.Version != 1 {
+		t.Fatalf("Unexpected notification: %+v", published)
+	}
+	var found *Diagnostic
+	for i, d := range published.Params.Diagnostics {
+		if d.Code == "E9901" {
+			found = &published.Params.Diagnostics[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("Expected E9901 diagnostic, got %+v", published.Params.Diagnostics)
+	}
+	if found.Severity != SeverityError || found.Source != "cfn-lint" || found.Range.Start != (Position{Line: 2, Character: 4}) {
+		t.Errorf("Unexpected diagnostic: %+v", *found)
+	}
+
+	// The fixed version clears the diagnostic
+	c.send(0, "textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: 2},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: "Resources:\n  Good:\n    Type: AWS::SNS::Topic\n"}},
+	})
+	c.receive(&published)
+	for _, d := range published.Params.Diagnostics {
+		if d.Code == "E9901" {
+			t.Errorf("Expected E9901 to be fixed in version %d", published.Params.Version)
+		}
+	}
+
+	c.send(2, "unknown/method", nil)
+	var errResp struct {
+		ID    int       `json:"id"`
+		Error *rpcError `json:"error"`
+	}
+	c.receive(&errResp)
+	if errResp.ID != 2 || errResp.Error == nil || errResp.Error.Code != codeMethodNotFound {
+		t.Errorf("Expected method not found, got %+v", errResp)
+	}
+
+	c.send(3, "shutdown", nil)
+	var shutdown map[string]any
+	c.receive(&shutdown)
+	if _, ok := shutdown["result"]; !ok {
+		t.Errorf("Expected a null result for shutdown, got %v", shutdown)
+	}
+
+	c.send(0, "exit", nil)
+	select {
+	case err := <-c.done:
+		if err != nil {
+			t.Errorf("Serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after exit")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	matches := []lint.Match{
+		{
+			Rule:  lint.MatchRule{ID: "W1001"},
+			Level: "Warning",
+			Location: lint.MatchLocation{
+				Start: lint.MatchPosition{LineNumber: 3, ColumnNumber: 5},
+				End:   lint.MatchPosition{LineNumber: 3, ColumnNumber: 9},
+			},
+		},
+		{
+			Rule:     lint.MatchRule{ID: "I3011"},
+			Level:    "Informational",
+			Location: lint.MatchLocation{Start: lint.MatchPosition{LineNumber: 1, ColumnNumber: 1}},
+		},
+		{
+			Rule:  lint.MatchRule{ID: "E3012"},
+			Level: "Error",
+			Location: lint.MatchLocation{
+				Start: lint.MatchPosition{LineNumber: 3, ColumnNumber: 10},
+				End:   lint.MatchPosition{LineNumber: 3, ColumnNumber: 11},
+			},
+		},
+	}
+
+	diagnostics := Diagnostics("a: 1\nb: 2\n  Name: \U0001F600x\n", matches)
+	want := []Range{
+		{Start: Position{Line: 2, Character: 4}, End: Position{Line: 2, Character: 8}},
+		{Start: Position{Line: 0, Character: 0}, End: Position{Line: 0, Character: 1}},
+		// the emoji before the match is two UTF-16 code units
+		{Start: Position{Line: 2, Character: 10}, End: Position{Line: 2, Character: 11}},
+	}
+	for i, d := range diagnostics {
+		if d.Range != want[i] {
+			t.Errorf("Diagnostic %d: expected range %+v, got %+v", i, want[i], d.Range)
+		}
+	}
+	if diagnostics[0].Severity != SeverityWarning || diagnostics[1].Severity != SeverityInformation {
+		t.Errorf("Unexpected severities: %d, %d", diagnostics[0].Severity, diagnostics[1].Severity)
+	}
+}
+
+func TestDocumentMatches(t *testing.T) {
+	matches := []lint.Match{
+		{Rule: lint.MatchRule{ID: "E3012"}, Location: lint.MatchLocation{Filename: "template.yaml"}},
+		{Rule: lint.MatchRule{ID: "E0200"}, Location: lint.MatchLocation{Filename: "params.json"}},
+	}
+	got := documentMatches(matches, "template.yaml")
+	if len(got) != 1 || got[0].Rule.ID != "E3012" {
+		t.Errorf("Expected only the template's match, got %+v", got)
+	}
+}
+
+func TestURIToPath(t *testing.T) {
+	tests := map[string]string{
+		"file:///project/a%20b.yaml": "/project/a b.yaml",
+		"untitled:Untitled-1":        "untitled:Untitled-1",
+	}
+	for uri, want := range tests {
+		if got := uriToPath(uri); got != want {
+			t.Errorf("uriToPath(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
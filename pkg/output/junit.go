@@ -3,6 +3,7 @@ package output
 import (
 	"encoding/xml"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/lex00/cfn-lint-go/pkg/lint"
@@ -33,6 +34,12 @@ type JUnitTestCase struct {
 	Classname string        `xml:"classname,attr"`
 	Time      string        `xml:"time,attr"`
 	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+}
+
+// JUnitSkipped marks a test case whose match was suppressed.
+type JUnitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
 }
 
 // JUnitFailure represents a test failure.
@@ -63,14 +70,20 @@ func WriteJUnit(w io.Writer, matches []lint.Match) error {
 		failures := 0
 
 		for _, m := range matches {
+			name := m.Rule.ID
+			if len(m.Regions) > 0 {
+				name += " (" + strings.Join(m.Regions, ", ") + ")"
+			}
 			testCase := JUnitTestCase{
-				Name:      m.Rule.ID,
+				Name:      name,
 				Classname: filename,
 				Time:      "0",
 			}
 
-			// Add failure for errors and warnings
-			if m.Level == "Error" || m.Level == "Warning" {
+			// Suppressed matches are skipped; errors and warnings fail
+			if m.Suppression != nil {
+				testCase.Skipped = &JUnitSkipped{Message: m.Suppression.Justification}
+			} else if m.Level == "Error" || m.Level == "Warning" {
 				testCase.Failure = &JUnitFailure{
 					Message: m.Message,
 					Type:    m.Level,
@@ -7,6 +7,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/lex00/cfn-lint-go/pkg/lint"
 )
@@ -20,8 +21,45 @@ const (
 	colorBold   = "\033[1m"
 )
 
+// Sources maps file names to the template content that was linted.
+// Formatters that show source context read it from here rather than from
+// disk, so in-memory and stdin templates get context too.
+type Sources map[string][]byte
+
+// SourcesFromResults collects the linted content of each file result.
+func SourcesFromResults(results []lint.FileResult) Sources {
+	sources := make(Sources, len(results))
+	for _, r := range results {
+		if r.Source != nil {
+			sources[r.Filename] = r.Source
+		}
+	}
+	return sources
+}
+
+// lines returns the source lines of a file. Without sources, the file is
+// read from disk (best effort).
+func (s Sources) lines(filename string) []string {
+	if s == nil {
+		return readFileLines(filename)
+	}
+	data, ok := s[filename]
+	if !ok {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), "\n")
+}
+
 // WritePretty writes matches in a pretty, colorized format with context.
+// Source context is read from disk; use WritePrettyWithSources for
+// templates that were linted from memory.
 func WritePretty(w io.Writer, matches []lint.Match, noColor bool) error {
+	return WritePrettyWithSources(w, matches, nil, noColor)
+}
+
+// WritePrettyWithSources writes matches in a pretty, colorized format,
+// taking source context from sources.
+func WritePrettyWithSources(w io.Writer, matches []lint.Match, sources Sources, noColor bool) error {
 	if len(matches) == 0 {
 		if !noColor {
 			fmt.Fprintf(w, "%s✓ No issues found%s\n", colorBlue, colorReset)
@@ -73,42 +111,53 @@ func WritePretty(w io.Writer, matches []lint.Match, noColor bool) error {
 		}
 		fmt.Fprintln(w, strings.Repeat("─", len(filename)))
 
-		// Read file for context (best effort)
-		fileLines := readFileLines(filename)
+		// Source lines for context (best effort)
+		fileLines := sources.lines(filename)
 
 		// Print each match
 		for _, m := range matches {
 			levelColor := colorRed
 			levelSymbol := "✖"
 
-			switch m.Level {
-			case "Error":
+			switch {
+			case m.Suppression != nil:
+				// Suppressed matches are shown but not counted
+				levelColor = colorGray
+				levelSymbol = "-"
+			case m.Level == "Error":
 				errorCount++
 				levelColor = colorRed
 				levelSymbol = "✖"
-			case "Warning":
+			case m.Level == "Warning":
 				warningCount++
 				levelColor = colorYellow
 				levelSymbol = "⚠"
-			case "Informational":
+			case m.Level == "Informational":
 				infoCount++
 				levelColor = colorBlue
 				levelSymbol = "ℹ"
 			}
 
 			// Print location and rule
+			ruleLabel := m.Rule.ID
+			if len(m.Regions) > 0 {
+				ruleLabel += " " + strings.Join(m.Regions, ",")
+			}
+			if m.Suppression != nil {
+				ruleLabel += " suppressed"
+			}
 			if !noColor {
 				fmt.Fprintf(w, "\n  %s%s%s Line %d:%d - %s[%s]%s\n",
 					levelColor, levelSymbol, colorReset,
 					m.Location.Start.LineNumber,
 					m.Location.Start.ColumnNumber,
-					colorGray, m.Rule.ID, colorReset)
+					colorGray, ruleLabel, colorReset)
 			} else {
 				fmt.Fprintf(w, "\n  %s Line %d:%d - [%s]\n",
 					levelSymbol,
 					m.Location.Start.LineNumber,
 					m.Location.Start.ColumnNumber,
-					m.Rule.ID)
+					ruleLabel)
 			}
 
 			// Print message
@@ -117,10 +166,13 @@ func WritePretty(w io.Writer, matches []lint.Match, noColor bool) error {
 			} else {
 				fmt.Fprintf(w, "  %s\n", m.Message)
 			}
+			if m.Suppression != nil && m.Suppression.Justification != "" {
+				fmt.Fprintf(w, "  Justification: %s\n", m.Suppression.Justification)
+			}
 
 			// Print code context if available
 			if len(fileLines) > 0 && m.Location.Start.LineNumber > 0 {
-				printContext(w, fileLines, m.Location.Start.LineNumber, noColor)
+				printContext(w, fileLines, m.Location, noColor)
 			}
 		}
 	}
@@ -164,17 +216,39 @@ func readFileLines(filename string) []string {
 	return lines
 }
 
-// printContext prints lines around the error location.
-func printContext(w io.Writer, lines []string, lineNum int, noColor bool) {
+// maxSpanLines is the number of lines of a multi-line span that are shown
+// before the rest is elided.
+const maxSpanLines = 6
+
+// printContext prints lines around the match location and underlines the
+// matched span.
+func printContext(w io.Writer, lines []string, loc lint.MatchLocation, noColor bool) {
 	contextBefore := 2
 	contextAfter := 2
 
-	start := lineNum - contextBefore - 1
+	spanStart := loc.Start.LineNumber
+	spanEnd := loc.End.LineNumber
+	if spanEnd < spanStart {
+		spanEnd = spanStart
+	}
+	if spanEnd > len(lines) {
+		spanEnd = len(lines)
+	}
+	elided := false
+	if spanEnd-spanStart+1 > maxSpanLines {
+		spanEnd = spanStart + maxSpanLines - 1
+		elided = true
+	}
+
+	start := spanStart - contextBefore - 1
 	if start < 0 {
 		start = 0
 	}
 
-	end := lineNum + contextAfter
+	end := spanEnd + contextAfter
+	if elided {
+		end = spanEnd
+	}
 	if end > len(lines) {
 		end = len(lines)
 	}
@@ -185,22 +259,71 @@ func printContext(w io.Writer, lines []string, lineNum int, noColor bool) {
 
 	fmt.Fprintln(w)
 	for i := start; i < end; i++ {
-		lineNumStr := fmt.Sprintf("%*d", lineNumWidth, i+1)
+		lineNum := i + 1
+		lineNumStr := fmt.Sprintf("%*d", lineNumWidth, lineNum)
 
-		if i+1 == lineNum {
-			// Highlight the error line
+		if lineNum < spanStart || lineNum > spanEnd {
+			// Context lines
 			if !noColor {
-				fmt.Fprintf(w, "    %s%s │ %s%s\n", colorRed, lineNumStr, lines[i], colorReset)
+				fmt.Fprintf(w, "    %s%s │%s %s\n", colorGray, lineNumStr, colorReset, lines[i])
 			} else {
-				fmt.Fprintf(w, "  > %s │ %s\n", lineNumStr, lines[i])
+				fmt.Fprintf(w, "    %s │ %s\n", lineNumStr, lines[i])
 			}
+			continue
+		}
+
+		// Highlight the matched lines
+		if !noColor {
+			fmt.Fprintf(w, "    %s%s │ %s%s\n", colorRed, lineNumStr, lines[i], colorReset)
 		} else {
-			// Context lines
+			fmt.Fprintf(w, "  > %s │ %s\n", lineNumStr, lines[i])
+		}
+
+		from, to := underlineRange(lines[i], lineNum, loc)
+		if to > from {
+			padding := strings.Repeat(" ", lineNumWidth)
+			underline := strings.Repeat(" ", from) + strings.Repeat("^", to-from)
 			if !noColor {
-				fmt.Fprintf(w, "    %s%s │%s %s\n", colorGray, lineNumStr, colorReset, lines[i])
+				fmt.Fprintf(w, "    %s │ %s%s%s\n", padding, colorRed, underline, colorReset)
 			} else {
-				fmt.Fprintf(w, "    %s │ %s\n", lineNumStr, lines[i])
+				fmt.Fprintf(w, "    %s │ %s\n", padding, underline)
 			}
 		}
 	}
+
+	if elided {
+		fmt.Fprintf(w, "    %s ⋮\n", strings.Repeat(" ", lineNumWidth))
+	}
+}
+
+// underlineRange returns the 0-based character range of a source line
+// covered by a match span, counted in characters like span columns. Lines
+// inside a multi-line span are underlined from their first non-blank
+// character.
+func underlineRange(text string, lineNum int, loc lint.MatchLocation) (int, int) {
+	length := utf8.RuneCountInString(text)
+	from := length - utf8.RuneCountInString(strings.TrimLeft(text, " \t"))
+	to := utf8.RuneCountInString(strings.TrimRight(text, " \t"))
+
+	if lineNum == loc.Start.LineNumber && loc.Start.ColumnNumber > 0 {
+		from = loc.Start.ColumnNumber - 1
+	}
+	switch {
+	case lineNum == loc.End.LineNumber && loc.End.ColumnNumber > 0:
+		to = loc.End.ColumnNumber - 1
+	case loc.End.LineNumber < loc.Start.LineNumber:
+		to = from
+	}
+	if to <= from && lineNum == loc.Start.LineNumber {
+		// No span: underline a single character
+		to = from + 1
+	}
+
+	if to > length {
+		to = length
+	}
+	if from > to {
+		from = to
+	}
+	return from, to
 }
@@ -2,6 +2,8 @@ package output
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -313,3 +315,137 @@ func TestReadFileLines(t *testing.T) {
 		t.Error("Expected nil for non-existent file")
 	}
 }
+
+func TestWritePretty_UnderlinesSpan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.yaml")
+	source := `Resources:
+  MyFunction:
+    Properties:
+      Code:
+        ZipFile: |
+          exports.handler = async () => {};
+          // done
+      Handler: index.handler
+`
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		start lint.MatchPosition
+		end   lint.MatchPosition
+		want  []string
+	}{
+		{
+			name:  "single line span",
+			start: lint.MatchPosition{LineNumber: 8, ColumnNumber: 16},
+			end:   lint.MatchPosition{LineNumber: 8, ColumnNumber: 29},
+			want: []string{
+				"  > 8 │       Handler: index.handler\n      │                ^^^^^^^^^^^^^\n",
+			},
+		},
+		{
+			name:  "multi-line span",
+			start: lint.MatchPosition{LineNumber: 5, ColumnNumber: 9},
+			end:   lint.MatchPosition{LineNumber: 7, ColumnNumber: 18},
+			want: []string{
+				"  > 5 │         ZipFile: |\n      │         ^^^^^^^^^^\n",
+				"  > 6 │           exports.handler = async () => {};\n      │           ^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^\n",
+				"  > 7 │           // done\n      │           ^^^^^^^\n",
+			},
+		},
+		{
+			name:  "position without span",
+			start: lint.MatchPosition{LineNumber: 8, ColumnNumber: 7},
+			want: []string{
+				"  > 8 │       Handler: index.handler\n      │       ^\n",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matches := []lint.Match{
+				{
+					Rule: lint.MatchRule{ID: "E3012"},
+					Location: lint.MatchLocation{
+						Filename: path,
+						Start:    tc.start,
+						End:      tc.end,
+					},
+					Level:   "Error",
+					Message: "Test error",
+				},
+			}
+
+			var buf bytes.Buffer
+			if err := WritePretty(&buf, matches, true); err != nil {
+				t.Fatalf("WritePretty failed: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tc.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain:\n%s\ngot:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestUnderlineRange_NonASCII(t *testing.T) {
+	text := "  Description: déjà vu  "
+	loc := lint.MatchLocation{
+		Start: lint.MatchPosition{LineNumber: 1, ColumnNumber: 3},
+		End:   lint.MatchPosition{LineNumber: 3, ColumnNumber: 5},
+	}
+
+	// A line inside the span is underlined up to its last non-blank
+	// character, counted in characters
+	if from, to := underlineRange(text, 2, loc); from != 2 || to != 22 {
+		t.Errorf("Expected range 2-22, got %d-%d", from, to)
+	}
+
+	// Ends past the line are capped at its length in characters
+	loc = lint.MatchLocation{
+		Start: lint.MatchPosition{LineNumber: 1, ColumnNumber: 16},
+		End:   lint.MatchPosition{LineNumber: 1, ColumnNumber: 40},
+	}
+	if from, to := underlineRange(text, 1, loc); from != 15 || to != 24 {
+		t.Errorf("Expected range 15-24, got %d-%d", from, to)
+	}
+}
+
+func TestWritePrettyWithSources(t *testing.T) {
+	matches := []lint.Match{
+		{
+			Rule: lint.MatchRule{ID: "W2001"},
+			Location: lint.MatchLocation{
+				Filename: "generated.yaml",
+				Start:    lint.MatchPosition{LineNumber: 2, ColumnNumber: 3},
+				End:      lint.MatchPosition{LineNumber: 2, ColumnNumber: 6},
+			},
+			Level:   "Warning",
+			Message: "Parameter Env not used",
+		},
+	}
+	sources := SourcesFromResults([]lint.FileResult{
+		{Filename: "generated.yaml", Source: []byte("Parameters:\r\n  Env:\r\n    Type: String\r\n")},
+		{Filename: "unreadable.yaml"},
+	})
+
+	if _, ok := sources["unreadable.yaml"]; ok {
+		t.Error("Expected files without source to be left out")
+	}
+
+	var buf bytes.Buffer
+	if err := WritePrettyWithSources(&buf, matches, sources, true); err != nil {
+		t.Fatalf("WritePrettyWithSources failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "  > 2 │   Env:\n      │   ^^^\n") {
+		t.Errorf("Expected context from in-memory source, got:\n%s", output)
+	}
+}
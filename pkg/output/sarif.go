@@ -56,10 +56,48 @@ type SARIFProperties struct {
 
 // SARIFResult represents a single result in SARIF format.
 type SARIFResult struct {
-	RuleID    string          `json:"ruleId"`
-	Level     string          `json:"level"`
-	Message   SARIFMessage    `json:"message"`
-	Locations []SARIFLocation `json:"locations"`
+	RuleID       string                 `json:"ruleId"`
+	Level        string                 `json:"level"`
+	Message      SARIFMessage           `json:"message"`
+	Locations    []SARIFLocation        `json:"locations"`
+	Suppressions []SARIFSuppression     `json:"suppressions,omitempty"`
+	Fixes        []SARIFFix             `json:"fixes,omitempty"`
+	Properties   *SARIFResultProperties `json:"properties,omitempty"`
+}
+
+// SARIFFix is a proposed fix for a result.
+type SARIFFix struct {
+	Description     SARIFMessage          `json:"description"`
+	ArtifactChanges []SARIFArtifactChange `json:"artifactChanges"`
+}
+
+// SARIFArtifactChange lists the replacements a fix makes to one file.
+type SARIFArtifactChange struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Replacements     []SARIFReplacement    `json:"replacements"`
+}
+
+// SARIFReplacement replaces a region of a file. An empty deleted region
+// inserts the content; a missing inserted content deletes the region.
+type SARIFReplacement struct {
+	DeletedRegion   SARIFRegion           `json:"deletedRegion"`
+	InsertedContent *SARIFArtifactContent `json:"insertedContent,omitempty"`
+}
+
+// SARIFArtifactContent is text inserted by a replacement.
+type SARIFArtifactContent struct {
+	Text string `json:"text"`
+}
+
+// SARIFSuppression records that a result was suppressed in source.
+type SARIFSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// SARIFResultProperties represents additional result properties.
+type SARIFResultProperties struct {
+	Regions []string `json:"regions,omitempty"`
 }
 
 // SARIFLocation represents a location in SARIF format.
@@ -120,6 +158,16 @@ func WriteSARIF(w io.Writer, matches []lint.Match, version string) error {
 			level = "note"
 		}
 
+		var props *SARIFResultProperties
+		if len(m.Regions) > 0 {
+			props = &SARIFResultProperties{Regions: m.Regions}
+		}
+
+		var suppressions []SARIFSuppression
+		if m.Suppression != nil {
+			suppressions = []SARIFSuppression{{Kind: "inSource", Justification: m.Suppression.Justification}}
+		}
+
 		results = append(results, SARIFResult{
 			RuleID: m.Rule.ID,
 			Level:  level,
@@ -141,6 +189,9 @@ func WriteSARIF(w io.Writer, matches []lint.Match, version string) error {
 					},
 				},
 			},
+			Suppressions: suppressions,
+			Fixes:        sarifFixes(m),
+			Properties:   props,
 		})
 	}
 
@@ -171,3 +222,32 @@ func WriteSARIF(w io.Writer, matches []lint.Match, version string) error {
 	enc.SetIndent("", "  ")
 	return enc.Encode(sarif)
 }
+
+// sarifFixes converts the fix of a match, if any, to SARIF fixes.
+func sarifFixes(m lint.Match) []SARIFFix {
+	if m.Fix == nil {
+		return nil
+	}
+	replacements := make([]SARIFReplacement, 0, len(m.Fix.Edits))
+	for _, e := range m.Fix.Edits {
+		r := SARIFReplacement{
+			DeletedRegion: SARIFRegion{
+				StartLine:   e.Start.LineNumber,
+				StartColumn: e.Start.ColumnNumber,
+				EndLine:     e.End.LineNumber,
+				EndColumn:   e.End.ColumnNumber,
+			},
+		}
+		if e.NewText != "" {
+			r.InsertedContent = &SARIFArtifactContent{Text: e.NewText}
+		}
+		replacements = append(replacements, r)
+	}
+	return []SARIFFix{{
+		Description: SARIFMessage{Text: m.Fix.Description},
+		ArtifactChanges: []SARIFArtifactChange{{
+			ArtifactLocation: SARIFArtifactLocation{URI: m.Location.Filename},
+			Replacements:     replacements,
+		}},
+	}}
+}
@@ -3,6 +3,7 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/lex00/cfn-lint-go/pkg/lint"
@@ -190,3 +191,114 @@ func TestWriteSARIF_UniqueRules(t *testing.T) {
 		t.Errorf("Expected 2 results, got %d", len(sarif.Runs[0].Results))
 	}
 }
+
+func TestWriteSARIF_Region(t *testing.T) {
+	matches := []lint.Match{
+		{
+			Rule:     lint.MatchRule{ID: "E3070"},
+			Location: lint.MatchLocation{Filename: "template.yaml"},
+			Level:    "Error",
+			Message:  "Resource type not available",
+			Regions:  []string{"cn-north-1", "cn-northwest-1"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, matches, "1.0.0"); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+
+	var sarif SARIF
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Failed to parse SARIF output: %v", err)
+	}
+
+	props := sarif.Runs[0].Results[0].Properties
+	if props == nil || strings.Join(props.Regions, ",") != "cn-north-1,cn-northwest-1" {
+		t.Errorf("Expected regions property cn-north-1,cn-northwest-1, got %+v", props)
+	}
+}
+
+func TestWriteSARIF_Suppressed(t *testing.T) {
+	matches := []lint.Match{
+		{
+			Rule:        lint.MatchRule{ID: "W3005"},
+			Location:    lint.MatchLocation{Filename: "template.yaml"},
+			Level:       "Warning",
+			Message:     "Obsolete DependsOn",
+			Suppression: &lint.MatchSuppression{Justification: "needed for ordering", Line: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, matches, "1.0.0"); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+
+	var sarif SARIF
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Failed to parse SARIF output: %v", err)
+	}
+
+	suppressions := sarif.Runs[0].Results[0].Suppressions
+	if len(suppressions) != 1 {
+		t.Fatalf("Expected 1 suppression, got %d", len(suppressions))
+	}
+	if suppressions[0].Kind != "inSource" || suppressions[0].Justification != "needed for ordering" {
+		t.Errorf("Unexpected suppression %+v", suppressions[0])
+	}
+}
+
+func TestWriteSARIF_Fixes(t *testing.T) {
+	matches := []lint.Match{
+		{
+			Rule:     lint.MatchRule{ID: "W3005"},
+			Location: lint.MatchLocation{Filename: "template.yaml"},
+			Level:    "Warning",
+			Message:  "Redundant DependsOn",
+			Fix: &lint.MatchFix{
+				Description: "Remove redundant DependsOn Topic",
+				Edits: []lint.MatchEdit{
+					{Start: lint.MatchPosition{LineNumber: 5, ColumnNumber: 1}, End: lint.MatchPosition{LineNumber: 7, ColumnNumber: 1}},
+					{Start: lint.MatchPosition{LineNumber: 9, ColumnNumber: 7}, End: lint.MatchPosition{LineNumber: 9, ColumnNumber: 9}, NewText: "[]"},
+				},
+			},
+		},
+		{
+			Rule:     lint.MatchRule{ID: "E3012"},
+			Location: lint.MatchLocation{Filename: "template.yaml"},
+			Level:    "Error",
+			Message:  "Wrong type",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, matches, "1.0.0"); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+
+	var sarif SARIF
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Failed to parse SARIF output: %v", err)
+	}
+
+	results := sarif.Runs[0].Results
+	if len(results[1].Fixes) != 0 {
+		t.Errorf("Expected no fixes for a match without one, got %+v", results[1].Fixes)
+	}
+	fixes := results[0].Fixes
+	if len(fixes) != 1 || fixes[0].Description.Text != "Remove redundant DependsOn Topic" || len(fixes[0].ArtifactChanges) != 1 {
+		t.Fatalf("Unexpected fixes %+v", fixes)
+	}
+	change := fixes[0].ArtifactChanges[0]
+	if change.ArtifactLocation.URI != "template.yaml" || len(change.Replacements) != 2 {
+		t.Fatalf("Unexpected artifact change %+v", change)
+	}
+	deletion, replacement := change.Replacements[0], change.Replacements[1]
+	if deletion.DeletedRegion != (SARIFRegion{StartLine: 5, StartColumn: 1, EndLine: 7, EndColumn: 1}) || deletion.InsertedContent != nil {
+		t.Errorf("Unexpected deletion %+v", deletion)
+	}
+	if replacement.InsertedContent == nil || replacement.InsertedContent.Text != "[]" {
+		t.Errorf("Unexpected replacement %+v", replacement)
+	}
+}
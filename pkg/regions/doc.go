@@ -0,0 +1,25 @@
+// Package regions provides AWS region and partition data for region-aware linting.
+//
+// Templates are often deployed to several partitions (commercial, China,
+// GovCloud). This package supplies the data rules need to validate a template
+// against a specific target region.
+//
+// # Expanding Regions
+//
+// Expand resolves the configured region list, handling the ALL_REGIONS keyword
+// and defaulting to us-east-1:
+//
+//	regions.Expand(nil)                       // ["us-east-1"]
+//	regions.Expand([]string{"ALL_REGIONS"})   // every known region
+//
+// # Partitions and Pseudo Parameters
+//
+//	regions.Partition("cn-north-1")         // "aws-cn"
+//	regions.URLSuffix("cn-north-1")         // "amazonaws.com.cn"
+//	regions.PseudoParameters("us-gov-west-1")["AWS::Partition"] // "aws-us-gov"
+//	regions.AvailabilityZones("us-west-1")  // ["us-west-1a", "us-west-1c"]
+//
+// # Resource Availability
+//
+//	regions.IsResourceTypeAvailable("AWS::AppRunner::Service", "cn-north-1") // false
+package regions
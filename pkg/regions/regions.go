@@ -0,0 +1,266 @@
+// Package regions provides AWS region and partition data.
+package regions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AllRegions is the special region value that expands to every known region,
+// matching the Python cfn-lint ALL_REGIONS keyword.
+const AllRegions = "ALL_REGIONS"
+
+// Default is the region used when no regions are configured.
+const Default = "us-east-1"
+
+// Partition names.
+const (
+	PartitionAWS   = "aws"
+	PartitionChina = "aws-cn"
+	PartitionGov   = "aws-us-gov"
+	PartitionISO   = "aws-iso"
+	PartitionISOB  = "aws-iso-b"
+)
+
+// regionPartitions maps every known region to its partition.
+var regionPartitions = map[string]string{
+	// Commercial
+	"af-south-1":     PartitionAWS,
+	"ap-east-1":      PartitionAWS,
+	"ap-east-2":      PartitionAWS,
+	"ap-northeast-1": PartitionAWS,
+	"ap-northeast-2": PartitionAWS,
+	"ap-northeast-3": PartitionAWS,
+	"ap-south-1":     PartitionAWS,
+	"ap-south-2":     PartitionAWS,
+	"ap-southeast-1": PartitionAWS,
+	"ap-southeast-2": PartitionAWS,
+	"ap-southeast-3": PartitionAWS,
+	"ap-southeast-4": PartitionAWS,
+	"ap-southeast-5": PartitionAWS,
+	"ap-southeast-7": PartitionAWS,
+	"ca-central-1":   PartitionAWS,
+	"ca-west-1":      PartitionAWS,
+	"eu-central-1":   PartitionAWS,
+	"eu-central-2":   PartitionAWS,
+	"eu-north-1":     PartitionAWS,
+	"eu-south-1":     PartitionAWS,
+	"eu-south-2":     PartitionAWS,
+	"eu-west-1":      PartitionAWS,
+	"eu-west-2":      PartitionAWS,
+	"eu-west-3":      PartitionAWS,
+	"il-central-1":   PartitionAWS,
+	"me-central-1":   PartitionAWS,
+	"me-south-1":     PartitionAWS,
+	"mx-central-1":   PartitionAWS,
+	"sa-east-1":      PartitionAWS,
+	"us-east-1":      PartitionAWS,
+	"us-east-2":      PartitionAWS,
+	"us-west-1":      PartitionAWS,
+	"us-west-2":      PartitionAWS,
+
+	// China
+	"cn-north-1":     PartitionChina,
+	"cn-northwest-1": PartitionChina,
+
+	// GovCloud
+	"us-gov-east-1": PartitionGov,
+	"us-gov-west-1": PartitionGov,
+
+	// ISO
+	"us-iso-east-1":  PartitionISO,
+	"us-iso-west-1":  PartitionISO,
+	"us-isob-east-1": PartitionISOB,
+}
+
+// urlSuffixes maps partitions to their AWS::URLSuffix value.
+var urlSuffixes = map[string]string{
+	PartitionAWS:   "amazonaws.com",
+	PartitionChina: "amazonaws.com.cn",
+	PartitionGov:   "amazonaws.com",
+	PartitionISO:   "c2s.ic.gov",
+	PartitionISOB:  "sc2s.sgov.gov",
+}
+
+// zoneSuffixes lists the availability zone letters of regions whose zones
+// are not simply a, b and c. Accounts can see different zones; these are
+// the zones Fn::GetAZs returns in most accounts.
+var zoneSuffixes = map[string]string{
+	"ap-northeast-1": "acd",
+	"ap-northeast-2": "abcd",
+	"ca-central-1":   "abd",
+	"cn-north-1":     "abd",
+	"us-east-1":      "abcdef",
+	"us-west-1":      "ac",
+	"us-west-2":      "abcd",
+}
+
+// unavailableServices lists resource type prefixes that CloudFormation does not
+// support in a partition. The list is curated and intentionally conservative:
+// it only covers services that are absent from the whole partition.
+var unavailableServices = map[string][]string{
+	PartitionChina: {
+		"AWS::Amplify::",
+		"AWS::AmplifyUIBuilder::",
+		"AWS::AppRunner::",
+		"AWS::Cognito::UserPool",
+		"AWS::Connect::",
+		"AWS::IVS::",
+		"AWS::Lightsail::",
+		"AWS::Location::",
+	},
+	PartitionGov: {
+		"AWS::Amplify::",
+		"AWS::AmplifyUIBuilder::",
+		"AWS::AppRunner::",
+		"AWS::GameLift::",
+		"AWS::IVS::",
+		"AWS::Lightsail::",
+	},
+	PartitionISO: {
+		"AWS::Amplify::",
+		"AWS::AppRunner::",
+		"AWS::Cognito::",
+		"AWS::IVS::",
+		"AWS::Lightsail::",
+	},
+	PartitionISOB: {
+		"AWS::Amplify::",
+		"AWS::AppRunner::",
+		"AWS::Cognito::",
+		"AWS::IVS::",
+		"AWS::Lightsail::",
+	},
+}
+
+// All returns every known region, sorted.
+func All() []string {
+	result := make([]string, 0, len(regionPartitions))
+	for region := range regionPartitions {
+		result = append(result, region)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// IsValid reports whether region is a known AWS region.
+func IsValid(region string) bool {
+	_, ok := regionPartitions[region]
+	return ok
+}
+
+// Validate returns an error naming the first unknown region in the list.
+// The AllRegions keyword is accepted.
+func Validate(list []string) error {
+	for _, region := range list {
+		if region == AllRegions {
+			continue
+		}
+		if !IsValid(region) {
+			return fmt.Errorf("unknown region %q", region)
+		}
+	}
+	return nil
+}
+
+// Expand resolves a configured region list into concrete regions.
+// AllRegions expands to every known region, duplicates are removed while
+// preserving order, and an empty list yields the default region.
+func Expand(list []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	add := func(region string) {
+		if !seen[region] {
+			seen[region] = true
+			result = append(result, region)
+		}
+	}
+
+	for _, region := range list {
+		if region == AllRegions {
+			for _, r := range All() {
+				add(r)
+			}
+			continue
+		}
+		add(region)
+	}
+
+	if len(result) == 0 {
+		return []string{Default}
+	}
+	return result
+}
+
+// Partition returns the partition of a region. Unknown regions are assumed to
+// belong to the partition implied by their prefix, defaulting to "aws".
+func Partition(region string) string {
+	if p, ok := regionPartitions[region]; ok {
+		return p
+	}
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionChina
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionGov
+	case strings.HasPrefix(region, "us-isob-"):
+		return PartitionISOB
+	case strings.HasPrefix(region, "us-iso-"):
+		return PartitionISO
+	}
+	return PartitionAWS
+}
+
+// URLSuffix returns the AWS::URLSuffix value for a region.
+func URLSuffix(region string) string {
+	return urlSuffixes[Partition(region)]
+}
+
+// PseudoParameters returns the values CloudFormation supplies for the
+// region-dependent pseudo parameters.
+func PseudoParameters(region string) map[string]string {
+	return map[string]string{
+		"AWS::Region":    region,
+		"AWS::Partition": Partition(region),
+		"AWS::URLSuffix": URLSuffix(region),
+	}
+}
+
+// AvailabilityZones returns the availability zones Fn::GetAZs returns for a
+// region. Regions without curated data are assumed to have zones a, b and c.
+func AvailabilityZones(region string) []string {
+	suffixes, ok := zoneSuffixes[region]
+	if !ok {
+		suffixes = "abc"
+	}
+	zones := make([]string, len(suffixes))
+	for i, suffix := range suffixes {
+		zones[i] = region + string(suffix)
+	}
+	return zones
+}
+
+// AvailabilityZoneRegion returns the region of an availability zone name
+// such as us-east-1a, and false if az is not a zone of a known region.
+func AvailabilityZoneRegion(az string) (string, bool) {
+	if len(az) < 2 {
+		return "", false
+	}
+	if suffix := az[len(az)-1]; suffix < 'a' || suffix > 'z' {
+		return "", false
+	}
+	region := az[:len(az)-1]
+	return region, IsValid(region)
+}
+
+// IsResourceTypeAvailable reports whether a resource type can be deployed in a region.
+// Types not covered by the curated availability data are assumed to be available.
+func IsResourceTypeAvailable(resourceType, region string) bool {
+	for _, prefix := range unavailableServices[Partition(region)] {
+		if strings.HasPrefix(resourceType, prefix) {
+			return false
+		}
+	}
+	return true
+}
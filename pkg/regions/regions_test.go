@@ -0,0 +1,124 @@
+package regions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{"empty defaults to us-east-1", nil, []string{"us-east-1"}},
+		{"preserves order", []string{"eu-west-1", "us-east-1"}, []string{"eu-west-1", "us-east-1"}},
+		{"removes duplicates", []string{"cn-north-1", "cn-north-1"}, []string{"cn-north-1"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Expand(tc.input)
+			if len(got) != len(tc.want) {
+				t.Fatalf("Expand(%v) = %v, want %v", tc.input, got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("Expand(%v)[%d] = %q, want %q", tc.input, i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpand_AllRegions(t *testing.T) {
+	got := Expand([]string{"us-east-1", AllRegions})
+	if len(got) != len(All()) {
+		t.Errorf("Expected %d regions, got %d", len(All()), len(got))
+	}
+	if got[0] != "us-east-1" {
+		t.Errorf("Expected explicit region first, got %s", got[0])
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate([]string{"us-east-1", "cn-north-1", AllRegions}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := Validate([]string{"us-east-1", "mars-north-1"}); err == nil {
+		t.Error("Expected error for unknown region")
+	}
+}
+
+func TestPartition(t *testing.T) {
+	tests := map[string]string{
+		"us-east-1":      PartitionAWS,
+		"eu-central-1":   PartitionAWS,
+		"cn-north-1":     PartitionChina,
+		"cn-northwest-1": PartitionChina,
+		"us-gov-west-1":  PartitionGov,
+		"us-iso-east-1":  PartitionISO,
+		"us-isob-east-1": PartitionISOB,
+		"cn-future-9":    PartitionChina,
+	}
+
+	for region, want := range tests {
+		if got := Partition(region); got != want {
+			t.Errorf("Partition(%q) = %q, want %q", region, got, want)
+		}
+	}
+}
+
+func TestPseudoParameters(t *testing.T) {
+	params := PseudoParameters("cn-north-1")
+	if params["AWS::Region"] != "cn-north-1" {
+		t.Errorf("AWS::Region = %q", params["AWS::Region"])
+	}
+	if params["AWS::Partition"] != "aws-cn" {
+		t.Errorf("AWS::Partition = %q", params["AWS::Partition"])
+	}
+	if params["AWS::URLSuffix"] != "amazonaws.com.cn" {
+		t.Errorf("AWS::URLSuffix = %q", params["AWS::URLSuffix"])
+	}
+}
+
+func TestAvailabilityZones(t *testing.T) {
+	if got, want := AvailabilityZones("us-west-1"), []string{"us-west-1a", "us-west-1c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AvailabilityZones(us-west-1) = %v, want %v", got, want)
+	}
+	if got, want := AvailabilityZones("eu-west-1"), []string{"eu-west-1a", "eu-west-1b", "eu-west-1c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AvailabilityZones(eu-west-1) = %v, want %v", got, want)
+	}
+}
+
+func TestAvailabilityZoneRegion(t *testing.T) {
+	tests := []struct {
+		az     string
+		region string
+		ok     bool
+	}{
+		{"us-east-1a", "us-east-1", true},
+		{"us-gov-west-1b", "us-gov-west-1", true},
+		{"us-east-1", "", false},
+		{"xx-east-1a", "xx-east-1", false},
+		{"a", "", false},
+	}
+	for _, tt := range tests {
+		region, ok := AvailabilityZoneRegion(tt.az)
+		if ok != tt.ok || (ok && region != tt.region) {
+			t.Errorf("AvailabilityZoneRegion(%q) = %q, %v; want %q, %v", tt.az, region, ok, tt.region, tt.ok)
+		}
+	}
+}
+
+func TestIsResourceTypeAvailable(t *testing.T) {
+	if !IsResourceTypeAvailable("AWS::S3::Bucket", "cn-north-1") {
+		t.Error("Expected AWS::S3::Bucket to be available in cn-north-1")
+	}
+	if IsResourceTypeAvailable("AWS::AppRunner::Service", "cn-north-1") {
+		t.Error("Expected AWS::AppRunner::Service to be unavailable in cn-north-1")
+	}
+	if !IsResourceTypeAvailable("AWS::AppRunner::Service", "us-east-1") {
+		t.Error("Expected AWS::AppRunner::Service to be available in us-east-1")
+	}
+}
@@ -43,6 +43,103 @@
 //	    return matches
 //	}
 //
+// # Region-Aware Rules
+//
+// Rules whose result depends on the deployment region implement RegionalRule.
+// The linter calls MatchRegion once per target region and tags each match with
+// the region it was found in. Matches that are the same in several regions are
+// reported once, listing all of them, so messages should not name the region
+// and checks that do not depend on the region can stay in MatchRegion:
+//
+//	func (r *MyRule) Match(tmpl *template.Template) []rules.Match {
+//	    return r.MatchRegion(tmpl, regions.Default)
+//	}
+//
+//	func (r *MyRule) MatchRegion(tmpl *template.Template, region string) []rules.Match {
+//	    // validate against region-specific data
+//	}
+//
+// # Rule Status
+//
+// New or noisy rules can declare a non-stable status by implementing
+// StatusRule. Experimental rules only run with IncludeExperimental, opt-in
+// rules only run when included by ID, and deprecated rules still run:
+//
+//	func (r *MyRule) Status() rules.Status { return rules.StatusExperimental }
+//
+// # Configurable Rules
+//
+// Rules with options set through configure_rules implement ConfigurableRule.
+// Options declare a name, type and default; the linter validates configured
+// values and passes them to MatchWithContext:
+//
+//	func (r *MyRule) Options() []rules.Option {
+//	    return []rules.Option{{Name: "limit", Type: rules.OptionInteger, Default: 500}}
+//	}
+//
+//	func (r *MyRule) MatchWithContext(ctx *rules.Context, tmpl *template.Template) []rules.Match {
+//	    limit := ctx.Int("limit")
+//	    // ...
+//	}
+//
+// # Fixable Rules
+//
+// Rules whose matches have a mechanical fix implement FixableRule. Fix
+// returns text edits against the original source, built with helpers such
+// as ReplaceScalar and DeleteEntry, or nil when there is no safe fix:
+//
+//	func (r *MyRule) Fix(tmpl *template.Template, m rules.Match) *rules.Fix {
+//	    node := tmpl.ResolvePath(m.Path).Value
+//	    return &rules.Fix{
+//	        Description: "Use Retain",
+//	        Edits:       []rules.TextEdit{rules.ReplaceScalar(tmpl, node, "Retain")},
+//	    }
+//	}
+//
+// # Parameter File Rules
+//
+// Rules that check parameter files against the template implement
+// ParameterRule. The linter calls MatchParameters once for each file in
+// Options.ParameterFiles; matches in the parameter file set Filename:
+//
+//	func (r *MyRule) MatchParameters(tmpl *template.Template, params *deployment.Parameters) []rules.Match {
+//	    var matches []rules.Match
+//	    for _, v := range params.Values {
+//	        if _, ok := tmpl.Parameters[v.Key]; !ok {
+//	            matches = append(matches, rules.Match{
+//	                Message:  fmt.Sprintf("Parameter '%s' is not defined in the template", v.Key),
+//	                Line:     v.KeyNode.Line,
+//	                Column:   v.KeyNode.Column,
+//	                Path:     v.KeyPath,
+//	                Filename: params.Filename,
+//	            })
+//	        }
+//	    }
+//	    return matches
+//	}
+//
+// # Deployment File Rules
+//
+// Rules that check Git sync deployment files implement DeploymentRule. When
+// the linter lints a deployment file it runs only these and ParameterRule
+// rules, calling MatchDeployment with the file and the template it names:
+//
+//	func (r *MyRule) MatchDeployment(tmpl *template.Template, file *deployment.File) []rules.Match {
+//	    var matches []rules.Match
+//	    for _, tag := range file.Tags {
+//	        if tag.Value == "" {
+//	            matches = append(matches, rules.Match{
+//	                Message:  fmt.Sprintf("Tag '%s' is empty", tag.Key),
+//	                Line:     tag.ValueNode.Line,
+//	                Column:   tag.ValueNode.Column,
+//	                Path:     tag.Path,
+//	                Filename: file.Filename,
+//	            })
+//	        }
+//	    }
+//	    return matches
+//	}
+//
 // # Registry Functions
 //
 // Query registered rules:
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// TextEdit replaces a range of the template source with new text. Lines
+// and columns are 1-based and count characters, like yaml.Node positions;
+// EndColumn is the column after the last replaced character, so an empty
+// range inserts NewText. To delete whole lines, a range may end at column 1
+// of the line after the last one.
+type TextEdit struct {
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+	NewText     string
+}
+
+// Fix is a change to the template source that resolves a match.
+type Fix struct {
+	// Description says what the fix does, e.g. "Replace runtime
+	// python3.7 with python3.12".
+	Description string
+
+	// Edits are applied together. They must not overlap.
+	Edits []TextEdit
+}
+
+// FixableRule is implemented by rules that can fix some of their matches
+// mechanically. For templates that were not transformed, the linter calls
+// Fix for each match the rule reports and attaches the result to the match.
+// Rules can also set Match.Fix directly.
+type FixableRule interface {
+	Rule
+
+	// Fix returns a fix for a match reported by the rule, or nil when the
+	// match has no mechanical fix. Edits should touch as little of the
+	// source as possible so comments and formatting are kept.
+	Fix(tmpl *template.Template, m Match) *Fix
+}
+
+// ReplaceNode returns an edit replacing a node, including any tag or
+// anchor, with text.
+func ReplaceNode(tmpl *template.Template, node *yaml.Node, text string) TextEdit {
+	return spanEdit(tmpl.NodeSpan(node), text)
+}
+
+// ReplaceScalar returns an edit replacing the value of a single-line
+// scalar, keeping its tag and quoting style.
+func ReplaceScalar(tmpl *template.Template, node *yaml.Node, value string) TextEdit {
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		value = strconv.Quote(value)
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		value = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return spanEdit(tmpl.ContentSpan(node), value)
+}
+
+// DeleteEntry returns an edit deleting the entry for key from a block
+// mapping, removing the lines from the key to the end of its value. It
+// reports false when the key is missing or the entry does not occupy whole
+// lines, as in flow mappings and JSON templates.
+func DeleteEntry(tmpl *template.Template, mapping *yaml.Node, key string) (TextEdit, bool) {
+	if mapping.Kind != yaml.MappingNode || mapping.Style&yaml.FlowStyle != 0 {
+		return TextEdit{}, false
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return deleteLines(tmpl, tmpl.NodeSpan(mapping.Content[i]), tmpl.NodeSpan(mapping.Content[i+1]))
+		}
+	}
+	return TextEdit{}, false
+}
+
+// DeleteItem returns an edit deleting an item from a block sequence,
+// removing the lines from its "-" to the end of the item. It reports false
+// when the item does not occupy whole lines, as in flow sequences.
+func DeleteItem(tmpl *template.Template, sequence *yaml.Node, index int) (TextEdit, bool) {
+	if sequence.Kind != yaml.SequenceNode || sequence.Style&yaml.FlowStyle != 0 || index < 0 || index >= len(sequence.Content) {
+		return TextEdit{}, false
+	}
+	item := tmpl.NodeSpan(sequence.Content[index])
+
+	// The item starts after "- " on its first line
+	line, ok := tmpl.SourceLine(item.StartLine)
+	if !ok {
+		return TextEdit{}, false
+	}
+	prefix := strings.TrimSpace(prefixChars(line, item.StartColumn-1))
+	if prefix != "-" {
+		return TextEdit{}, false
+	}
+	return deleteLines(tmpl, template.Span{StartLine: item.StartLine, StartColumn: 1}, item)
+}
+
+// deleteLines returns an edit deleting the whole lines from start to end,
+// provided nothing but whitespace precedes start and nothing but whitespace
+// or a comment follows end. A comment after end is kept on its own line, at
+// the indentation of the first line. Lines before the last one that may
+// hold a comment are refused, since the comment could not be told apart
+// from the value.
+func deleteLines(tmpl *template.Template, start, end template.Span) (TextEdit, bool) {
+	first, ok := tmpl.SourceLine(start.StartLine)
+	if !ok || strings.TrimSpace(prefixChars(first, start.StartColumn-1)) != "" {
+		return TextEdit{}, false
+	}
+	for line := start.StartLine; line < end.EndLine; line++ {
+		if text, ok := tmpl.SourceLine(line); !ok || strings.Contains(text, "#") {
+			return TextEdit{}, false
+		}
+	}
+	last, ok := tmpl.SourceLine(end.EndLine)
+	if !ok {
+		return TextEdit{}, false
+	}
+	rest := strings.TrimSpace(string([]rune(last)[min(end.EndColumn-1, len([]rune(last))):]))
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return TextEdit{}, false
+	}
+	edit := TextEdit{StartLine: start.StartLine, StartColumn: 1, EndLine: end.EndLine + 1, EndColumn: 1}
+	if rest != "" {
+		indent := first[:len(first)-len(strings.TrimLeft(first, " \t"))]
+		edit.NewText = indent + rest + "\n"
+	}
+	return edit, true
+}
+
+func spanEdit(span template.Span, text string) TextEdit {
+	return TextEdit{
+		StartLine:   span.StartLine,
+		StartColumn: span.StartColumn,
+		EndLine:     span.EndLine,
+		EndColumn:   span.EndColumn,
+		NewText:     text,
+	}
+}
+
+// prefixChars returns the first n characters of a line.
+func prefixChars(line string, n int) string {
+	runes := []rune(line)
+	return string(runes[:min(max(n, 0), len(runes))])
+}
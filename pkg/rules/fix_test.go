@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+const fixTemplate = `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    DependsOn:
+      - First
+      - Second # keep
+    DeletionPolicy: 'it''s'
+    Properties: {BucketName: !Sub "name"}
+  Other:
+    Type: AWS::SNS::Topic
+    DependsOn: [First, Second]
+`
+
+func TestReplaceScalar(t *testing.T) {
+	tmpl, err := template.Parse([]byte(fixTemplate))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path []string
+		want TextEdit
+	}{
+		{"plain", []string{"Resources", "Bucket", "Type"}, TextEdit{3, 11, 3, 26, "new's"}},
+		{"single-quoted", []string{"Resources", "Bucket", "DeletionPolicy"}, TextEdit{7, 21, 7, 28, `'new''s'`}},
+		{"tagged double-quoted", []string{"Resources", "Bucket", "Properties", "BucketName"}, TextEdit{8, 35, 8, 41, `"new's"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := tmpl.ResolvePath(tt.path).Value
+			if got := ReplaceScalar(tmpl, node, "new's"); got != tt.want {
+				t.Errorf("ReplaceScalar() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteEntry(t *testing.T) {
+	tmpl, err := template.Parse([]byte(fixTemplate))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	bucket := tmpl.Resources["Bucket"].Node
+
+	got, ok := DeleteEntry(tmpl, bucket, "DependsOn")
+	if want := (TextEdit{StartLine: 4, StartColumn: 1, EndLine: 7, EndColumn: 1, NewText: "    # keep\n"}); !ok || got != want {
+		t.Errorf("DeleteEntry(DependsOn) = %+v, %v, want %+v", got, ok, want)
+	}
+	if _, ok := DeleteEntry(tmpl, bucket, "Missing"); ok {
+		t.Error("Expected no edit for a missing key")
+	}
+	props := tmpl.ResolvePath([]string{"Resources", "Bucket", "Properties"}).Value
+	if _, ok := DeleteEntry(tmpl, props, "BucketName"); ok {
+		t.Error("Expected no edit in a flow mapping")
+	}
+
+	commented, err := template.Parse([]byte("Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n    DependsOn: # why\n      - First\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if _, ok := DeleteEntry(commented, commented.Resources["Bucket"].Node, "DependsOn"); ok {
+		t.Error("Expected no edit that would drop a comment inside the entry")
+	}
+}
+
+func TestDeleteItem(t *testing.T) {
+	tmpl, err := template.Parse([]byte(fixTemplate))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	dependsOn := tmpl.ResolvePath([]string{"Resources", "Bucket", "DependsOn"}).Value
+	got, ok := DeleteItem(tmpl, dependsOn, 1)
+	if want := (TextEdit{StartLine: 6, StartColumn: 1, EndLine: 7, EndColumn: 1, NewText: "      # keep\n"}); !ok || got != want {
+		t.Errorf("DeleteItem() = %+v, %v, want %+v", got, ok, want)
+	}
+	got, ok = DeleteItem(tmpl, dependsOn, 0)
+	if want := (TextEdit{StartLine: 5, StartColumn: 1, EndLine: 6, EndColumn: 1}); !ok || got != want {
+		t.Errorf("DeleteItem() = %+v, %v, want %+v", got, ok, want)
+	}
+	if _, ok := DeleteItem(tmpl, dependsOn, 2); ok {
+		t.Error("Expected no edit for an index out of range")
+	}
+
+	flow := tmpl.ResolvePath([]string{"Resources", "Other", "DependsOn"}).Value
+	if _, ok := DeleteItem(tmpl, flow, 0); ok {
+		t.Error("Expected no edit in a flow sequence")
+	}
+}
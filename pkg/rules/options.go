@@ -0,0 +1,222 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// OptionType is the type of a configurable rule option.
+type OptionType string
+
+const (
+	// OptionString is a string value.
+	OptionString OptionType = "string"
+
+	// OptionInteger is a whole number.
+	OptionInteger OptionType = "integer"
+
+	// OptionBoolean is true or false.
+	OptionBoolean OptionType = "boolean"
+
+	// OptionStringList is a list of strings.
+	OptionStringList OptionType = "list"
+
+	// OptionPattern is a string that must compile as a regular expression.
+	OptionPattern OptionType = "pattern"
+)
+
+// Option declares a rule option that can be set through configure_rules.
+type Option struct {
+	Name        string     `json:"name"`
+	Type        OptionType `json:"type"`
+	Default     any        `json:"default"`
+	Description string     `json:"description"`
+}
+
+// ConfigurableRule is implemented by rules that accept options from the
+// configure_rules configuration section. The linter validates the configured
+// values against Options and calls MatchWithContext instead of Match. A rule
+// that is also a RegionalRule is called once per target region, with the
+// region in the context, instead of MatchRegion.
+type ConfigurableRule interface {
+	Rule
+
+	// Options returns the options the rule accepts.
+	Options() []Option
+
+	// MatchWithContext checks the template using the configured options.
+	MatchWithContext(ctx *Context, tmpl *template.Template) []Match
+}
+
+// Context carries the validated option values for a single rule, and the
+// target region when the rule is also a RegionalRule.
+type Context struct {
+	values map[string]any
+	region string
+}
+
+// NewContext validates configured values against a rule's options and
+// returns a context holding them, with defaults for unset options.
+// Unknown options and values of the wrong type are errors.
+func NewContext(r ConfigurableRule, configured map[string]any) (*Context, error) {
+	options := make(map[string]Option)
+	ctx := &Context{values: make(map[string]any)}
+	for _, opt := range r.Options() {
+		options[opt.Name] = opt
+		ctx.values[opt.Name] = opt.Default
+	}
+
+	// Validate in name order so errors are deterministic
+	names := make([]string, 0, len(configured))
+	for name := range configured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		opt, ok := options[name]
+		if !ok {
+			return nil, fmt.Errorf("rule %s has no option %q", r.ID(), name)
+		}
+		value, err := convertOption(opt, configured[name])
+		if err != nil {
+			return nil, fmt.Errorf("rule %s option %q: %w", r.ID(), name, err)
+		}
+		ctx.values[name] = value
+	}
+
+	return ctx, nil
+}
+
+// DefaultContext returns a context holding a rule's default option values.
+func DefaultContext(r ConfigurableRule) *Context {
+	ctx, _ := NewContext(r, nil)
+	return ctx
+}
+
+// String returns a string or pattern option.
+func (c *Context) String(name string) string {
+	s, _ := c.values[name].(string)
+	return s
+}
+
+// Int returns an integer option.
+func (c *Context) Int(name string) int {
+	i, _ := c.values[name].(int)
+	return i
+}
+
+// Bool returns a boolean option.
+func (c *Context) Bool(name string) bool {
+	b, _ := c.values[name].(bool)
+	return b
+}
+
+// StringList returns a list option.
+func (c *Context) StringList(name string) []string {
+	list, _ := c.values[name].([]string)
+	return list
+}
+
+// Pattern returns a pattern option compiled as a regular expression.
+func (c *Context) Pattern(name string) *regexp.Regexp {
+	// Patterns were validated when the context was built
+	re, _ := regexp.Compile(c.String(name))
+	return re
+}
+
+// Region returns the target region the rule is matching for, or "" when
+// the rule is not a RegionalRule.
+func (c *Context) Region() string {
+	return c.region
+}
+
+// WithRegion returns a copy of the context for a target region.
+func (c *Context) WithRegion(region string) *Context {
+	copied := *c
+	copied.region = region
+	return &copied
+}
+
+// convertOption checks a configured value against an option's type and
+// normalizes it to the Go type the Context accessors expect.
+func convertOption(opt Option, value any) (any, error) {
+	switch opt.Type {
+	case OptionString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case OptionPattern:
+		if s, ok := value.(string); ok {
+			if _, err := regexp.Compile(s); err != nil {
+				return nil, fmt.Errorf("invalid pattern: %w", err)
+			}
+			return s, nil
+		}
+	case OptionInteger:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			// JSON numbers decode as float64
+			if v == math.Trunc(v) {
+				return int(v), nil
+			}
+		}
+	case OptionBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case OptionStringList:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []any:
+			list := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected a list of strings, got item %v", item)
+				}
+				list = append(list, s)
+			}
+			return list, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported option type %q", opt.Type)
+	}
+
+	return nil, fmt.Errorf("expected %s, got %s", describeType(opt.Type), describeValue(value))
+}
+
+func describeType(t OptionType) string {
+	switch t {
+	case OptionInteger:
+		return "an integer"
+	case OptionStringList:
+		return "a list of strings"
+	case OptionPattern:
+		return "a regular expression string"
+	}
+	return "a " + string(t)
+}
+
+func describeValue(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("string %q", value)
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "a mapping"
+	}
+	return fmt.Sprintf("%T %v", value, value)
+}
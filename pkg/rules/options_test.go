@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/cfn-lint-go/pkg/template"
+)
+
+// configurableMockRule declares one option of each type.
+type configurableMockRule struct {
+	mockRule
+}
+
+func (r *configurableMockRule) Options() []Option {
+	return []Option{
+		{Name: "name", Type: OptionString, Default: "default"},
+		{Name: "limit", Type: OptionInteger, Default: 10},
+		{Name: "strict", Type: OptionBoolean, Default: false},
+		{Name: "deny", Type: OptionStringList, Default: []string{"a"}},
+		{Name: "pattern", Type: OptionPattern, Default: "^x$"},
+	}
+}
+
+func (r *configurableMockRule) MatchWithContext(ctx *Context, tmpl *template.Template) []Match {
+	return nil
+}
+
+func TestNewContext_Defaults(t *testing.T) {
+	ctx, err := NewContext(&configurableMockRule{mockRule{id: "E9000"}}, nil)
+	if err != nil {
+		t.Fatalf("NewContext failed: %v", err)
+	}
+
+	if ctx.String("name") != "default" {
+		t.Errorf("Expected default name, got %q", ctx.String("name"))
+	}
+	if ctx.Int("limit") != 10 {
+		t.Errorf("Expected default limit 10, got %d", ctx.Int("limit"))
+	}
+	if ctx.Bool("strict") {
+		t.Error("Expected default strict false")
+	}
+	if deny := ctx.StringList("deny"); len(deny) != 1 || deny[0] != "a" {
+		t.Errorf("Expected default deny list [a], got %v", deny)
+	}
+	if !ctx.Pattern("pattern").MatchString("x") {
+		t.Error("Expected default pattern to match x")
+	}
+}
+
+func TestNewContext_Configured(t *testing.T) {
+	ctx, err := NewContext(&configurableMockRule{mockRule{id: "E9000"}}, map[string]any{
+		"name":    "custom",
+		"limit":   float64(3), // JSON numbers
+		"strict":  true,
+		"deny":    []any{"b", "c"},
+		"pattern": "^y+$",
+	})
+	if err != nil {
+		t.Fatalf("NewContext failed: %v", err)
+	}
+
+	if ctx.String("name") != "custom" {
+		t.Errorf("Expected name custom, got %q", ctx.String("name"))
+	}
+	if ctx.Int("limit") != 3 {
+		t.Errorf("Expected limit 3, got %d", ctx.Int("limit"))
+	}
+	if !ctx.Bool("strict") {
+		t.Error("Expected strict true")
+	}
+	if deny := ctx.StringList("deny"); len(deny) != 2 || deny[1] != "c" {
+		t.Errorf("Expected deny list [b c], got %v", deny)
+	}
+	if !ctx.Pattern("pattern").MatchString("yyy") {
+		t.Error("Expected configured pattern to match yyy")
+	}
+}
+
+func TestNewContext_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]any
+		want   string
+	}{
+		{"unknown option", map[string]any{"bogus": 1}, `has no option "bogus"`},
+		{"string for integer", map[string]any{"limit": "ten"}, "expected an integer"},
+		{"fractional integer", map[string]any{"limit": 1.5}, "expected an integer"},
+		{"string for boolean", map[string]any{"strict": "yes"}, "expected a boolean"},
+		{"mixed list", map[string]any{"deny": []any{"a", 1}}, "list of strings"},
+		{"bad pattern", map[string]any{"pattern": "("}, "invalid pattern"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewContext(&configurableMockRule{mockRule{id: "E9000"}}, tc.values)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.want) || !strings.Contains(err.Error(), "E9000") {
+				t.Errorf("Expected error containing %q and the rule ID, got %q", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestContext_WithRegion(t *testing.T) {
+	ctx := DefaultContext(&configurableMockRule{mockRule{id: "E9000"}})
+	regional := ctx.WithRegion("eu-west-1")
+	if regional.Region() != "eu-west-1" || ctx.Region() != "" {
+		t.Errorf("Expected only the copy to have a region, got %q and %q", regional.Region(), ctx.Region())
+	}
+	if regional.Int("limit") != 10 {
+		t.Errorf("Expected the copy to keep the options, got limit %d", regional.Int("limit"))
+	}
+}
@@ -4,6 +4,7 @@ package rules
 import (
 	"sync"
 
+	"github.com/lex00/cfn-lint-go/pkg/deployment"
 	"github.com/lex00/cfn-lint-go/pkg/template"
 )
 
@@ -28,12 +29,98 @@ type Rule interface {
 	Match(tmpl *template.Template) []Match
 }
 
+// RegionalRule is implemented by rules whose checks depend on the target region.
+// The linter calls MatchRegion once for each configured region instead of Match,
+// and tags the resulting matches with that region. A match found again in a
+// later region, with the same path and message, is reported once with both
+// regions.
+type RegionalRule interface {
+	Rule
+
+	// MatchRegion checks the template for deployment to the given region.
+	MatchRegion(tmpl *template.Template, region string) []Match
+}
+
+// ParameterRule is implemented by rules that check parameter files against
+// the template. The linter calls MatchParameters once for each parameter
+// file instead of Match. Matches that point into the parameter file set
+// Filename to params.Filename.
+type ParameterRule interface {
+	Rule
+
+	// MatchParameters checks the values of one parameter file.
+	MatchParameters(tmpl *template.Template, params *deployment.Parameters) []Match
+}
+
+// DeploymentRule is implemented by rules that check Git sync deployment
+// files. When the linter lints a deployment file it calls MatchDeployment
+// with the file and the template it names, and runs ParameterRule rules on
+// the file's parameters when the template loaded; other rules do not run.
+// tmpl is empty when the template could not be loaded.
+type DeploymentRule interface {
+	Rule
+
+	// MatchDeployment checks a deployment file.
+	MatchDeployment(tmpl *template.Template, file *deployment.File) []Match
+}
+
+// Status describes where a rule is in its lifecycle.
+type Status string
+
+const (
+	// StatusStable rules run by default.
+	StatusStable Status = "stable"
+
+	// StatusExperimental rules only run when experimental rules are enabled.
+	StatusExperimental Status = "experimental"
+
+	// StatusOptIn rules only run when explicitly included by ID.
+	StatusOptIn Status = "opt-in"
+
+	// StatusDeprecated rules still run but are scheduled for removal.
+	StatusDeprecated Status = "deprecated"
+)
+
+// StatusRule is implemented by rules that are not stable.
+// Rules that do not implement it are treated as StatusStable.
+type StatusRule interface {
+	Rule
+
+	// Status returns the rule's lifecycle status.
+	Status() Status
+}
+
+// StatusOf returns the lifecycle status of a rule.
+func StatusOf(r Rule) Status {
+	if sr, ok := r.(StatusRule); ok {
+		if status := sr.Status(); status != "" {
+			return status
+		}
+	}
+	return StatusStable
+}
+
 // Match represents a single rule violation.
 type Match struct {
 	Message string
 	Line    int
 	Column  int
 	Path    []string // JSON path to the problematic element
+
+	// EndLine and EndColumn optionally end the reported span; EndColumn is
+	// the column after the last character. When unset the linter derives
+	// the span from Path.
+	EndLine   int
+	EndColumn int
+
+	// Fix optionally resolves the match by editing the template source.
+	// See FixableRule.
+	Fix *Fix
+
+	// Filename is set when the match is in a file other than the template,
+	// such as a parameter or deployment file. Line, Column and Path then
+	// refer to that file.
+	Filename string
 }
 
 // registry holds all registered rules.
@@ -75,3 +75,33 @@ func TestAll(t *testing.T) {
 		t.Error("All() should return a copy, not the original slice")
 	}
 }
+
+// statusRule is a mock rule that declares a lifecycle status.
+type statusRule struct {
+	mockRule
+	status Status
+}
+
+func (r *statusRule) Status() Status { return r.status }
+
+func TestStatusOf(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		want Status
+	}{
+		{"no status", &mockRule{id: "TEST001"}, StatusStable},
+		{"empty status", &statusRule{mockRule{id: "TEST002"}, ""}, StatusStable},
+		{"experimental", &statusRule{mockRule{id: "TEST003"}, StatusExperimental}, StatusExperimental},
+		{"opt-in", &statusRule{mockRule{id: "TEST004"}, StatusOptIn}, StatusOptIn},
+		{"deprecated", &statusRule{mockRule{id: "TEST005"}, StatusDeprecated}, StatusDeprecated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := StatusOf(tc.rule); got != tc.want {
+				t.Errorf("StatusOf() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package schema
+
+import "strings"
+
+// lambdaRuntimeSuccessors maps deprecated and end-of-life Lambda runtimes to
+// the supported runtime their functions should move to.
+var lambdaRuntimeSuccessors = map[string]string{
+	"python2.7":     "python3.12",
+	"python3.6":     "python3.12",
+	"python3.7":     "python3.12",
+	"python3.8":     "python3.12",
+	"nodejs":        "nodejs20.x",
+	"nodejs4.3":     "nodejs20.x",
+	"nodejs6.10":    "nodejs20.x",
+	"nodejs8.10":    "nodejs20.x",
+	"nodejs10.x":    "nodejs20.x",
+	"nodejs12.x":    "nodejs20.x",
+	"nodejs14.x":    "nodejs20.x",
+	"nodejs16.x":    "nodejs20.x",
+	"dotnetcore1.0": "dotnet8",
+	"dotnetcore2.0": "dotnet8",
+	"dotnetcore2.1": "dotnet8",
+	"dotnetcore3.1": "dotnet8",
+	"dotnet5.0":     "dotnet8",
+	"dotnet6":       "dotnet8",
+	"ruby2.5":       "ruby3.3",
+	"ruby2.7":       "ruby3.3",
+	"java8":         "java21",
+	"go1.x":         "provided.al2023",
+}
+
+// LambdaRuntimeSuccessor returns the supported runtime that functions using
+// a deprecated or end-of-life Lambda runtime should move to. The runtime is
+// matched case-insensitively; ok is false for runtimes that are not
+// deprecated.
+func LambdaRuntimeSuccessor(runtime string) (successor string, ok bool) {
+	successor, ok = lambdaRuntimeSuccessors[strings.ToLower(runtime)]
+	return successor, ok
+}
@@ -3,6 +3,10 @@
 package schema
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
 	"sync"
 
 	"github.com/lex00/cloudformation-schema-go/spec"
@@ -13,6 +17,11 @@ var (
 	globalSpec     *spec.Spec
 	globalSpecErr  error
 	globalSpecOnce sync.Once
+
+	// globalFingerprint is the content hash of globalSpec.
+	globalFingerprint     string
+	globalFingerprintErr  error
+	globalFingerprintOnce sync.Once
 )
 
 // Options configures how the schema is loaded.
@@ -35,6 +44,7 @@ func LoadWithOptions(opts *Options) (*spec.Spec, error) {
 	if opts != nil && opts.Force {
 		// Force refresh - reset the once guard
 		globalSpecOnce = sync.Once{}
+		globalFingerprintOnce = sync.Once{}
 	}
 
 	globalSpecOnce.Do(func() {
@@ -51,6 +61,26 @@ func LoadWithOptions(opts *Options) (*spec.Spec, error) {
 	return globalSpec, globalSpecErr
 }
 
+// Fingerprint returns the SHA-256 of the loaded spec's content. It changes
+// whenever the spec is updated, so results computed against an older spec
+// can be told apart.
+func Fingerprint() (string, error) {
+	s, err := Load()
+	if err != nil {
+		return "", err
+	}
+	globalFingerprintOnce.Do(func() {
+		data, err := json.Marshal(s)
+		if err != nil {
+			globalFingerprintErr = err
+			return
+		}
+		sum := sha256.Sum256(data)
+		globalFingerprint = hex.EncodeToString(sum[:])
+	})
+	return globalFingerprint, globalFingerprintErr
+}
+
 // GetRequiredProperties returns the required property names for a resource type.
 // Returns nil if the resource type is not found in the spec.
 func GetRequiredProperties(resourceType string) ([]string, error) {
@@ -147,3 +177,19 @@ func HasAttribute(resourceType, attributeName string) (bool, error) {
 
 	return rt.HasAttribute(attributeName), nil
 }
+
+// ResourceTypeNames returns the names of all resource types in the spec,
+// sorted.
+func ResourceTypeNames() ([]string, error) {
+	s, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(s.ResourceTypes))
+	for name := range s.ResourceTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -133,3 +133,17 @@ func TestHasAttribute(t *testing.T) {
 		})
 	}
 }
+
+func TestFingerprint(t *testing.T) {
+	first, err := Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+	if len(first) != 64 {
+		t.Errorf("Fingerprint() = %q, want a hex SHA-256", first)
+	}
+	second, _ := Fingerprint()
+	if second != first {
+		t.Errorf("Fingerprint() changed between calls: %q, %q", first, second)
+	}
+}
@@ -40,6 +40,27 @@
 //	line := res.Node.Line
 //	column := res.Node.Column
 //
+// Any match path can be mapped back to its YAML node:
+//
+//	loc := tmpl.ResolvePath([]string{"Resources", "MyBucket", "Properties", "Tags", "[0]"})
+//	line, column := loc.Node().Line, loc.Node().Column
+//
+// ElementSpan and NodeSpan return the full source range of an element,
+// including block scalars and multi-line flow collections:
+//
+//	span := tmpl.ElementSpan(loc)
+//	fmt.Println(span.EndLine, span.EndColumn)
+//
+// # Inline Suppressions
+//
+// Comment directives such as "# cfn-lint-disable-line E3012 -- reason",
+// "# cfn-lint-disable-next-line" and "# cfn-lint-disable" / "# cfn-lint-enable"
+// blocks are collected into Template.Suppressions:
+//
+//	if s := tmpl.SuppressionFor("E3012", line); s != nil {
+//	    fmt.Println("suppressed:", s.Reason)
+//	}
+//
 // # Intrinsic Functions
 //
 // CloudFormation intrinsic functions are parsed into their long-form map representation:
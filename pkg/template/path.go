@@ -0,0 +1,127 @@
+package template
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PathNode is the result of resolving a match path against the YAML tree.
+type PathNode struct {
+	// Key is the mapping key node of the resolved element. It is nil for
+	// list items and the document root.
+	Key *yaml.Node
+
+	// Value is the node of the resolved element.
+	Value *yaml.Node
+
+	// Depth is the number of path elements that were resolved. It is less
+	// than the path length when the path leaves the YAML tree, for example
+	// inside a short-form intrinsic function.
+	Depth int
+}
+
+// Exact reports whether the whole path of length n was resolved.
+func (p PathNode) Exact(n int) bool {
+	return p.Depth == n
+}
+
+// Node returns the node a match on this element should point at: the key
+// of a mapping entry, or the value itself.
+func (p PathNode) Node() *yaml.Node {
+	if p.Key != nil {
+		return p.Key
+	}
+	return p.Value
+}
+
+// ResolvePath maps a match path such as
+//
+//	[]string{"Resources", "MyBucket", "Properties", "Tags", "[0]", "Key"}
+//
+// to the YAML node it refers to. List indexes are written as "[0]" (a plain
+// "0" is also accepted), and the long-form name of a short-form intrinsic
+// function tag ("Fn::Sub" for !Sub, "Ref" for !Ref) resolves to the tagged
+// node. When the path cannot be fully resolved the deepest element reached
+// is returned. ResolvePath returns nil when the template has no YAML tree.
+func (t *Template) ResolvePath(path []string) *PathNode {
+	if t.Root == nil {
+		return nil
+	}
+
+	node := t.Root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+
+	result := &PathNode{Value: node}
+	for _, segment := range path {
+		key, value := childNode(result.Value, segment)
+		if value == nil {
+			break
+		}
+		result.Key, result.Value = key, value
+		result.Depth++
+	}
+	return result
+}
+
+// childNode returns the key and value nodes of segment within node.
+func childNode(node *yaml.Node, segment string) (*yaml.Node, *yaml.Node) {
+	node = resolveAlias(node)
+
+	// Short-form intrinsic functions: the tag stands in for the
+	// long-form mapping key
+	if name := intrinsicTagName(node.Tag); name != "" && name == segment {
+		return nil, node
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i], resolveAlias(node.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		index, ok := listIndex(segment)
+		if ok && index < len(node.Content) {
+			return nil, resolveAlias(node.Content[index])
+		}
+	}
+	return nil, nil
+}
+
+// intrinsicTagName returns the long-form name of a CloudFormation short-form
+// tag, e.g. "Fn::Sub" for "!Sub", or "" for other tags.
+func intrinsicTagName(tag string) string {
+	if !strings.HasPrefix(tag, "!") || strings.HasPrefix(tag, "!!") {
+		return ""
+	}
+	name := tag[1:]
+	if name == "Ref" || name == "Condition" {
+		return name
+	}
+	return "Fn::" + name
+}
+
+// listIndex parses a list index segment, "[3]" or "3".
+func listIndex(segment string) (int, bool) {
+	segment = strings.TrimSuffix(strings.TrimPrefix(segment, "["), "]")
+	index, err := strconv.Atoi(segment)
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
@@ -0,0 +1,67 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestResolvePath(t *testing.T) {
+	yaml := `AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-data"
+      Tags:
+        - Key: Name
+          Value: !If [IsProd, prod, dev]
+  Shared: &shared
+    Type: AWS::SNS::Topic
+  Alias: *shared
+`
+	tmpl, err := Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		path   []string
+		line   int
+		column int
+		exact  bool
+	}{
+		{"section", []string{"Resources"}, 2, 1, true},
+		{"resource", []string{"Resources", "MyBucket"}, 3, 3, true},
+		{"property", []string{"Resources", "MyBucket", "Properties", "BucketName"}, 6, 7, true},
+		{"short-form intrinsic", []string{"Resources", "MyBucket", "Properties", "BucketName", "Fn::Sub"}, 6, 19, true},
+		{"list item", []string{"Resources", "MyBucket", "Properties", "Tags", "[0]"}, 8, 11, true},
+		{"plain list index", []string{"Resources", "MyBucket", "Properties", "Tags", "0", "Key"}, 8, 11, true},
+		{"intrinsic list item", []string{"Resources", "MyBucket", "Properties", "Tags", "[0]", "Value", "Fn::If", "[1]"}, 9, 31, true},
+		{"alias", []string{"Resources", "Alias", "Type"}, 11, 5, true},
+		{"missing key", []string{"Resources", "MyBucket", "Properties", "Missing"}, 5, 5, false},
+		{"index out of range", []string{"Resources", "MyBucket", "Properties", "Tags", "[5]"}, 7, 7, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved := tmpl.ResolvePath(tc.path)
+			if resolved == nil {
+				t.Fatal("ResolvePath returned nil")
+			}
+			node := resolved.Node()
+			if node.Line != tc.line || node.Column != tc.column {
+				t.Errorf("Expected %d:%d, got %d:%d", tc.line, tc.column, node.Line, node.Column)
+			}
+			if resolved.Exact(len(tc.path)) != tc.exact {
+				t.Errorf("Expected exact=%v, got depth %d", tc.exact, resolved.Depth)
+			}
+		})
+	}
+}
+
+func TestResolvePath_NoRoot(t *testing.T) {
+	tmpl := &Template{}
+	if tmpl.ResolvePath([]string{"Resources"}) != nil {
+		t.Error("Expected nil without a YAML tree")
+	}
+}
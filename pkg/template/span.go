@@ -0,0 +1,284 @@
+package template
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Span is a range of source positions. Lines and columns are 1-based and
+// count characters, as yaml.Node positions do, and EndColumn is the column
+// after the last character of the range.
+type Span struct {
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+}
+
+// NodeSpan returns the source range of a node, including any tag or anchor
+// in front of it. Block and flow collections, quoted and block scalars may
+// span several lines.
+func (t *Template) NodeSpan(node *yaml.Node) Span {
+	endLine, endColumn := t.nodeEnd(node)
+	return Span{StartLine: node.Line, StartColumn: node.Column, EndLine: endLine, EndColumn: endColumn}
+}
+
+// ContentSpan is like NodeSpan but starts after any tag or anchor, so for
+// !Sub "text" it covers "text".
+func (t *Template) ContentSpan(node *yaml.Node) Span {
+	span := t.NodeSpan(node)
+	span.StartLine, span.StartColumn = t.skipProperties(node)
+	return span
+}
+
+// SourceLine returns a line of the template source, without its line
+// terminator. Lines are 1-based.
+func (t *Template) SourceLine(line int) (string, bool) {
+	if !t.hasLine(line) {
+		return "", false
+	}
+	return t.line(line), true
+}
+
+// SourceText returns the template source covered by a span, with lines
+// joined by "\n". Columns count characters, as in yaml.Node positions.
+func (t *Template) SourceText(span Span) string {
+	var lines []string
+	for l := span.StartLine; l <= span.EndLine && t.hasLine(l); l++ {
+		runes := []rune(t.line(l))
+		start, end := 0, len(runes)
+		if l == span.StartLine {
+			start = min(max(span.StartColumn-1, 0), len(runes))
+		}
+		if l == span.EndLine {
+			end = min(max(span.EndColumn-1, start), len(runes))
+		}
+		lines = append(lines, string(runes[start:end]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ElementSpan returns the range to report for a resolved path element.
+// A mapping entry spans from its key to the end of its value. Collections
+// that cover several lines are cut short so a match on, say, a resource does
+// not cover the whole resource: mapping entries then only cover the key, and
+// list items only their first line.
+func (t *Template) ElementSpan(p *PathNode) Span {
+	value := p.Value
+	valueSpan := t.NodeSpan(value)
+	startLine := valueSpan.StartLine
+	if p.Key != nil {
+		startLine = p.Key.Line
+	}
+	multiLineCollection := (value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode) &&
+		valueSpan.EndLine > startLine
+
+	if p.Key == nil {
+		if multiLineCollection && t.hasLine(valueSpan.StartLine) {
+			valueSpan.EndLine = valueSpan.StartLine
+			valueSpan.EndColumn = utf8.RuneCountInString(strings.TrimRight(t.line(valueSpan.StartLine), " \t")) + 1
+		}
+		return valueSpan
+	}
+
+	span := t.NodeSpan(p.Key)
+	if !multiLineCollection {
+		span.EndLine, span.EndColumn = valueSpan.EndLine, valueSpan.EndColumn
+	}
+	return span
+}
+
+// nodeEnd returns the position after the last character of a node.
+func (t *Template) nodeEnd(node *yaml.Node) (int, int) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return node.Line, node.Column + 1 + utf8.RuneCountInString(node.Value)
+
+	case yaml.MappingNode, yaml.SequenceNode:
+		if node.Style&yaml.FlowStyle != 0 || len(node.Content) == 0 {
+			if line, col, ok := t.scanFlow(node); ok {
+				return line, col
+			}
+			return node.Line, node.Column + 2
+		}
+		return t.nodeEnd(node.Content[len(node.Content)-1])
+
+	case yaml.ScalarNode:
+		return t.scalarEnd(node)
+	}
+	return node.Line, node.Column
+}
+
+// scalarEnd returns the position after the last character of a scalar.
+func (t *Template) scalarEnd(node *yaml.Node) (int, int) {
+	line, col := t.skipProperties(node)
+
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return t.blockScalarEnd(node)
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		if l, c, ok := t.scanQuoted(line, col, '"'); ok {
+			return l, c
+		}
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		if l, c, ok := t.scanQuoted(line, col, '\''); ok {
+			return l, c
+		}
+	}
+
+	// Plain scalars are written as-is; multi-line plain scalars are
+	// approximated by their length on the first line
+	return line, col + utf8.RuneCountInString(node.Value)
+}
+
+// blockScalarEnd returns the end of a literal or folded block scalar: the
+// last non-blank line indented deeper than the line holding the indicator.
+func (t *Template) blockScalarEnd(node *yaml.Node) (int, int) {
+	if !t.hasLine(node.Line) {
+		lines := strings.Split(strings.TrimRight(node.Value, "\n"), "\n")
+		return node.Line + len(lines), utf8.RuneCountInString(lines[len(lines)-1]) + 1
+	}
+
+	indent := indentation(t.line(node.Line))
+	endLine, endColumn := node.Line, utf8.RuneCountInString(t.line(node.Line))+1
+	for l := node.Line + 1; t.hasLine(l); l++ {
+		text := t.line(l)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if indentation(text) <= indent {
+			break
+		}
+		endLine, endColumn = l, utf8.RuneCountInString(strings.TrimRight(text, " \t"))+1
+	}
+	return endLine, endColumn
+}
+
+// skipProperties returns the position of a node's content, after any tag
+// (such as !Sub) or anchor in front of it.
+func (t *Template) skipProperties(node *yaml.Node) (int, int) {
+	line, col := node.Line, node.Column
+	if !t.hasLine(line) {
+		return line, col
+	}
+	text := t.line(line)
+	i := byteIndex(text, col)
+	if i >= len(text) || (text[i] != '!' && text[i] != '&') {
+		return line, col
+	}
+	for i < len(text) && (text[i] == '!' || text[i] == '&') {
+		// Skip the token and the whitespace after it
+		for i < len(text) && text[i] != ' ' && text[i] != '\t' {
+			i++
+		}
+		for i < len(text) && (text[i] == ' ' || text[i] == '\t') {
+			i++
+		}
+	}
+	return line, column(text, i)
+}
+
+// scanQuoted finds the closing quote of a quoted scalar starting at the
+// opening quote.
+func (t *Template) scanQuoted(line, col int, quote byte) (int, int, bool) {
+	if !t.hasLine(line) {
+		return 0, 0, false
+	}
+	i := byteIndex(t.line(line), col)
+	if i >= len(t.line(line)) || t.line(line)[i] != quote {
+		return 0, 0, false
+	}
+
+	i++ // index after the opening quote
+	for l := line; t.hasLine(l); l++ {
+		text := t.line(l)
+		for ; i < len(text); i++ {
+			switch {
+			case quote == '"' && text[i] == '\\':
+				i++
+			case text[i] == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+				i++
+			case text[i] == quote:
+				return l, column(text, i+1), true
+			}
+		}
+		i = 0
+	}
+	return 0, 0, false
+}
+
+// scanFlow finds the closing bracket of a flow collection.
+func (t *Template) scanFlow(node *yaml.Node) (int, int, bool) {
+	line, col := t.skipProperties(node)
+	if !t.hasLine(line) {
+		return 0, 0, false
+	}
+
+	depth := 0
+	var quote byte
+	i := byteIndex(t.line(line), col)
+	for l := line; t.hasLine(l); l++ {
+		text := t.line(l)
+		for ; i < len(text); i++ {
+			c := text[i]
+			switch {
+			case quote != 0:
+				if quote == '"' && c == '\\' {
+					i++
+				} else if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+				i = len(text) // comment runs to the end of the line
+			case c == '[' || c == '{':
+				depth++
+			case c == ']' || c == '}':
+				depth--
+				if depth == 0 {
+					return l, column(text, i+1), true
+				}
+			}
+		}
+		i = 0
+	}
+	return 0, 0, false
+}
+
+func (t *Template) hasLine(line int) bool {
+	return line >= 1 && line <= len(t.lines)
+}
+
+// line returns a source line without its line terminator.
+func (t *Template) line(line int) string {
+	return strings.TrimRight(t.lines[line-1], "\r")
+}
+
+func indentation(text string) int {
+	return len(text) - len(strings.TrimLeft(text, " "))
+}
+
+// byteIndex returns the byte offset in text of a 1-based character column.
+// Columns past the end of text map past its end.
+func byteIndex(text string, col int) int {
+	i := 0
+	for n := 1; n < col; n++ {
+		if i >= len(text) {
+			return len(text) + col - n
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return i
+}
+
+// column returns the 1-based character column of a byte offset in text.
+func column(text string, i int) int {
+	if i > len(text) {
+		return utf8.RuneCountInString(text) + 1 + i - len(text)
+	}
+	return utf8.RuneCountInString(text[:i]) + 1
+}
@@ -0,0 +1,142 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestElementSpan(t *testing.T) {
+	yaml := `Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-data"
+      Quoted: 'it''s'
+      Tags: [{Key: Name, Value: data}]
+      Script: |
+        echo one
+        echo two
+
+      Empty: {}
+  Other: {"Type": "AWS::SNS::Topic",
+    "Properties": {}}
+`
+	tmpl, err := Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	props := []string{"Resources", "MyBucket", "Properties"}
+	tests := []struct {
+		name string
+		path []string
+		want Span
+	}{
+		{"plain scalar", append(props[:2:2], "Type"), Span{3, 5, 3, 26}},
+		{"block mapping covers key", props, Span{4, 5, 4, 15}},
+		{"tagged double-quoted scalar", append(props[:3:3], "BucketName"), Span{5, 7, 5, 48}},
+		{"single-quoted scalar", append(props[:3:3], "Quoted"), Span{6, 7, 6, 22}},
+		{"flow sequence", append(props[:3:3], "Tags"), Span{7, 7, 7, 39}},
+		{"flow mapping item", append(props[:3:3], "Tags", "[0]"), Span{7, 14, 7, 38}},
+		{"block scalar", append(props[:3:3], "Script"), Span{8, 7, 10, 17}},
+		{"empty flow mapping", append(props[:3:3], "Empty"), Span{12, 7, 12, 16}},
+		{"multi-line flow mapping covers key", []string{"Resources", "Other"}, Span{13, 3, 13, 8}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved := tmpl.ResolvePath(tc.path)
+			if !resolved.Exact(len(tc.path)) {
+				t.Fatalf("Path %v did not resolve", tc.path)
+			}
+			if got := tmpl.ElementSpan(resolved); got != tc.want {
+				t.Errorf("Expected span %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestContentSpanAndSourceText(t *testing.T) {
+	yaml := `Resources:
+  MyBucket:
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-data"
+      Name: "café"
+      Script: |
+        echo one
+        echo two
+`
+	tmpl, err := Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	props := []string{"Resources", "MyBucket", "Properties"}
+
+	node := tmpl.ResolvePath(append(props[:3:3], "BucketName")).Value
+	span := tmpl.ContentSpan(node)
+	if want := (Span{4, 24, 4, 48}); span != want {
+		t.Errorf("Expected content span %+v, got %+v", want, span)
+	}
+	if got := tmpl.SourceText(span); got != `"${AWS::StackName}-data"` {
+		t.Errorf("Unexpected source text %q", got)
+	}
+
+	// Columns count characters
+	node = tmpl.ResolvePath(append(props[:3:3], "Name")).Value
+	if got := tmpl.SourceText(tmpl.NodeSpan(node)); got != `"café"` {
+		t.Errorf("Unexpected source text %q", got)
+	}
+
+	node = tmpl.ResolvePath(append(props[:3:3], "Script")).Value
+	if got := tmpl.SourceText(tmpl.NodeSpan(node)); got != "|\n        echo one\n        echo two" {
+		t.Errorf("Unexpected source text %q", got)
+	}
+
+	if line, ok := tmpl.SourceLine(2); !ok || line != "  MyBucket:" {
+		t.Errorf("SourceLine(2) = %q, %v", line, ok)
+	}
+	if _, ok := tmpl.SourceLine(100); ok {
+		t.Error("Expected no line 100")
+	}
+}
+
+func TestSpan_NonASCII(t *testing.T) {
+	yaml := `Resources:
+  Café:
+    Properties:
+      List: ["é", "ü", !Ref Ünnep]
+      Flow: {Név: "ő"}
+      Plain: ÁÉ # ü
+`
+	tmpl, err := Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	props := []string{"Resources", "Café", "Properties"}
+
+	tests := []struct {
+		path []string
+		want Span
+		text string
+	}{
+		{append(props[:3:3], "List", "[1]"), Span{4, 19, 4, 22}, `"ü"`},
+		{append(props[:3:3], "List", "[2]"), Span{4, 24, 4, 34}, `!Ref Ünnep`},
+		{append(props[:3:3], "List"), Span{4, 13, 4, 35}, `["é", "ü", !Ref Ünnep]`},
+		{append(props[:3:3], "Flow"), Span{5, 13, 5, 23}, `{Név: "ő"}`},
+		{append(props[:3:3], "Plain"), Span{6, 14, 6, 16}, `ÁÉ`},
+	}
+	for _, tt := range tests {
+		node := tmpl.ResolvePath(tt.path).Value
+		span := tmpl.NodeSpan(node)
+		if span != tt.want {
+			t.Errorf("NodeSpan(%v) = %+v, want %+v", tt.path, span, tt.want)
+		}
+		if got := tmpl.SourceText(span); got != tt.text {
+			t.Errorf("SourceText(%v) = %q, want %q", tt.path, got, tt.text)
+		}
+	}
+
+	node := tmpl.ResolvePath(append(props[:3:3], "List", "[2]")).Value
+	if got := tmpl.SourceText(tmpl.ContentSpan(node)); got != "Ünnep" {
+		t.Errorf("Expected the content after the tag, got %q", got)
+	}
+}
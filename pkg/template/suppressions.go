@@ -0,0 +1,204 @@
+package template
+
+import (
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Inline suppression directives recognised in YAML comments.
+const (
+	directiveDisable         = "cfn-lint-disable"
+	directiveEnable          = "cfn-lint-enable"
+	directiveDisableLine     = "cfn-lint-disable-line"
+	directiveDisableNextLine = "cfn-lint-disable-next-line"
+)
+
+// Suppression is a range of lines in which matches are suppressed by an
+// inline comment directive such as:
+//
+//	BucketName: MyBucket # cfn-lint-disable-line E3012 -- legacy name
+//	# cfn-lint-disable-next-line W3005
+//	# cfn-lint-disable E3012, W2001 -- generated section
+//	# cfn-lint-enable
+type Suppression struct {
+	// Rules lists the suppressed rule IDs. Empty means every rule.
+	Rules []string
+
+	// StartLine and EndLine bound the suppressed lines, inclusive.
+	// An EndLine of zero extends the suppression to the end of the file.
+	StartLine int
+	EndLine   int
+
+	// Reason is the justification given after "--", if any.
+	Reason string
+
+	// Line is the line of the directive comment.
+	Line int
+}
+
+// Covers reports whether the suppression applies to a rule match on line.
+func (s Suppression) Covers(ruleID string, line int) bool {
+	if line < s.StartLine || (s.EndLine > 0 && line > s.EndLine) {
+		return false
+	}
+	if len(s.Rules) == 0 {
+		return true
+	}
+	for _, id := range s.Rules {
+		if id == ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+// SuppressionFor returns the first suppression covering a rule match on line,
+// or nil if the match is not suppressed.
+func (t *Template) SuppressionFor(ruleID string, line int) *Suppression {
+	for i := range t.Suppressions {
+		if t.Suppressions[i].Covers(ruleID, line) {
+			return &t.Suppressions[i]
+		}
+	}
+	return nil
+}
+
+// parseSuppressions extracts suppression directives from the template source.
+// Only text that the YAML parser also saw as a comment is considered, so
+// directive-like text inside strings and block scalars is ignored.
+func parseSuppressions(data []byte, root *yaml.Node) []Suppression {
+	comments := make(map[string]bool)
+	collectComments(root, comments)
+	if len(comments) == 0 {
+		return nil
+	}
+
+	type openBlock struct {
+		line   int
+		reason string
+	}
+	var result []Suppression
+	open := make(map[string]openBlock) // rule ID ("" for all rules) -> block
+	var openOrder []string             // rule IDs in the order their first block opened
+
+	closeBlock := func(id string, endLine int) {
+		block, ok := open[id]
+		if !ok {
+			return
+		}
+		s := Suppression{StartLine: block.line, EndLine: endLine, Reason: block.reason, Line: block.line}
+		if id != "" {
+			s.Rules = []string{id}
+		}
+		result = append(result, s)
+		delete(open, id)
+	}
+
+	for i, text := range strings.Split(string(data), "\n") {
+		line := i + 1
+		comment := findComment(text, comments)
+		if comment == "" {
+			continue
+		}
+		directive, ids, reason := parseDirective(comment)
+
+		switch directive {
+		case directiveDisableLine:
+			result = append(result, Suppression{Rules: ids, StartLine: line, EndLine: line, Reason: reason, Line: line})
+		case directiveDisableNextLine:
+			result = append(result, Suppression{Rules: ids, StartLine: line + 1, EndLine: line + 1, Reason: reason, Line: line})
+		case directiveDisable:
+			if len(ids) == 0 {
+				ids = []string{""}
+			}
+			for _, id := range ids {
+				if _, ok := open[id]; !ok {
+					open[id] = openBlock{line: line, reason: reason}
+					if !slices.Contains(openOrder, id) {
+						openOrder = append(openOrder, id)
+					}
+				}
+			}
+		case directiveEnable:
+			if len(ids) == 0 {
+				for _, id := range openOrder {
+					closeBlock(id, line)
+				}
+				continue
+			}
+			for _, id := range ids {
+				closeBlock(id, line)
+			}
+		}
+	}
+
+	// Unterminated blocks run to the end of the file
+	for _, id := range openOrder {
+		closeBlock(id, 0)
+	}
+
+	return result
+}
+
+// collectComments records every comment line in the node tree.
+func collectComments(node *yaml.Node, comments map[string]bool) {
+	if node == nil {
+		return
+	}
+	for _, c := range []string{node.HeadComment, node.LineComment, node.FootComment} {
+		for _, line := range strings.Split(c, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				comments[line] = true
+			}
+		}
+	}
+	for _, child := range node.Content {
+		collectComments(child, comments)
+	}
+}
+
+// findComment returns the comment on a source line, if it is a known comment.
+func findComment(text string, comments map[string]bool) string {
+	for i := 0; i < len(text); i++ {
+		if text[i] != '#' || (i > 0 && text[i-1] != ' ' && text[i-1] != '\t') {
+			continue
+		}
+		if comment := strings.TrimSpace(text[i:]); comments[comment] {
+			return comment
+		}
+	}
+	return ""
+}
+
+// parseDirective splits a comment into its directive, rule IDs and reason.
+// Comments that are not directives return an empty directive.
+func parseDirective(comment string) (string, []string, string) {
+	body := strings.TrimSpace(strings.TrimPrefix(comment, "#"))
+
+	var reason string
+	if idx := strings.Index(body, "--"); idx >= 0 {
+		reason = strings.TrimSpace(body[idx+2:])
+		body = body[:idx]
+	}
+
+	fields := strings.FieldsFunc(body, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+	if len(fields) == 0 {
+		return "", nil, ""
+	}
+
+	switch fields[0] {
+	case directiveDisable, directiveEnable, directiveDisableLine, directiveDisableNextLine:
+	default:
+		return "", nil, ""
+	}
+
+	var ids []string
+	for _, f := range fields[1:] {
+		ids = append(ids, strings.ToUpper(f))
+	}
+	return fields[0], ids, reason
+}
@@ -0,0 +1,99 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	yaml := `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket # cfn-lint-disable-line E3012 -- legacy type
+  # cfn-lint-disable-next-line W3005, w2001
+  Queue:
+    Type: AWS::SQS::Queue
+  # cfn-lint-disable -- generated section
+  Topic:
+    Type: AWS::SNS::Topic
+  # cfn-lint-enable
+  Script:
+    Type: AWS::SSM::Document
+    Properties:
+      Content: |
+        # cfn-lint-disable
+        echo hello
+  Other:
+    Type: AWS::SNS::Topic # not a directive
+  # cfn-lint-disable E1001
+  Last:
+    Type: AWS::SNS::Topic
+`
+	tmpl, err := Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		ruleID string
+		line   int
+		want   bool
+		reason string
+	}{
+		{"disable-line matching rule", "E3012", 3, true, "legacy type"},
+		{"disable-line other rule", "E3002", 3, false, ""},
+		{"disable-line other line", "E3012", 4, false, ""},
+		{"disable-next-line", "W3005", 5, true, ""},
+		{"disable-next-line second ID uppercased", "W2001", 5, true, ""},
+		{"disable-next-line only next line", "W3005", 6, false, ""},
+		{"block all rules", "E3002", 8, true, "generated section"},
+		{"block ends at enable", "E3002", 11, false, ""},
+		{"block scalar text ignored", "E3002", 16, false, ""},
+		{"unterminated block", "E1001", 21, true, ""},
+		{"unterminated block other rule", "E3002", 21, false, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tmpl.SuppressionFor(tc.ruleID, tc.line)
+			if (s != nil) != tc.want {
+				t.Fatalf("SuppressionFor(%s, %d) = %+v, want suppressed=%v", tc.ruleID, tc.line, s, tc.want)
+			}
+			if s != nil && s.Reason != tc.reason {
+				t.Errorf("Expected reason %q, got %q", tc.reason, s.Reason)
+			}
+		})
+	}
+}
+
+func TestParseSuppressions_ReopenedBlock(t *testing.T) {
+	yaml := `Resources:
+  # cfn-lint-disable E1001
+  A:
+    Type: AWS::SNS::Topic
+  # cfn-lint-enable
+  # cfn-lint-disable E1001
+  B:
+    Type: AWS::SNS::Topic
+  # cfn-lint-enable
+`
+	tmpl, err := Parse([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(tmpl.Suppressions) != 2 {
+		t.Fatalf("Expected 2 suppressions, got %+v", tmpl.Suppressions)
+	}
+	if s := tmpl.Suppressions[1]; s.StartLine != 6 || s.EndLine != 9 {
+		t.Errorf("Expected the second block to cover lines 6-9, got %+v", s)
+	}
+}
+
+func TestParseSuppressions_NoComments(t *testing.T) {
+	tmpl, err := Parse([]byte("Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(tmpl.Suppressions) != 0 {
+		t.Errorf("Expected no suppressions, got %v", tmpl.Suppressions)
+	}
+}
@@ -32,8 +32,19 @@ type Template struct {
 	ConditionsNode *yaml.Node
 	RulesNode      *yaml.Node
 
+	// Suppressions are the inline cfn-lint-disable comment directives.
+	Suppressions []Suppression
+
 	// Filename for error reporting.
 	Filename string
+
+	// Source is the template as written when this one was rebuilt from it,
+	// for example by expanding a transform, and nil otherwise.
+	// ReferenceValues includes it.
+	Source *Template
+
+	// lines holds the source text, used to compute node spans.
+	lines []string
 }
 
 // Mapping represents a CloudFormation mapping.
@@ -119,8 +130,39 @@ func Parse(data []byte) (*Template, error) {
 		return nil, fmt.Errorf("parsing YAML: %w", err)
 	}
 
-	tmpl := &Template{
-		Root:       &root,
+	tmpl := newTemplate(&root)
+	if err := tmpl.parseRoot(); err != nil {
+		return nil, err
+	}
+	tmpl.Suppressions = parseSuppressions(data, &root)
+	tmpl.lines = strings.Split(string(data), "\n")
+
+	return tmpl, nil
+}
+
+// Rebuild returns a template parsed from root, a rewritten copy of t.Root
+// whose nodes keep the positions they have in t's source. The new template
+// shares t's source text, suppressions and filename, so spans and matches
+// found in it point at the original file, and its Source is the template
+// as written.
+func (t *Template) Rebuild(root *yaml.Node) (*Template, error) {
+	tmpl := newTemplate(root)
+	if err := tmpl.parseRoot(); err != nil {
+		return nil, err
+	}
+	tmpl.Suppressions = t.Suppressions
+	tmpl.Filename = t.Filename
+	tmpl.lines = t.lines
+	tmpl.Source = t
+	if t.Source != nil {
+		tmpl.Source = t.Source
+	}
+	return tmpl, nil
+}
+
+func newTemplate(root *yaml.Node) *Template {
+	return &Template{
+		Root:       root,
 		Parameters: make(map[string]*Parameter),
 		Mappings:   make(map[string]*Mapping),
 		Conditions: make(map[string]*Condition),
@@ -129,12 +171,6 @@ func Parse(data []byte) (*Template, error) {
 		Metadata:   make(map[string]any),
 		Rules:      make(map[string]*Rule),
 	}
-
-	if err := tmpl.parseRoot(); err != nil {
-		return nil, err
-	}
-
-	return tmpl, nil
 }
 
 func (t *Template) parseRoot() error {
@@ -453,6 +489,13 @@ func (t *Template) parseRules(node *yaml.Node) error {
 	return nil
 }
 
+// DecodeNode converts a node to the Go values used in Resource.Properties
+// and the other parsed sections, with intrinsic function tags in their
+// long form.
+func DecodeNode(node *yaml.Node) any {
+	return parseYAMLNode(node)
+}
+
 // parseYAMLNode recursively converts a yaml.Node to Go values, handling CF intrinsic tags.
 func parseYAMLNode(node *yaml.Node) any {
 	if node == nil {
@@ -699,6 +742,32 @@ func (t *Template) GetParameterNames() []string {
 	return names
 }
 
+// ReferenceValues returns the values to search for references to
+// parameters, mappings and conditions: resource properties, output values
+// and condition expressions. A transform such as AWS::LanguageExtensions
+// may resolve references away, so when the template was rebuilt from
+// Source, the Resources and Outputs sections as written are included too.
+func (t *Template) ReferenceValues() []any {
+	values := make([]any, 0, len(t.Resources)+len(t.Outputs)+len(t.Conditions)+2)
+	for _, res := range t.Resources {
+		values = append(values, res.Properties)
+	}
+	for _, out := range t.Outputs {
+		values = append(values, out.Value)
+	}
+	for _, cond := range t.Conditions {
+		values = append(values, cond.Expression)
+	}
+	if t.Source != nil {
+		for _, section := range []string{"Resources", "Outputs"} {
+			if p := t.Source.ResolvePath([]string{section}); p != nil && p.Exact(1) {
+				values = append(values, DecodeNode(p.Value))
+			}
+		}
+	}
+	return values
+}
+
 // HasResource checks if a resource with the given logical ID exists.
 func (t *Template) HasResource(name string) bool {
 	_, ok := t.Resources[name]
@@ -113,3 +113,83 @@ Resources:
 		t.Error("Expected HasParameter('NonExistent') to be false")
 	}
 }
+
+func TestReferenceValues(t *testing.T) {
+	yaml := `
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Ref Name
+Outputs:
+  Arn:
+    Value: !GetAtt MyBucket.Arn
+`
+	tmpl, _ := Parse([]byte(yaml))
+	if got := len(tmpl.ReferenceValues()); got != 3 {
+		t.Errorf("Expected 3 values, got %d", got)
+	}
+
+	rebuilt, err := tmpl.Rebuild(tmpl.Root)
+	if err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	if got := len(rebuilt.ReferenceValues()); got != 5 {
+		t.Errorf("Expected the Resources and Outputs as written as two more values, got %d", got)
+	}
+}
+
+func TestReferenceValues_SourceSections(t *testing.T) {
+	yaml := `
+Metadata:
+  Docs: !Ref InMetadata
+Rules:
+  Check:
+    Assertions:
+      - Assert: !Equals [!Ref InRules, prod]
+Resources:
+  MyBucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Ref InResources
+`
+	tmpl, _ := Parse([]byte(yaml))
+	rebuilt, err := tmpl.Rebuild(tmpl.Root)
+	if err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	values := rebuilt.ReferenceValues()
+	if !refersTo(values, "InResources") {
+		t.Error("Expected a Ref in Resources to count as a use")
+	}
+	for _, name := range []string{"InMetadata", "InRules"} {
+		if refersTo(values, name) {
+			t.Errorf("Expected a Ref to %s outside Resources and Outputs not to count as a use", name)
+		}
+	}
+}
+
+// refersTo reports whether v contains a Ref to name.
+func refersTo(v any, name string) bool {
+	switch val := v.(type) {
+	case map[string]any:
+		if val["Ref"] == name {
+			return true
+		}
+		for _, child := range val {
+			if refersTo(child, name) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range val {
+			if refersTo(child, name) {
+				return true
+			}
+		}
+	}
+	return false
+}